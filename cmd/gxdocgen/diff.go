@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/diff"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/generator"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// Output formats of the diff subcommand
+const (
+	diffFormatMarkdown = "markdown"
+	diffFormatJSON     = "json"
+)
+
+// runDiff implements the diff subcommand and returns the process exit code
+func runDiff(args []string) int {
+	var (
+		oldPath    string
+		newPath    string
+		outputPath string
+		format     string
+		failOnBrk  bool
+	)
+
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.StringVar(&oldPath, "old", "", "Path to the previous XPZ export (required)")
+	fs.StringVar(&newPath, "new", "", "Path to the new XPZ export (required)")
+	fs.StringVar(&outputPath, "output", "", "File to write the report to (default: standard output)")
+	fs.StringVar(&format, "format", diffFormatMarkdown, "Report format: 'markdown' or 'json'")
+	fs.BoolVar(&failOnBrk, "fail-on-breaking", false, "Exit with an error when the new export has breaking changes")
+	fs.Usage = printDiffUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if oldPath == "" || newPath == "" {
+		utils.Error("Missing required flags: --old and --new")
+		fmt.Println()
+		printDiffUsage()
+		return 1
+	}
+	if format != diffFormatMarkdown && format != diffFormatJSON {
+		utils.Error("Invalid --format value '%s' (expected 'markdown' or 'json')", format)
+		return 1
+	}
+	for _, path := range []string{oldPath, newPath} {
+		if err := validateInput(path); err != nil {
+			utils.Error("Invalid input: %v", err)
+			return 1
+		}
+	}
+
+	// Keep standard output clean for the report itself
+	if outputPath == "" {
+		utils.SetLevel(utils.LevelQuiet)
+	}
+
+	oldResult, err := xpz.Extract(oldPath)
+	if err != nil {
+		utils.Error("Failed to extract %s: %v", oldPath, err)
+		return 1
+	}
+	newResult, err := xpz.Extract(newPath)
+	if err != nil {
+		utils.Error("Failed to extract %s: %v", newPath, err)
+		return 1
+	}
+
+	result := diff.Compare(oldResult.Objects, newResult.Objects)
+
+	var report bytes.Buffer
+	if format == diffFormatJSON {
+		// Signatures are full of '&', keep them readable
+		encoder := json.NewEncoder(&report)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result.Report()); err != nil {
+			utils.Error("Failed to encode report: %v", err)
+			return 1
+		}
+	} else {
+		report.WriteString(generator.RenderDiff(result, oldPath, newPath))
+	}
+
+	if outputPath == "" {
+		os.Stdout.Write(report.Bytes())
+	} else {
+		if err := os.WriteFile(outputPath, report.Bytes(), 0644); err != nil {
+			utils.Error("Failed to write diff report: %v", err)
+			return 1
+		}
+		utils.Success("Diff report written to: %s", outputPath)
+		utils.Info("%d added, %d removed, %d changed object(s)", len(result.Added), len(result.Removed), len(result.Changed))
+	}
+
+	if breaking := result.BreakingChanges(); failOnBrk && len(breaking) > 0 {
+		utils.Error("%d breaking change(s) found (--fail-on-breaking)", len(breaking))
+		return 1
+	}
+	return 0
+}
+
+// printDiffUsage prints the usage information for the diff subcommand
+func printDiffUsage() {
+	fmt.Println("GXDocGen diff - Compare two XPZ exports")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s diff --old <xpz-file> --new <xpz-file> [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --old <path>         Previous XPZ export (required)")
+	fmt.Println("  --new <path>         New XPZ export (required)")
+	fmt.Println("  --output <path>      File to write the report to (default: standard output)")
+	fmt.Println("  --format <format>    Report format: 'markdown' (default) or 'json'")
+	fmt.Println("  --fail-on-breaking   Exit with code 1 when objects were removed or signatures broke")
+	fmt.Println()
+}
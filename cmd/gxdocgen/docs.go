@@ -0,0 +1,262 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// subcommand is a command dispatched by its first argument
+type subcommand struct {
+	name    string
+	summary string
+	run     func(args []string) int
+}
+
+// renderSummary describes the render alias of the main command
+const renderSummary = "Generate documentation from a model file (same flags, --model)"
+
+// subcommands lists the subcommands in the order they are documented
+func subcommands() []subcommand {
+	return []subcommand{
+		{"extract", "Extract an export into a model file (model.json)", runExtract},
+		{"validate", "Check an XPZ archive for suspicious or corrupt entries", runValidate},
+		{"lint", "Check doc comments against lint rules", runLint},
+		{"release-notes", "Generate release notes from two exports", runReleaseNotes},
+		{"diff", "Compare two exports (Markdown or JSON)", runDiff},
+		{"snapshot", "Compare generated output against golden files", runSnapshot},
+		{"fixture", "Build a synthetic XPZ export for testing", runFixture},
+		{"rewrite", "Suggest mechanical doc comment fixes as patch files", runRewrite},
+		{"repack", "Write an XPZ with doc comment stubs (experimental)", runRepack},
+		{"docs", "Generate the man page and Markdown CLI reference", runDocs},
+	}
+}
+
+// findSubcommand returns the subcommand called name
+func findSubcommand(name string) (subcommand, bool) {
+	for _, cmd := range subcommands() {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return subcommand{}, false
+}
+
+// describeFlags, when set, receives the flag set of a subcommand instead of
+// running it; the docs subcommand uses it to collect every flag definition
+var describeFlags func(fs *flag.FlagSet)
+
+// parseFlags parses args with fs and reports whether the subcommand should
+// run. While the CLI reference is generated it only records fs.
+func parseFlags(fs *flag.FlagSet, args []string) bool {
+	if describeFlags != nil {
+		describeFlags(fs)
+		return false
+	}
+	fs.Parse(args)
+	return true
+}
+
+// commandDoc is the documentation of one command
+type commandDoc struct {
+	name    string
+	summary string
+	usage   string
+	flags   []*flag.Flag
+}
+
+// runDocs implements the docs subcommand and returns the process exit code
+func runDocs(args []string) int {
+	var outputDir string
+
+	fs := flag.NewFlagSet("docs", flag.ExitOnError)
+	fs.StringVar(&outputDir, "output", ".", "Directory to write gxdocgen.1 and CLI.md to")
+	fs.Usage = printDocsUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	commands := collectCommandDocs()
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		utils.Error("Failed to create output directory: %v", err)
+		return 1
+	}
+	for name, content := range map[string]string{
+		"gxdocgen.1": manPage(commands),
+		"CLI.md":     markdownReference(commands),
+	} {
+		path := filepath.Join(outputDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			utils.Error("Failed to write %s: %v", path, err)
+			return 1
+		}
+		utils.Success("Written: %s", path)
+	}
+	return 0
+}
+
+// collectCommandDocs gathers the flags of the main command and of every
+// subcommand from their definitions
+func collectCommandDocs() []commandDoc {
+	commands := []commandDoc{{
+		name:    "gxdocgen",
+		summary: "Generate Markdown documentation from a GeneXus XPZ export",
+		usage:   "gxdocgen --input <xpz-file> [options]",
+		flags:   visibleFlags(flag.CommandLine),
+	}}
+	commands = append(commands, commandDoc{
+		name:    "gxdocgen render",
+		summary: renderSummary,
+		usage:   "gxdocgen render --model <model-file> [options]",
+	})
+
+	var described *flag.FlagSet
+	describeFlags = func(fs *flag.FlagSet) { described = fs }
+	defer func() { describeFlags = nil }()
+	for _, cmd := range subcommands() {
+		described = nil
+		cmd.run(nil)
+		doc := commandDoc{
+			name:    "gxdocgen " + cmd.name,
+			summary: cmd.summary,
+			usage:   "gxdocgen " + cmd.name + " [options]",
+		}
+		if described != nil {
+			doc.flags = visibleFlags(described)
+		}
+		commands = append(commands, doc)
+	}
+	return commands
+}
+
+// visibleFlags returns the flags of fs in name order, leaving out the
+// one-letter shorthands documented with their long form
+func visibleFlags(fs *flag.FlagSet) []*flag.Flag {
+	var flags []*flag.Flag
+	fs.VisitAll(func(f *flag.Flag) {
+		if len(f.Name) > 1 {
+			flags = append(flags, f)
+		}
+	})
+	return flags
+}
+
+// flagSynopsis renders a flag and its value name, e.g. "--input string"
+func flagSynopsis(f *flag.Flag) string {
+	name, _ := flag.UnquoteUsage(f)
+	if name == "" {
+		return "--" + f.Name
+	}
+	return "--" + f.Name + " " + name
+}
+
+// flagDefault returns the default worth documenting, or "" for zero values
+func flagDefault(f *flag.Flag) string {
+	switch f.DefValue {
+	case "", "0", "0s", "false", "[]":
+		return ""
+	}
+	return f.DefValue
+}
+
+// manPage renders the commands as a roff man page
+func manPage(commands []commandDoc) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(".TH GXDOCGEN 1 \"\" \"gxdocgen %s\" \"User Commands\"\n", version))
+	sb.WriteString(".SH NAME\n")
+	sb.WriteString("gxdocgen \\- GeneXus documentation generator\n")
+	sb.WriteString(".SH SYNOPSIS\n")
+	for i, cmd := range commands {
+		if i > 0 {
+			sb.WriteString(".br\n")
+		}
+		sb.WriteString(roffEscape(cmd.usage) + "\n")
+	}
+	sb.WriteString(".SH DESCRIPTION\n")
+	sb.WriteString(roffEscape(commands[0].summary) + ".\n")
+
+	sb.WriteString(".SH OPTIONS\n")
+	writeManFlags(&sb, commands[0].flags)
+
+	sb.WriteString(".SH COMMANDS\n")
+	for _, cmd := range commands[1:] {
+		sb.WriteString(".SS " + strings.TrimPrefix(cmd.name, "gxdocgen ") + "\n")
+		sb.WriteString(roffEscape(cmd.summary) + ".\n")
+		writeManFlags(&sb, cmd.flags)
+	}
+
+	sb.WriteString(".SH EXIT STATUS\n")
+	sb.WriteString(".TP\n0\nSuccess.\n")
+	sb.WriteString(".TP\n1\nFatal error, including failed \\-\\-strict, \\-\\-min\\-coverage and \\-\\-verify checks.\n")
+	sb.WriteString(".TP\n2\nCompleted with warnings under \\-\\-warnings\\-as\\-errors.\n")
+	return sb.String()
+}
+
+// writeManFlags appends a tagged paragraph per flag
+func writeManFlags(sb *strings.Builder, flags []*flag.Flag) {
+	for _, f := range flags {
+		_, usage := flag.UnquoteUsage(f)
+		sb.WriteString(".TP\n")
+		sb.WriteString("\\fB" + roffEscape(flagSynopsis(f)) + "\\fR\n")
+		sb.WriteString(roffEscape(usage))
+		if def := flagDefault(f); def != "" {
+			sb.WriteString(" (default: " + roffEscape(def) + ")")
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// roffEscape escapes backslashes and dashes, and keeps lines from starting
+// with a control character
+func roffEscape(text string) string {
+	text = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(text)
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = `\&` + text
+	}
+	return text
+}
+
+// markdownReference renders the commands as a Markdown CLI reference
+func markdownReference(commands []commandDoc) string {
+	var sb strings.Builder
+	sb.WriteString("# gxdocgen CLI Reference\n\n")
+	sb.WriteString(fmt.Sprintf("Generated from the flag definitions of gxdocgen %s with `gxdocgen docs`.\n\n", version))
+	for _, cmd := range commands {
+		sb.WriteString("## " + cmd.name + "\n\n")
+		sb.WriteString(cmd.summary + ".\n\n")
+		sb.WriteString("```\n" + cmd.usage + "\n```\n\n")
+		if len(cmd.flags) == 0 {
+			continue
+		}
+		sb.WriteString("| Flag | Default | Description |\n")
+		sb.WriteString("|------|---------|-------------|\n")
+		for _, f := range cmd.flags {
+			_, usage := flag.UnquoteUsage(f)
+			def := "-"
+			if d := flagDefault(f); d != "" {
+				def = "`" + d + "`"
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", flagSynopsis(f), def, strings.ReplaceAll(usage, "|", `\|`)))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// printDocsUsage prints the usage information for the docs subcommand
+func printDocsUsage() {
+	fmt.Println("GXDocGen docs - Generate the man page and Markdown CLI reference")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s docs [--output <dir>]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --output <dir>       Directory to write gxdocgen.1 and CLI.md to (default: .)")
+	fmt.Println()
+	fmt.Println("View the man page with: man ./gxdocgen.1")
+	fmt.Println()
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/config"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// runExtract implements the extract subcommand and returns the process exit code
+func runExtract(args []string) int {
+	var (
+		inputPath  string
+		modelPath  string
+		configPath string
+		typesList  string
+		packages   string
+		include    patternList
+		exclude    patternList
+		jobs       int
+		recoverXML bool
+	)
+
+	fs := flag.NewFlagSet("extract", flag.ExitOnError)
+	fs.StringVar(&inputPath, "input", "", "Path to the GeneXus XPZ file, export XML or extracted export directory (required)")
+	fs.StringVar(&modelPath, "model", "model.json", "Path of the model file to write")
+	fs.StringVar(&configPath, "config", "", "Path to a JSON configuration file")
+	fs.StringVar(&typesList, "types", "", "Comma-separated object types to extract (e.g. Procedure)")
+	fs.StringVar(&packages, "package", "", "Comma-separated packages (or KB folders) to extract")
+	fs.Var(&include, "include", "Only extract objects whose name or path matches this regex (repeatable)")
+	fs.Var(&exclude, "exclude", "Skip objects whose name or path matches this regex (repeatable)")
+	fs.IntVar(&jobs, "jobs", 1, "Number of objects parsed concurrently")
+	fs.BoolVar(&recoverXML, "recover", false, "Parse malformed export files object by object, skipping the broken objects")
+	fs.Usage = printExtractUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if inputPath == "" {
+		utils.Error("Missing required flag: --input")
+		fmt.Println()
+		printExtractUsage()
+		return 1
+	}
+	if err := validateInput(inputPath); err != nil {
+		utils.Error("Invalid input: %v", err)
+		return 1
+	}
+
+	opts := xpz.Options{Packages: xpz.ParseList(packages), Jobs: jobs, Recover: recoverXML}
+
+	// Custom tags, aliases and object types shape the extraction
+	maxEntry := defaultMaxEntrySize
+	if configPath != "" {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			utils.Error("Invalid config: %v", err)
+			return 1
+		}
+		applyConfig(cfg)
+		include = append(include, cfg.Include...)
+		exclude = append(exclude, cfg.Exclude...)
+		if opts.MaxSourceSize, err = config.ParseSize(cfg.Limits.MaxSourceSize); err != nil {
+			utils.Error("Invalid config: limits.maxSourceSize: %v", err)
+			return 1
+		}
+		opts.MaxObjects = cfg.Limits.MaxObjects
+		if cfg.Limits.MaxEntrySize != "" {
+			maxEntry = cfg.Limits.MaxEntrySize
+		}
+	}
+	maxEntrySize, err := config.ParseSize(maxEntry)
+	if err != nil {
+		utils.Error("Invalid config: limits.maxEntrySize: %v", err)
+		return 1
+	}
+	opts.MaxEntrySize = int64(maxEntrySize)
+	// Types registered by the config file are valid --types values
+	if opts.Types, err = xpz.ParseTypes(typesList); err != nil {
+		utils.Error("Invalid --types value: %v", err)
+		return 1
+	}
+	if opts.Include, err = xpz.CompilePatterns(include); err != nil {
+		utils.Error("Invalid --include: %v", err)
+		return 1
+	}
+	if opts.Exclude, err = xpz.CompilePatterns(exclude); err != nil {
+		utils.Error("Invalid --exclude: %v", err)
+		return 1
+	}
+
+	result, err := xpz.ExtractWithOptions(inputPath, opts)
+	if err != nil {
+		utils.Error("Failed to extract XPZ: %v", err)
+		return 1
+	}
+
+	err = model.WriteModelFile(modelPath, &model.ModelFile{
+		Generator: "gxdocgen " + version,
+		KBName:    result.KBName,
+		Info:      result.Info,
+		Objects:   result.Objects,
+		Skipped:   result.Skipped,
+
+		UnknownTypes: result.UnknownTypes,
+	})
+	if err != nil {
+		utils.Error("Failed to write model: %v", err)
+		return 1
+	}
+
+	utils.Success("Model written to: %s", modelPath)
+	utils.Info("%d object(s) from %s", len(result.Objects), inputPath)
+	return 0
+}
+
+// printExtractUsage prints the usage information for the extract subcommand
+func printExtractUsage() {
+	fmt.Println("GXDocGen extract - Extract an export into a model file for render")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s extract --input <xpz-file> [--model model.json] [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --input <path>       GeneXus XPZ file, export XML or export directory (required)")
+	fmt.Println("  --model <path>       Model file to write (default: model.json)")
+	fmt.Println("  --config <path>      JSON configuration file (custom tags, filters, limits)")
+	fmt.Println("  --types <list>       Only extract these object types (e.g. Procedure)")
+	fmt.Println("  --package <list>     Only extract these packages or KB folders")
+	fmt.Println("  --include <regex>    Only extract objects whose name or path matches (repeatable)")
+	fmt.Println("  --exclude <regex>    Skip objects whose name or path matches (repeatable)")
+	fmt.Println("  --jobs <n>           Parse n objects concurrently (default: 1)")
+	fmt.Println("  --recover            Keep the well-formed objects of malformed export files")
+	fmt.Println()
+	fmt.Printf("Render the model with: %s render --model model.json --output ./docs\n", os.Args[0])
+	fmt.Println()
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/fixture"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// runFixture implements the fixture subcommand and returns the process exit code
+func runFixture(args []string) int {
+	var (
+		outputPath string
+		opts       fixture.Options
+	)
+
+	fs := flag.NewFlagSet("fixture", flag.ExitOnError)
+	fs.StringVar(&outputPath, "output", "fixture.xpz", "Path of the XPZ file to write")
+	fs.StringVar(&opts.KB, "kb", "FixtureKB", "Knowledge base name")
+	fs.IntVar(&opts.Procedures, "procedures", 10, "Number of procedures to generate")
+	fs.IntVar(&opts.Transactions, "transactions", 2, "Number of transactions to generate")
+	fs.IntVar(&opts.Packages, "packages", 2, "Number of packages procedures are spread over")
+	fs.Usage = printFixtureUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if opts.Procedures < 0 || opts.Transactions < 0 || opts.Packages < 1 {
+		utils.Error("Invalid counts: --procedures and --transactions must be >= 0, --packages >= 1")
+		return 1
+	}
+
+	file, err := os.Create(outputPath)
+	if err != nil {
+		utils.Error("Failed to create %s: %v", outputPath, err)
+		return 1
+	}
+	if err := fixture.Write(file, opts); err != nil {
+		file.Close()
+		utils.Error("Failed to write fixture: %v", err)
+		return 1
+	}
+	if err := file.Close(); err != nil {
+		utils.Error("Failed to write fixture: %v", err)
+		return 1
+	}
+
+	utils.Success("Fixture written to: %s", outputPath)
+	utils.Info("%d procedure(s), %d transaction(s) in %d package(s)", opts.Procedures, opts.Transactions, opts.Packages)
+	return 0
+}
+
+// printFixtureUsage prints the usage information for the fixture subcommand
+func printFixtureUsage() {
+	fmt.Println("GXDocGen fixture - Build a synthetic XPZ export for testing")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s fixture [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --output <path>        XPZ file to write (default: fixture.xpz)")
+	fmt.Println("  --kb <name>            Knowledge base name (default: FixtureKB)")
+	fmt.Println("  --procedures <n>       Number of annotated procedures (default: 10)")
+	fmt.Println("  --transactions <n>     Number of transactions (default: 2)")
+	fmt.Println("  --packages <n>         Number of packages procedures are spread over (default: 2)")
+	fmt.Println()
+}
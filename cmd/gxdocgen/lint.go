@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/config"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/layers"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/lint"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// runLint implements the lint subcommand and returns the process exit code
+func runLint(args []string) int {
+	var (
+		inputPath  string
+		configPath string
+		disable    string
+		listRules  bool
+	)
+
+	fs := flag.NewFlagSet("lint", flag.ExitOnError)
+	fs.StringVar(&inputPath, "input", "", "Path to the GeneXus XPZ file, export XML or extracted export directory (required)")
+	fs.StringVar(&configPath, "config", "", "Path to a JSON configuration file")
+	fs.StringVar(&disable, "disable", "", "Comma-separated list of rules to disable")
+	fs.BoolVar(&listRules, "list-rules", false, "List available rules and exit")
+	fs.Usage = printLintUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if listRules {
+		for _, rule := range lint.Rules {
+			fmt.Printf("  %-22s %-8s %s\n", rule.Name, rule.Severity, rule.Description)
+		}
+		return 0
+	}
+
+	if inputPath == "" {
+		utils.Error("Missing required flag: --input")
+		fmt.Println()
+		printLintUsage()
+		return 1
+	}
+	if err := validateInput(inputPath); err != nil {
+		utils.Error("Invalid input: %v", err)
+		return 1
+	}
+
+	// Rule severities: config file first, then --disable on top
+	overrides := make(map[string]lint.Severity)
+	var classifier *layers.Classifier
+	if configPath != "" {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			utils.Error("Invalid config: %v", err)
+			return 1
+		}
+		applyConfig(cfg)
+		for name, value := range cfg.Lint.Rules {
+			severity, err := lint.ParseSeverity(value)
+			if err != nil {
+				utils.Error("Invalid config for rule '%s': %v", name, err)
+				return 1
+			}
+			overrides[name] = severity
+		}
+		if classifier, err = compileLayers(cfg.Layers); err != nil {
+			utils.Error("Invalid config: layers: %v", err)
+			return 1
+		}
+	}
+	for _, name := range strings.Split(disable, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			overrides[name] = lint.SeverityOff
+		}
+	}
+
+	result, err := xpz.Extract(inputPath)
+	if err != nil {
+		utils.Error("Failed to extract XPZ: %v", err)
+		return 1
+	}
+
+	findings := lint.RunWithOptions(result.Objects, lint.Options{Overrides: overrides, Layers: classifier})
+
+	errorCount := 0
+	for _, f := range findings {
+		if f.Severity == lint.SeverityError {
+			errorCount++
+			utils.Error("%s: [%s] %s", f.Object, f.Rule, f.Message)
+		} else {
+			utils.Warning("%s: [%s] %s", f.Object, f.Rule, f.Message)
+		}
+	}
+
+	fmt.Println()
+	if len(findings) == 0 {
+		utils.Success("No lint findings")
+		return 0
+	}
+	utils.Info("%d finding(s): %d error(s), %d warning(s)", len(findings), errorCount, len(findings)-errorCount)
+	if errorCount > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printLintUsage prints the usage information for the lint subcommand
+func printLintUsage() {
+	fmt.Println("GXDocGen lint - Check doc comments against lint rules")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s lint --input <xpz-file> [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --input <path>       GeneXus XPZ file, export XML or export directory (required)")
+	fmt.Println("  --config <path>      JSON configuration file (lint.rules severities, layers)")
+	fmt.Println("  --disable <rules>    Comma-separated list of rules to disable")
+	fmt.Println("  --list-rules         List available rules and exit")
+	fmt.Println()
+	fmt.Println("The layer-* rules check calls across the architecture layers defined by")
+	fmt.Println("\"layers\" in the config file, e.g. a Data procedure calling a UI panel.")
+	fmt.Println()
+}
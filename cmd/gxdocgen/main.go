@@ -1,14 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/config"
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/generator"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/layers"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/overlay"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/parser"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/verify"
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
 )
 
@@ -16,73 +27,549 @@ const (
 	version = "0.2.0"
 )
 
+// Process exit codes
+const (
+	exitOK       = 0 // documentation generated without warnings
+	exitFatal    = 1 // the run failed
+	exitWarnings = 2 // completed with warnings under --warnings-as-errors
+)
+
 func main() {
 	// Define command-line flags
 	var (
-		inputPath  string
-		outputPath string
-		showHelp   bool
-		showVer    bool
+		inputPath   string
+		modelPath   string
+		format      string
+		outputPath  string
+		configPath  string
+		strict      bool
+		minCover    float64
+		cheatsheet  string
+		sortMode    string
+		layout      string
+		locale      string
+		lang        string
+		translate   string
+		localize    bool
+		assets      string
+		typesList   string
+		frontMatter string
+		include     patternList
+		exclude     patternList
+		overlays    patternList
+		examples    bool
+		withSource  bool
+		packages    string
+		incremental bool
+		jobs        int
+		badges      bool
+		quiet       bool
+		verbose     bool
+		slowest     int
+		debug       bool
+		noColor     bool
+		verifyOut   bool
+		warnErrors  bool
+		maxSource   string
+		maxEntry    string
+		maxObjects  int
+		recoverXML  bool
+		diagramCmd  string
+		pageTimeout time.Duration
+		showHelp    bool
+		showVer     bool
 	)
 
-	flag.StringVar(&inputPath, "input", "", "Path to the GeneXus XPZ file (required)")
-	flag.StringVar(&outputPath, "output", "./docs", "Output directory for generated documentation")
+	flag.StringVar(&inputPath, "input", "", "Path to the GeneXus XPZ file, export XML or extracted export directory (required)")
+	flag.StringVar(&outputPath, "output", "./docs", "Output directory for generated documentation, or a .zip archive to write ({kb}, {date} and {version} are expanded)")
+	flag.StringVar(&modelPath, "model", "", "Render a model file written by the extract subcommand instead of an XPZ file")
+	flag.StringVar(&format, "format", "markdown", "Output format (only 'markdown' is available)")
+	flag.StringVar(&configPath, "config", "", "Path to a JSON configuration file")
+	flag.BoolVar(&strict, "strict", false, "Exit with an error when procedures lack /** */ documentation or @param tags disagree with Parm()")
+	flag.BoolVar(&strict, "fail-on-undocumented", false, "Alias for --strict")
+	flag.Float64Var(&minCover, "min-coverage", 0, "Fail when documentation coverage is below this percentage")
+	flag.StringVar(&cheatsheet, "cheatsheet", "", "Generate a printable cheat sheet grouped by 'package' or 'tag'")
+	flag.StringVar(&sortMode, "sort", generator.SortByName, "Sort object listings by 'name', 'type' or 'package'")
+	flag.StringVar(&layout, "layout", generator.LayoutFlat, "Place procedure pages in one folder per package ('flat') or mirror the package hierarchy ('nested')")
+	flag.StringVar(&locale, "locale", "", "Locale whose collation orders alphabetical indexes (e.g. pt-BR, es; 'C' for byte order)")
+	flag.StringVar(&lang, "lang", "", "Language of headings, counts and dates (e.g. pt-BR, es)")
+	flag.StringVar(&translate, "translations", "", "JSON file overriding heading and label translations")
+	flag.StringVar(&assets, "assets", "", "Folder of object screenshots (<ObjectName>/*.png); defaults to assets/ next to --config")
+	flag.BoolVar(&localize, "localize-values", false, "Also translate parameter directions, type names and 'Unknown' into the --lang language")
+	flag.StringVar(&typesList, "types", "", "Comma-separated object types to document (e.g. Procedure)")
+	flag.StringVar(&frontMatter, "frontmatter", "", "Comma-separated YAML front matter fields for Markdown pages (title, tags, package, date or all)")
+	flag.Var(&include, "include", "Only document objects whose name or path matches this regex (repeatable)")
+	flag.Var(&exclude, "exclude", "Skip objects whose name or path matches this regex (repeatable)")
+	flag.Var(&overlays, "overlay", "JSON file patching extracted objects before rendering (repeatable)")
+	flag.StringVar(&packages, "package", "", "Comma-separated packages (or KB folders) to document")
+	flag.StringVar(&diagramCmd, "diagram-command", "", "Pre-render Mermaid diagrams to SVG with this command (e.g. \"mmdc -i {input} -o {output}\")")
+	flag.BoolVar(&examples, "examples", false, "Show call sites from other objects as example usages")
+	flag.BoolVar(&withSource, "include-source", false, "Append the procedure source in a collapsible block")
+	flag.BoolVar(&incremental, "incremental", false, "Skip pages whose object did not change since the last run")
+	flag.IntVar(&jobs, "jobs", 1, "Number of objects parsed and rendered concurrently")
+	flag.BoolVar(&badges, "badges", false, "Write per-module health and coverage badges")
+	flag.BoolVar(&quiet, "quiet", false, "Only print warnings and errors")
+	flag.BoolVar(&quiet, "q", false, "Only print warnings and errors (shorthand)")
+	flag.BoolVar(&verbose, "verbose", false, "Print detailed progress messages")
+	flag.IntVar(&slowest, "slowest", 5, "Number of slowest objects listed in the verbose run summary (0 to disable)")
+	flag.BoolVar(&debug, "debug", false, "Print debug traces (XPath misses, extraction decisions)")
+	flag.BoolVar(&noColor, "no-color", false, "Disable colored output (also honors NO_COLOR)")
+	flag.BoolVar(&verifyOut, "verify", false, "Re-parse the generated files and fail on syntax problems")
+	flag.BoolVar(&warnErrors, "warnings-as-errors", false, "Exit with code 2 when the run logged any warning")
+	flag.StringVar(&maxSource, "max-source-size", "", "Skip objects whose source is larger than this (e.g. 8MB)")
+	flag.StringVar(&maxEntry, "max-entry-size", defaultMaxEntrySize, "Skip archive entries larger than this once decompressed")
+	flag.IntVar(&maxObjects, "max-objects", 0, "Skip objects beyond this count per export file (0: no limit)")
+	flag.BoolVar(&recoverXML, "recover", false, "Parse malformed export files object by object, skipping the broken objects")
+	flag.DurationVar(&pageTimeout, "page-timeout", 0, "Skip pages that take longer than this to render (e.g. 30s)")
 	flag.BoolVar(&showHelp, "help", false, "Show usage information")
 	flag.BoolVar(&showHelp, "h", false, "Show usage information (shorthand)")
 	flag.BoolVar(&showVer, "version", false, "Show version information")
 	flag.BoolVar(&showVer, "v", false, "Show version information (shorthand)")
 
+	// Dispatch subcommands. The main command's flags are defined first so
+	// the docs subcommand can describe them.
+	rendering := false
+	if len(os.Args) > 1 {
+		if os.Args[1] == "render" {
+			// render is the main command reading a model file written by
+			// extract instead of an export
+			rendering = true
+			os.Args = append(os.Args[:1:1], os.Args[2:]...)
+		} else if cmd, ok := findSubcommand(os.Args[1]); ok {
+			os.Exit(cmd.run(os.Args[2:]))
+		}
+	}
+
 	flag.Usage = printUsage
 	flag.Parse()
 
+	if noColor {
+		utils.SetColor(false)
+	}
+
+	// Log level: the most detailed flag wins
+	switch {
+	case debug:
+		utils.SetLevel(utils.LevelDebug)
+	case verbose:
+		utils.SetLevel(utils.LevelVerbose)
+	case quiet:
+		utils.SetLevel(utils.LevelQuiet)
+	}
+
 	// Handle version flag
 	if showVer {
 		fmt.Printf("GXDocGen version %s\n", version)
-		os.Exit(0)
+		os.Exit(exitOK)
 	}
 
 	// Handle help flag
 	if showHelp {
 		printUsage()
-		os.Exit(0)
+		os.Exit(exitOK)
 	}
 
 	// Validate required input flag
-	if inputPath == "" {
+	switch {
+	case rendering && modelPath == "":
+		utils.Error("Missing required flag: --model")
+		fmt.Println()
+		printUsage()
+		os.Exit(exitFatal)
+	case inputPath == "" && modelPath == "":
 		utils.Error("Missing required flag: --input")
 		fmt.Println()
 		printUsage()
-		os.Exit(1)
+		os.Exit(exitFatal)
+	case inputPath != "" && modelPath != "":
+		utils.Fatal("--input and --model cannot be used together")
 	}
 
-	// Validate input file exists and has .xpz extension
-	if err := validateInput(inputPath); err != nil {
-		utils.Fatal("Invalid input: %v", err)
+	if format != "markdown" {
+		utils.Fatal("Invalid --format value '%s' (only 'markdown' is available)", format)
 	}
 
-	// Print banner
-	printBanner()
+	// Validate option values
+	if cheatsheet != "" && cheatsheet != generator.CheatsheetByPackage && cheatsheet != generator.CheatsheetByTag {
+		utils.Fatal("Invalid --cheatsheet value '%s' (expected 'package' or 'tag')", cheatsheet)
+	}
+
+	switch sortMode {
+	case generator.SortByName, generator.SortByType, generator.SortByPackage:
+	default:
+		utils.Fatal("Invalid --sort value '%s' (expected 'name', 'type' or 'package')", sortMode)
+	}
+
+	if layout != generator.LayoutFlat && layout != generator.LayoutNested {
+		utils.Fatal("Invalid --layout value '%s' (expected 'flat' or 'nested')", layout)
+	}
+
+	if jobs < 1 {
+		utils.Fatal("Invalid --jobs value %d (expected 1 or more)", jobs)
+	}
+
+	// Validate the input exists and is an XPZ, export XML or export directory
+	if inputPath != "" {
+		if err := validateInput(inputPath); err != nil {
+			utils.Fatal("Invalid input: %v", err)
+		}
+	}
+
+	// A model file is already filtered; extraction flags belong to extract
+	if modelPath != "" {
+		for _, name := range []string{"types", "package", "include", "exclude", "max-source-size", "max-entry-size", "max-objects"} {
+			if flagSet(name) {
+				utils.Warning("--%s is ignored with --model; pass it to extract instead", name)
+			}
+		}
+	}
+
+	// Load optional configuration file
+	var thresholds generator.BadgeThresholds
+	var returnConventions []string
+	var layerClassifier *layers.Classifier
+	if configPath != "" {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			utils.Fatal("Invalid config: %v", err)
+		}
+		applyConfig(cfg)
+		if cfg.Output != "" && !flagSet("output") {
+			outputPath = cfg.Output
+		}
+		if cfg.Locale != "" && !flagSet("locale") {
+			locale = cfg.Locale
+		}
+		if cfg.Lang != "" && !flagSet("lang") {
+			lang = cfg.Lang
+		}
+		if cfg.Translations != "" && !flagSet("translations") {
+			translate = cfg.Translations
+		}
+		if cfg.LocalizeValues && !flagSet("localize-values") {
+			localize = true
+		}
+		if cfg.FrontMatter != "" && !flagSet("frontmatter") {
+			frontMatter = cfg.FrontMatter
+		}
+		if cfg.DiagramCommand != "" && !flagSet("diagram-command") {
+			diagramCmd = cfg.DiagramCommand
+		}
+		include = append(include, cfg.Include...)
+		exclude = append(exclude, cfg.Exclude...)
+		overlays = append(overlays, cfg.Overlays...)
+		thresholds = generator.BadgeThresholds{Green: cfg.Badges.Green, Yellow: cfg.Badges.Yellow}
+		returnConventions = cfg.ReturnConventions
+		if !flagSet("max-source-size") {
+			maxSource = cfg.Limits.MaxSourceSize
+		}
+		if cfg.Limits.MaxEntrySize != "" && !flagSet("max-entry-size") {
+			maxEntry = cfg.Limits.MaxEntrySize
+		}
+		if !flagSet("max-objects") {
+			maxObjects = cfg.Limits.MaxObjects
+		}
+		if cfg.Limits.PageTimeout != "" && !flagSet("page-timeout") {
+			if pageTimeout, err = time.ParseDuration(cfg.Limits.PageTimeout); err != nil {
+				utils.Fatal("Invalid config: limits.pageTimeout: %v", err)
+			}
+		}
+		if layerClassifier, err = compileLayers(cfg.Layers); err != nil {
+			utils.Fatal("Invalid config: layers: %v", err)
+		}
+		// Screenshots placed next to the config are picked up by convention
+		if dir := filepath.Join(filepath.Dir(configPath), "assets"); !flagSet("assets") && isDir(dir) {
+			assets = dir
+		}
+	}
 
-	// Step 1: Extract XPZ file
-	utils.Info("Step 1/2: Extracting XPZ file...")
-	result, err := xpz.Extract(inputPath)
+	// Types registered by the config file are valid --types values
+	types, err := xpz.ParseTypes(typesList)
 	if err != nil {
-		utils.Fatal("Failed to extract XPZ: %v", err)
+		utils.Fatal("Invalid --types value: %v", err)
+	}
+
+	var translations map[string]string
+	if translate != "" {
+		if translations, err = generator.LoadTranslations(translate); err != nil {
+			utils.Fatal("Invalid --translations: %v", err)
+		}
+	}
+
+	frontMatterFields, err := generator.ParseFrontMatter(frontMatter)
+	if err != nil {
+		utils.Fatal("Invalid --frontmatter value: %v", err)
+	}
+
+	maxSourceSize, err := config.ParseSize(maxSource)
+	if err != nil {
+		utils.Fatal("Invalid --max-source-size: %v", err)
+	}
+	maxEntrySize, err := config.ParseSize(maxEntry)
+	if err != nil {
+		utils.Fatal("Invalid --max-entry-size: %v", err)
+	}
+	if maxObjects < 0 || pageTimeout < 0 {
+		utils.Fatal("Invalid limits: --max-objects and --page-timeout must not be negative")
+	}
+
+	timings := utils.NewTimings()
+	started := time.Now()
+
+	extractOpts := xpz.Options{
+		Types:         types,
+		Packages:      xpz.ParseList(packages),
+		Jobs:          jobs,
+		Timings:       timings,
+		MaxSourceSize: maxSourceSize,
+		MaxEntrySize:  int64(maxEntrySize),
+		MaxObjects:    maxObjects,
+		Recover:       recoverXML,
+	}
+	if extractOpts.Include, err = xpz.CompilePatterns(include); err != nil {
+		utils.Fatal("Invalid --include: %v", err)
+	}
+	if extractOpts.Exclude, err = xpz.CompilePatterns(exclude); err != nil {
+		utils.Fatal("Invalid --exclude: %v", err)
+	}
+
+	// Print banner
+	if !quiet {
+		printBanner()
+	}
+
+	// Ctrl+C cancels extraction and generation cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Step 1: Extract XPZ file, or load the model of an earlier extraction
+	var result *xpz.ExtractResult
+	if modelPath != "" {
+		utils.Info("Step 1/2: Loading model...")
+		file, err := model.ReadModelFile(modelPath)
+		if err != nil {
+			utils.Fatal("Failed to load model: %v", err)
+		}
+		result = &xpz.ExtractResult{Objects: file.Objects, KBName: file.KBName, Info: file.Info, Skipped: file.Skipped, UnknownTypes: file.UnknownTypes}
+	} else {
+		utils.Info("Step 1/2: Extracting XPZ file...")
+		result, err = xpz.ExtractContext(ctx, inputPath, extractOpts)
+		if errors.Is(err, context.Canceled) {
+			utils.Fatal("Cancelled")
+		}
+		if err != nil {
+			utils.Fatal("Failed to extract XPZ: %v", err)
+		}
+	}
+
+	// Overlays fill documentation gaps the KB itself does not cover
+	for _, path := range overlays {
+		o, err := overlay.Load(path)
+		if err != nil {
+			utils.Fatal("Invalid overlay: %v", err)
+		}
+		for _, target := range o.Apply(result.Objects) {
+			utils.Warning("Overlay %s: no object matches %s", path, target)
+		}
+		utils.Verbose("Applied overlay %s", path)
+	}
+
+	outputPath = config.ExpandTemplate(outputPath, config.TemplateVars{KB: result.KBName, Version: version, Date: time.Now()})
+
+	// A .zip output is built in memory and archived once generation is done
+	var archive *storage.Zip
+	var archiveFile *os.File
+	if strings.EqualFold(filepath.Ext(outputPath), ".zip") {
+		archiveFile, err = os.Create(outputPath)
+		if err != nil {
+			utils.Fatal("Failed to create output archive: %v", err)
+		}
+		archive = storage.NewZip(archiveFile)
 	}
 
 	// Step 2: Generate documentation
 	utils.Info("Step 2/2: Generating documentation...")
-	if err := generator.GenerateDocs(result.Objects, result.KBName, outputPath); err != nil {
+	var out storage.Storage
+	if archive != nil {
+		out = archive
+	}
+	stopGenerate := timings.Start("generate")
+	err = generator.GenerateDocsContext(ctx, result.Objects, result.KBName, outputPath, generator.Options{
+		Sort:              sortMode,
+		Layout:            layout,
+		Locale:            locale,
+		Lang:              lang,
+		Translations:      translations,
+		Assets:            assets,
+		LocalizeValues:    localize,
+		Cheatsheet:        cheatsheet,
+		FrontMatter:       frontMatterFields,
+		Examples:          examples,
+		IncludeSource:     withSource,
+		Incremental:       incremental,
+		Jobs:              jobs,
+		BadgesEnabled:     badges,
+		Badges:            thresholds,
+		ReturnConventions: returnConventions,
+		Timings:           timings,
+		DiagramCommand:    diagramCmd,
+		PageTimeout:       pageTimeout,
+		KBInfo:            result.Info,
+		Layers:            layerClassifier,
+		Skipped:           result.Skipped,
+		UnknownTypes:      result.UnknownTypes,
+		Storage:           out,
+	})
+	if errors.Is(err, context.Canceled) {
+		utils.Fatal("Cancelled")
+	}
+	if err != nil {
 		utils.Fatal("Failed to generate documentation: %v", err)
 	}
+	stopGenerate()
+
+	// Self-test: re-parse everything that was written
+	if verifyOut {
+		var issues []verify.Issue
+		if archive != nil {
+			issues, err = verify.FS(archive)
+		} else {
+			issues, err = verify.Dir(outputPath)
+		}
+		if err != nil {
+			utils.Fatal("Failed to verify output: %v", err)
+		}
+		for _, issue := range issues {
+			utils.Error("%s", issue)
+		}
+		if len(issues) > 0 {
+			fmt.Println()
+			utils.Fatal("Verification found %d problem(s) in the generated output", len(issues))
+		}
+		utils.Success("Verified generated output")
+	}
+
+	if archive != nil {
+		if err := archive.Close(); err != nil {
+			utils.Fatal("Failed to write output archive: %v", err)
+		}
+		if err := archiveFile.Close(); err != nil {
+			utils.Fatal("Failed to write output archive: %v", err)
+		}
+	}
+
+	// Strict mode: fail when any procedure lacks annotations
+	if strict {
+		if undocumented := undocumentedProcedures(result.Objects); len(undocumented) > 0 {
+			fmt.Println()
+			utils.Fatal("Strict mode: %d procedure(s) without /** */ documentation: %s",
+				len(undocumented), strings.Join(undocumented, ", "))
+		}
+		if mismatched := mismatchedParams(result.Objects); len(mismatched) > 0 {
+			fmt.Println()
+			utils.Fatal("Strict mode: %d procedure(s) with @param tags that disagree with Parm(): %s",
+				len(mismatched), strings.Join(mismatched, ", "))
+		}
+	}
+
+	// Coverage threshold
+	if minCover > 0 {
+		if coverage := generator.ComputeCoverage(result.Objects); coverage.Percent < minCover {
+			fmt.Println()
+			utils.Fatal("Documentation coverage %.1f%% is below the required %.1f%%", coverage.Percent, minCover)
+		}
+	}
 
 	// Success message
 	fmt.Println()
 	utils.Success("Documentation generation complete!")
 	utils.Info("Output location: %s", outputPath)
+	timings.Report(time.Since(started), slowest)
+
+	if warnings, _ := utils.Counts(); warnErrors && warnings > 0 {
+		utils.Error("%d warning(s) logged with --warnings-as-errors", warnings)
+		os.Exit(exitWarnings)
+	}
+	os.Exit(exitOK)
+}
+
+// flagSet reports whether a flag was given explicitly on the command line
+func flagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// undocumentedProcedures returns the names of procedures without annotation blocks
+func undocumentedProcedures(objects []model.GXObject) []string {
+	var names []string
+	for _, obj := range objects {
+		if obj.Type == "Procedure" && !obj.IsDocumented() {
+			names = append(names, obj.Path)
+		}
+	}
+	return names
+}
+
+// isDir reports whether path is an existing directory
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
 }
 
-// validateInput checks if the input file exists and has proper extension
+// mismatchedParams returns the names of documented procedures whose @param
+// tags disagree with their Parm() declaration
+func mismatchedParams(objects []model.GXObject) []string {
+	var names []string
+	for _, obj := range objects {
+		if obj.Type == "Procedure" && obj.IsDocumented() && len(parser.CompareParams(obj.Documentation.Parameters, obj.Parameters)) > 0 {
+			names = append(names, obj.Path)
+		}
+	}
+	return names
+}
+
+// applyConfig registers configuration-driven behavior before extraction
+func applyConfig(cfg *config.Config) {
+	for tag, label := range cfg.CustomTags {
+		parser.RegisterTag(tag, parser.CustomFieldHandler(tag, label))
+	}
+	for alias, tag := range cfg.TagAliases {
+		parser.RegisterAlias(alias, tag)
+	}
+	for guid, name := range cfg.ObjectTypes {
+		xpz.RegisterType(guid, name)
+	}
+}
+
+// compileLayers returns the layer classifier of the config, or nil when it
+// has no layer rules
+func compileLayers(cfg config.LayersConfig) (*layers.Classifier, error) {
+	layerConfig := layers.Config{Order: cfg.Order}
+	for _, rule := range cfg.Rules {
+		layerConfig.Rules = append(layerConfig.Rules, layers.Rule{Layer: rule.Layer, Name: rule.Name, Module: rule.Module})
+	}
+	return layers.Compile(layerConfig)
+}
+
+// patternList collects the values of a repeatable string flag
+type patternList []string
+
+func (p *patternList) String() string {
+	return strings.Join(*p, ", ")
+}
+
+func (p *patternList) Set(value string) error {
+	*p = append(*p, value)
+	return nil
+}
+
+// validateInput checks that the input exists and is an XPZ file, a raw
+// export XML file or an already extracted export directory
 func validateInput(path string) error {
 	// Check if file exists
 	info, err := os.Stat(path)
@@ -93,20 +580,32 @@ func validateInput(path string) error {
 		return fmt.Errorf("cannot access file: %w", err)
 	}
 
-	// Check if it's a file (not a directory)
+	// Directories hold exports unzipped by GeneXus Server pipelines
 	if info.IsDir() {
-		return fmt.Errorf("expected a file, got a directory: %s", path)
+		return nil
 	}
 
 	// Check file extension
 	ext := strings.ToLower(filepath.Ext(path))
-	if ext != ".xpz" {
-		return fmt.Errorf("expected .xpz file, got: %s", ext)
+	if ext != ".xpz" && ext != ".xml" {
+		return fmt.Errorf("expected .xpz or .xml file, got: %s", ext)
 	}
 
 	return nil
 }
 
+// validateArchive checks that the input is an XPZ file, for commands that
+// read or rewrite the archive itself
+func validateArchive(path string) error {
+	if err := validateInput(path); err != nil {
+		return err
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext != ".xpz" {
+		return fmt.Errorf("expected .xpz file, got: %s", path)
+	}
+	return nil
+}
+
 // printBanner prints the application banner
 func printBanner() {
 	fmt.Println()
@@ -123,17 +622,74 @@ func printUsage() {
 	fmt.Println()
 	fmt.Println("USAGE:")
 	fmt.Printf("  %s --input <xpz-file> [options]\n", os.Args[0])
+	fmt.Printf("  %s render --model <model-file> [options]\n", os.Args[0])
+	fmt.Printf("  %s <command> [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("COMMANDS:")
+	fmt.Printf("  %-20s %s\n", "render", renderSummary)
+	for _, cmd := range subcommands() {
+		fmt.Printf("  %-20s %s\n", cmd.name, cmd.summary)
+	}
 	fmt.Println()
 	fmt.Println("REQUIRED FLAGS:")
-	fmt.Println("  --input <path>       Path to the GeneXus XPZ file")
+	fmt.Println("  --input <path>       Path to the GeneXus XPZ file, export XML or extracted export directory")
 	fmt.Println()
 	fmt.Println("OPTIONAL FLAGS:")
-	fmt.Println("  --output <path>      Output directory (default: ./docs)")
+	fmt.Println("  --model <path>       Generate from a model file written by extract instead of --input")
+	fmt.Println("  --format <name>      Output format (default: markdown)")
+	fmt.Println("  --output <path>      Output directory (default: ./docs); {kb}, {date} and {version} are expanded")
+	fmt.Println("  --config <path>      JSON configuration file (custom tags, ...)")
+	fmt.Println("  --strict             Fail when procedures lack /** */ documentation or @param")
+	fmt.Println("                       tags disagree with Parm()")
+	fmt.Println("                       (alias: --fail-on-undocumented)")
+	fmt.Println("  --min-coverage <n>   Fail when documentation coverage is below n percent")
+	fmt.Println("  --cheatsheet <mode>  Printable cheat sheet (Markdown + PDF) by 'package' or 'tag'")
+	fmt.Println("  --sort <mode>        Sort listings by 'name', 'type' or 'package' (default: name)")
+	fmt.Println("  --layout <mode>      Page folders: 'flat' (one per package) or 'nested' (Sales.Orders → Sales/Orders/)")
+	fmt.Println("  --locale <tag>       Collation of alphabetical indexes, e.g. pt-BR or es ('C': byte order)")
+	fmt.Println("  --lang <tag>         Translate headings and format counts and dates (e.g. pt-BR: 1.234, 31/12/2024)")
+	fmt.Println("  --translations <file> JSON file overriding heading and label translations")
+	fmt.Println("  --assets <dir>       Embed screenshots from <dir>/<ObjectName>/*.png (default: assets/ next to --config)")
+	fmt.Println("  --localize-values    Also translate IN/OUT/INOUT, type names and 'Unknown' (with --lang)")
+	fmt.Println("  --types <list>       Only document these object types (e.g. Procedure)")
+	fmt.Println("  --frontmatter <list> Prepend YAML front matter (title, tags, package, date or all) for Jekyll/Eleventy")
+	fmt.Println("  --include <regex>    Only document objects whose name or path matches (repeatable)")
+	fmt.Println("  --exclude <regex>    Skip objects whose name or path matches (repeatable)")
+	fmt.Println("  --overlay <file>     Patch summaries, tags, packages and parameters from a JSON file (repeatable)")
+	fmt.Println("  --package <list>     Only document these packages or KB folders (e.g. billing,users)")
+	fmt.Println("  --diagram-command <cmd> Pre-render Mermaid diagrams to SVG, e.g. \"mmdc -i {input} -o {output}\"")
+	fmt.Println("  --examples           Show real call sites from other objects as example usages")
+	fmt.Println("  --include-source     Append the procedure source in a collapsible block")
+	fmt.Println("  --incremental        Only regenerate pages whose object changed since the last run")
+	fmt.Println("  --jobs <n>           Parse and render n objects concurrently (default: 1)")
+	fmt.Println("  --badges             Write per-module health and coverage badges (SVG)")
+	fmt.Println("  --quiet, -q          Only print warnings and errors")
+	fmt.Println("  --verbose            Print detailed progress messages and per-object parse/render times")
+	fmt.Println("  --slowest <n>        List the n slowest objects in the verbose run summary (default: 5)")
+	fmt.Println("  --debug              Print debug traces (XPath misses, extraction decisions)")
+	fmt.Println("  --no-color           Disable colored output (also honors the NO_COLOR variable)")
+	fmt.Println("  --verify             Re-parse generated Markdown/JSON/SVG/PDF and fail on syntax problems")
+	fmt.Println("  --warnings-as-errors Exit with code 2 when any warning was logged")
+	fmt.Println("  --max-source-size <size>  Skip objects with a larger source (e.g. 8MB)")
+	fmt.Println("  --max-entry-size <size>   Skip archive entries larger than this (default: 1GB)")
+	fmt.Println("  --max-objects <n>    Skip objects beyond n per export file")
+	fmt.Println("  --recover            Keep the well-formed objects of malformed export files")
+	fmt.Println("  --page-timeout <d>   Replace pages slower than d (e.g. 30s) with a placeholder")
 	fmt.Println("  --help, -h           Show this help message")
 	fmt.Println("  --version, -v        Show version information")
 	fmt.Println()
+	fmt.Println("EXIT CODES:")
+	fmt.Println("  0                    Success")
+	fmt.Println("  1                    Fatal error (including failed --strict/--min-coverage/--verify checks)")
+	fmt.Println("  2                    Completed with warnings (only with --warnings-as-errors)")
+	fmt.Println()
 	fmt.Println("EXAMPLES:")
 	fmt.Printf("  %s --input ./export.xpz\n", os.Args[0])
 	fmt.Printf("  %s --input ./export.xpz --output ./documentation\n", os.Args[0])
+	fmt.Printf("  %s --input ./export.xpz --strict\n", os.Args[0])
+	fmt.Printf("  %s --input ./export.xpz --min-coverage 80\n", os.Args[0])
+	fmt.Printf("  %s --input ./export.xpz --types Procedure\n", os.Args[0])
+	fmt.Printf("  %s --input ./export.xpz --exclude '^Test' --exclude '^Prototypes/'\n", os.Args[0])
+	fmt.Printf("  %s --input ./extracted-export/ --output ./documentation\n", os.Args[0])
 	fmt.Println()
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/config"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/diff"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/generator"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// runReleaseNotes implements the release-notes subcommand and returns the process exit code
+func runReleaseNotes(args []string) int {
+	var (
+		oldPath        string
+		newPath        string
+		outputPath     string
+		releaseVersion string
+		lang           string
+	)
+
+	fs := flag.NewFlagSet("release-notes", flag.ExitOnError)
+	fs.StringVar(&oldPath, "old", "", "Path to the previous XPZ export (required)")
+	fs.StringVar(&newPath, "new", "", "Path to the new XPZ export (required)")
+	fs.StringVar(&outputPath, "output", "RELEASE_NOTES.md", "Output file for the release notes ({kb}, {version} and {date} are expanded)")
+	fs.StringVar(&releaseVersion, "version", "", "KB version label shown in the title")
+	fs.StringVar(&lang, "lang", "", "Language used to format dates (e.g. pt-BR)")
+	fs.Usage = printReleaseNotesUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if oldPath == "" || newPath == "" {
+		utils.Error("Missing required flags: --old and --new")
+		fmt.Println()
+		printReleaseNotesUsage()
+		return 1
+	}
+	for _, path := range []string{oldPath, newPath} {
+		if err := validateInput(path); err != nil {
+			utils.Error("Invalid input: %v", err)
+			return 1
+		}
+	}
+
+	oldResult, err := xpz.Extract(oldPath)
+	if err != nil {
+		utils.Error("Failed to extract %s: %v", oldPath, err)
+		return 1
+	}
+	newResult, err := xpz.Extract(newPath)
+	if err != nil {
+		utils.Error("Failed to extract %s: %v", newPath, err)
+		return 1
+	}
+
+	result := diff.Compare(oldResult.Objects, newResult.Objects)
+	outputPath = config.ExpandTemplate(outputPath, config.TemplateVars{KB: newResult.KBName, Version: releaseVersion, Date: time.Now()})
+	if err := generator.GenerateReleaseNotes(result, newResult.KBName, releaseVersion, lang, outputPath); err != nil {
+		utils.Error("Failed to write release notes: %v", err)
+		return 1
+	}
+
+	utils.Success("Release notes written to: %s", outputPath)
+	utils.Info("%d added, %d removed, %d changed object(s)", len(result.Added), len(result.Removed), len(result.Changed))
+	return 0
+}
+
+// printReleaseNotesUsage prints the usage information for the release-notes subcommand
+func printReleaseNotesUsage() {
+	fmt.Println("GXDocGen release-notes - Generate release notes from two exports")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s release-notes --old <xpz-file> --new <xpz-file> [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --old <path>         Previous XPZ export (required)")
+	fmt.Println("  --new <path>         New XPZ export (required)")
+	fmt.Println("  --output <path>      Output file (default: RELEASE_NOTES.md)")
+	fmt.Println("  --version <label>    KB version label shown in the title")
+	fmt.Println("  --lang <tag>         Format dates for this language (e.g. pt-BR)")
+	fmt.Println()
+}
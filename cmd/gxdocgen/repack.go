@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/rewrite"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// runRepack implements the experimental repack subcommand and returns the
+// process exit code
+func runRepack(args []string) int {
+	var inputPath, outputPath string
+
+	fs := flag.NewFlagSet("repack", flag.ExitOnError)
+	fs.StringVar(&inputPath, "input", "", "Path to the GeneXus XPZ file (required)")
+	fs.StringVar(&outputPath, "output", "", "Path of the XPZ file to write (required)")
+	fs.Usage = printRepackUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if inputPath == "" || outputPath == "" {
+		utils.Error("Missing required flags: --input and --output")
+		fmt.Println()
+		printRepackUsage()
+		return 1
+	}
+	if err := validateArchive(inputPath); err != nil {
+		utils.Error("Invalid input: %v", err)
+		return 1
+	}
+
+	result, err := xpz.Extract(inputPath)
+	if err != nil {
+		utils.Error("Failed to extract XPZ: %v", err)
+		return 1
+	}
+	undocumented := make(map[string]model.GXObject)
+	for _, obj := range result.Objects {
+		if obj.Type == "Procedure" && !obj.IsDocumented() {
+			undocumented[path.Join(obj.Parent, obj.Path)] = obj
+		}
+	}
+	if len(undocumented) == 0 {
+		utils.Success("All procedures are documented; nothing to repack")
+		return 0
+	}
+
+	edited, err := xpz.RepackFile(inputPath, outputPath, func(module, name, source string) (string, bool) {
+		obj, ok := undocumented[path.Join(module, name)]
+		if !ok {
+			return source, false
+		}
+		utils.Verbose("Adding stub to %s", name)
+		return rewrite.InjectStub(source, rewrite.Stub(obj))
+	})
+	if err != nil {
+		utils.Error("Failed to write %s: %v", outputPath, err)
+		return 1
+	}
+
+	utils.Success("Repacked XPZ written to: %s", outputPath)
+	utils.Info("%d undocumented procedure(s) received a doc comment stub", edited)
+	return 0
+}
+
+// printRepackUsage prints the usage information for the repack subcommand
+func printRepackUsage() {
+	fmt.Println("GXDocGen repack - Write an XPZ with doc comment stubs in undocumented procedures (experimental)")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s repack --input <xpz-file> --output <xpz-file>\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --input <path>       Path to the GeneXus XPZ file (required)")
+	fmt.Println("  --output <path>      Path of the XPZ file to write; must differ from --input (required)")
+	fmt.Println()
+	fmt.Println("Import the output back into GeneXus and replace the TODO markers.")
+	fmt.Println()
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/rewrite"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// runRewrite implements the rewrite subcommand and returns the process exit code
+func runRewrite(args []string) int {
+	var (
+		inputPath  string
+		outputPath string
+		renames    patternList
+		dateFormat string
+		opts       rewrite.Options
+	)
+
+	fs := flag.NewFlagSet("rewrite", flag.ExitOnError)
+	fs.StringVar(&inputPath, "input", "", "Path to the GeneXus XPZ file (required)")
+	fs.StringVar(&outputPath, "output", "patches", "Directory to write one .patch file per changed object to")
+	fs.Var(&renames, "rename-tag", "Rename a tag, as old=new (e.g. @brief=@summary; repeatable)")
+	fs.BoolVar(&opts.NormalizeDirections, "normalize-directions", false, "Upper-case @param directions (in -> IN)")
+	fs.StringVar(&dateFormat, "date-format", "", "Convert @created dates in this format (e.g. dd/mm/yyyy) to yyyy-mm-dd")
+	fs.Usage = printRewriteUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if inputPath == "" {
+		utils.Error("Missing required flag: --input")
+		fmt.Println()
+		printRewriteUsage()
+		return 1
+	}
+	if err := validateInput(inputPath); err != nil {
+		utils.Error("Invalid input: %v", err)
+		return 1
+	}
+
+	opts.RenameTags = make(map[string]string)
+	for _, rename := range renames {
+		oldTag, newTag, ok := strings.Cut(rename, "=")
+		if !ok || strings.TrimSpace(oldTag) == "" || strings.TrimSpace(newTag) == "" {
+			utils.Error("Invalid --rename-tag value '%s' (expected old=new)", rename)
+			return 1
+		}
+		opts.RenameTags[tagName(oldTag)] = tagName(newTag)
+	}
+	opts.DateLayout = dateLayout(dateFormat)
+	if len(opts.RenameTags) == 0 && !opts.NormalizeDirections && opts.DateLayout == "" {
+		utils.Error("Nothing to do: use --rename-tag, --normalize-directions or --date-format")
+		return 1
+	}
+
+	result, err := xpz.Extract(inputPath)
+	if err != nil {
+		utils.Error("Failed to extract XPZ: %v", err)
+		return 1
+	}
+
+	patches := rewrite.Run(result.Objects, opts)
+	if len(patches) == 0 {
+		utils.Success("No doc comments need changes")
+		return 0
+	}
+
+	if err := os.MkdirAll(outputPath, os.ModePerm); err != nil {
+		utils.Error("Failed to create output directory: %v", err)
+		return 1
+	}
+	for _, patch := range patches {
+		path := filepath.Join(outputPath, patch.Object+".patch")
+		if err := os.WriteFile(path, []byte(patch.Diff), 0644); err != nil {
+			utils.Error("Failed to write %s: %v", path, err)
+			return 1
+		}
+		utils.Verbose("Wrote %s", path)
+	}
+
+	utils.Success("Patches written to: %s", outputPath)
+	utils.Info("%d of %d object(s) have suggested doc comment changes", len(patches), len(result.Objects))
+	return 0
+}
+
+// tagName returns a tag with a single leading @
+func tagName(tag string) string {
+	return "@" + strings.TrimPrefix(strings.TrimSpace(tag), "@")
+}
+
+// dateLayout converts a dd/mm/yyyy style date format to a Go time layout
+func dateLayout(format string) string {
+	return strings.NewReplacer("yyyy", "2006", "yy", "06", "mm", "01", "dd", "02").Replace(strings.ToLower(format))
+}
+
+// printRewriteUsage prints the usage information for the rewrite subcommand
+func printRewriteUsage() {
+	fmt.Println("GXDocGen rewrite - Suggest mechanical fixes to doc comments as patches")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s rewrite --input <xpz-file> [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --input <path>           Path to the GeneXus XPZ file (required)")
+	fmt.Println("  --output <dir>           Directory for one .patch file per object (default: patches)")
+	fmt.Println("  --rename-tag <old=new>   Rename a tag, e.g. @brief=@summary (repeatable)")
+	fmt.Println("  --normalize-directions   Upper-case @param directions (in -> IN)")
+	fmt.Println("  --date-format <format>   Convert @created dates from this format (e.g. dd/mm/yyyy) to yyyy-mm-dd")
+	fmt.Println()
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/config"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/generator"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/snapshot"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// snapshotTime is the fixed "Generated on" timestamp used for snapshots so
+// golden files do not change between runs
+var snapshotTime = time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// runSnapshot implements the snapshot subcommand and returns the process exit code
+func runSnapshot(args []string) int {
+	var (
+		inputPath  string
+		goldenPath string
+		configPath string
+		sortOrder  string
+		cheatsheet string
+		examples   bool
+		update     bool
+	)
+
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	fs.StringVar(&inputPath, "input", "", "Path to the fixture XPZ file (required)")
+	fs.StringVar(&goldenPath, "golden", "", "Directory holding the expected output (required)")
+	fs.StringVar(&configPath, "config", "", "Path to a JSON configuration file")
+	fs.StringVar(&sortOrder, "sort", generator.SortByName, "Sort listings by 'name', 'type' or 'package'")
+	fs.StringVar(&cheatsheet, "cheatsheet", "", "Also generate a cheat sheet grouped by 'package' or 'tag'")
+	fs.BoolVar(&examples, "examples", false, "Show example usages from call sites")
+	fs.BoolVar(&update, "update", false, "Replace the golden files with the current output")
+	fs.Usage = printSnapshotUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if inputPath == "" || goldenPath == "" {
+		utils.Error("Missing required flags: --input and --golden")
+		fmt.Println()
+		printSnapshotUsage()
+		return 1
+	}
+	if err := validateInput(inputPath); err != nil {
+		utils.Error("Invalid input: %v", err)
+		return 1
+	}
+
+	var (
+		badges       generator.BadgeThresholds
+		locale       string
+		lang         string
+		translations map[string]string
+	)
+	if configPath != "" {
+		cfg, err := config.Load(configPath)
+		if err != nil {
+			utils.Error("Invalid config: %v", err)
+			return 1
+		}
+		applyConfig(cfg)
+		badges = generator.BadgeThresholds{Green: cfg.Badges.Green, Yellow: cfg.Badges.Yellow}
+		locale = cfg.Locale
+		lang = cfg.Lang
+		if cfg.Translations != "" {
+			if translations, err = generator.LoadTranslations(cfg.Translations); err != nil {
+				utils.Error("Invalid config: %v", err)
+				return 1
+			}
+		}
+	}
+
+	result, err := xpz.Extract(inputPath)
+	if err != nil {
+		utils.Error("Failed to extract XPZ: %v", err)
+		return 1
+	}
+
+	actualPath, err := os.MkdirTemp("", "gxdocgen-snapshot-")
+	if err != nil {
+		utils.Error("Failed to create temporary directory: %v", err)
+		return 1
+	}
+	defer os.RemoveAll(actualPath)
+
+	// Generation progress is noise here; only the comparison matters
+	utils.SetLevel(utils.LevelQuiet)
+	err = generator.GenerateDocs(result.Objects, result.KBName, actualPath, generator.Options{
+		Sort:         sortOrder,
+		Locale:       locale,
+		Lang:         lang,
+		Translations: translations,
+		Cheatsheet:   cheatsheet,
+		Examples:     examples,
+		Badges:       badges,
+		Timestamp:    snapshotTime,
+	})
+	utils.SetLevel(utils.LevelNormal)
+	if err != nil {
+		utils.Error("Failed to generate documentation: %v", err)
+		return 1
+	}
+
+	if update {
+		if err := snapshot.Update(goldenPath, actualPath); err != nil {
+			utils.Error("Failed to update golden directory: %v", err)
+			return 1
+		}
+		utils.Success("Golden directory updated: %s", goldenPath)
+		return 0
+	}
+
+	diffs, err := snapshot.Compare(goldenPath, actualPath)
+	if err != nil {
+		utils.Error("Failed to compare output: %v", err)
+		return 1
+	}
+	if len(diffs) == 0 {
+		utils.Success("Output matches golden directory: %s", goldenPath)
+		return 0
+	}
+
+	for _, d := range diffs {
+		switch d.Kind {
+		case snapshot.Missing:
+			utils.Error("%s: missing from generated output", d.File)
+		case snapshot.Extra:
+			utils.Error("%s: not in golden directory", d.File)
+		default:
+			utils.Error("%s: content differs", d.File)
+			for _, line := range d.Diff {
+				fmt.Println("    " + line)
+			}
+		}
+	}
+	utils.Error("%d file(s) differ from %s (run with --update to accept)", len(diffs), goldenPath)
+	return 1
+}
+
+// printSnapshotUsage prints the usage information for the snapshot subcommand
+func printSnapshotUsage() {
+	fmt.Println("GXDocGen snapshot - Compare generated output against golden files")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s snapshot --input <xpz-file> --golden <dir> [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --input <path>       Fixture XPZ file (required)")
+	fmt.Println("  --golden <dir>       Directory holding the expected output (required)")
+	fmt.Println("  --config <path>      JSON configuration file")
+	fmt.Println("  --sort <mode>        Sort listings by 'name', 'type' or 'package' (default: name)")
+	fmt.Println("  --cheatsheet <mode>  Also generate a cheat sheet grouped by 'package' or 'tag'")
+	fmt.Println("  --examples           Show example usages from call sites")
+	fmt.Println("  --update             Replace the golden files with the current output")
+	fmt.Println()
+	fmt.Println("Output is generated with a fixed timestamp so it is reproducible.")
+	fmt.Println("Exits with code 1 when any file differs.")
+	fmt.Println()
+}
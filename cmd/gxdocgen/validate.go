@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/config"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// defaultMaxEntrySize is the largest archive entry read by default, so a
+// zip bomb cannot exhaust memory
+const defaultMaxEntrySize = "1GB"
+
+// runValidate implements the validate subcommand and returns the process exit code
+func runValidate(args []string) int {
+	var (
+		inputPath string
+		maxEntry  string
+	)
+
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	fs.StringVar(&inputPath, "input", "", "Path to the GeneXus XPZ file (required)")
+	fs.StringVar(&maxEntry, "max-entry-size", defaultMaxEntrySize, "Report archive entries larger than this once decompressed")
+	fs.Usage = printValidateUsage
+	if !parseFlags(fs, args) {
+		return 0
+	}
+
+	if inputPath == "" {
+		utils.Error("Missing required flag: --input")
+		fmt.Println()
+		printValidateUsage()
+		return 1
+	}
+	if err := validateArchive(inputPath); err != nil {
+		utils.Error("Invalid input: %v", err)
+		return 1
+	}
+	maxEntrySize, err := config.ParseSize(maxEntry)
+	if err != nil {
+		utils.Error("Invalid --max-entry-size: %v", err)
+		return 1
+	}
+
+	issues, err := xpz.Validate(inputPath, xpz.Options{MaxEntrySize: int64(maxEntrySize)})
+	if err != nil {
+		utils.Error("Failed to validate XPZ: %v", err)
+		return 1
+	}
+	for _, issue := range issues {
+		if issue.Entry == "" {
+			utils.Error("%s", issue.Problem)
+		} else {
+			utils.Error("%s: %s", issue.Entry, issue.Problem)
+		}
+	}
+	if len(issues) > 0 {
+		utils.Error("%d problem(s) found in %s", len(issues), inputPath)
+		return 1
+	}
+
+	utils.Success("No problems found in %s", inputPath)
+	return 0
+}
+
+// printValidateUsage prints the usage information for the validate subcommand
+func printValidateUsage() {
+	fmt.Println("GXDocGen validate - Check an XPZ archive for suspicious or corrupt entries")
+	fmt.Println()
+	fmt.Println("USAGE:")
+	fmt.Printf("  %s validate --input <xpz-file> [options]\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("FLAGS:")
+	fmt.Println("  --input <path>            Path to the GeneXus XPZ file (required)")
+	fmt.Println("  --max-entry-size <size>   Report entries larger than this once decompressed (default: 1GB)")
+	fmt.Println()
+	fmt.Println("Reports entries with unsafe paths (absolute or ../), duplicate or encrypted")
+	fmt.Println("entries, oversized or highly compressed entries, checksum errors and export")
+	fmt.Println("XML that is not well-formed. Exits with 1 when any problem is found.")
+	fmt.Println()
+}
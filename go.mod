@@ -3,9 +3,12 @@ module github.com/rubensantoniorosa2704/gxdocgen
 go 1.25.3
 
 require (
-	github.com/antchfx/xmlquery v1.5.0 // indirect
+	github.com/antchfx/xmlquery v1.5.0
+	golang.org/x/net v0.33.0
+	golang.org/x/text v0.21.0
+)
+
+require (
 	github.com/antchfx/xpath v1.3.5 // indirect
 	github.com/golang/groupcache v0.0.0-20210331224755-41bb18bfe9da // indirect
-	golang.org/x/net v0.33.0 // indirect
-	golang.org/x/text v0.21.0 // indirect
 )
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config holds settings loaded from a GXDocGen JSON configuration file
+type Config struct {
+	// Output is the output directory used when --output is not given.
+	// Supports {kb}, {date} and {version} placeholders.
+	Output string `json:"output"`
+
+	// CustomTags maps custom annotations to display labels
+	// (e.g., {"@ticket": "Ticket", "@compliance": "Compliance ID"})
+	CustomTags map[string]string `json:"customTags"`
+
+	// TagAliases maps localized tag names to the tags they stand for
+	// (e.g., {"@resumo": "@summary", "@parametro": "@param"})
+	TagAliases map[string]string `json:"tagAliases"`
+
+	// ObjectTypes maps GeneXus object type GUIDs to type names, on top of
+	// the built-in table (e.g. a GUID used by another GeneXus version)
+	ObjectTypes map[string]string `json:"objectTypes"`
+
+	// Include limits documentation to objects whose name or path matches
+	// one of these regular expressions
+	Include []string `json:"include"`
+
+	// Exclude skips objects whose name or path matches one of these
+	// regular expressions (e.g. test objects or bundled modules)
+	Exclude []string `json:"exclude"`
+
+	// Overlays are JSON files patching extracted objects before rendering,
+	// applied after any --overlay files
+	Overlays []string `json:"overlays"`
+
+	// Lang is the BCP 47 language of headings, counts and dates
+	// (e.g. "pt-BR"), used when --lang is not given
+	Lang string `json:"lang"`
+
+	// Translations is a JSON file overriding heading and label
+	// translations, used when --translations is not given
+	Translations string `json:"translations"`
+
+	// LocalizeValues also translates parameter directions, type names and
+	// "Unknown" authors, as --localize-values does
+	LocalizeValues bool `json:"localizeValues"`
+
+	// FrontMatter is a comma-separated list of YAML front matter fields
+	// (e.g. "title,tags"), used when --frontmatter is not given
+	FrontMatter string `json:"frontMatter"`
+
+	// DiagramCommand pre-renders Mermaid diagrams to SVG (e.g.
+	// "mmdc -i {input} -o {output}"), used when --diagram-command is not given
+	DiagramCommand string `json:"diagramCommand"`
+
+	// Locale is the BCP 47 locale whose collation orders alphabetical
+	// indexes (e.g. "pt-BR"), used when --locale is not given
+	Locale string `json:"locale"`
+
+	// ReturnConventions are the names of OUT parameters documented as the
+	// return value of procedures without @return (e.g. ["Result", "Retorno"]).
+	// Absent uses the built-in conventions; an empty list disables them.
+	ReturnConventions []string `json:"returnConventions"`
+
+	// Layers classifies objects into architecture layers for layers.md
+	Layers LayersConfig `json:"layers"`
+
+	// Badges sets badge color thresholds
+	Badges BadgeConfig `json:"badges"`
+
+	// Lint configures the lint subcommand
+	Lint LintConfig `json:"lint"`
+
+	// Limits protects runs from pathological objects
+	Limits LimitsConfig `json:"limits"`
+}
+
+// LimitsConfig holds skip-and-report limits. Zero values mean no limit.
+type LimitsConfig struct {
+	// MaxSourceSize is the largest object source parsed (e.g. "8MB")
+	MaxSourceSize string `json:"maxSourceSize"`
+
+	// MaxEntrySize is the largest archive entry read once decompressed
+	// (e.g. "512MB"); empty keeps the 1GB default
+	MaxEntrySize string `json:"maxEntrySize"`
+
+	// MaxObjects is the number of objects parsed per export file
+	MaxObjects int `json:"maxObjects"`
+
+	// PageTimeout is the longest a single page may take to render (e.g. "30s")
+	PageTimeout string `json:"pageTimeout"`
+}
+
+// LayersConfig lists architecture layers and the rules filing objects
+// under them
+type LayersConfig struct {
+	// Order lists the layers from top to bottom (e.g. ["UI", "Service", "Data"])
+	Order []string `json:"order"`
+
+	// Rules are tried in order; the first match wins
+	Rules []LayerRule `json:"rules"`
+}
+
+// LayerRule files objects whose name and module match the regular
+// expressions under Layer. An empty pattern matches anything.
+type LayerRule struct {
+	Layer  string `json:"layer"`
+	Name   string `json:"name"`
+	Module string `json:"module"`
+}
+
+// BadgeConfig holds the minimum score/coverage for green and yellow badges
+type BadgeConfig struct {
+	Green  float64 `json:"green"`
+	Yellow float64 `json:"yellow"`
+}
+
+// LintConfig holds lint rule settings
+type LintConfig struct {
+	// Rules overrides rule severities by name ("off", "warning" or "error")
+	Rules map[string]string `json:"rules"`
+}
+
+// Load reads and decodes a JSON configuration file
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	// Expand ${ENV_VAR} references in every string value
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	expanded, err := expandValue(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	if data, err = json.Marshal(expanded); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("GXDOCGEN_TEST_TEAM", "billing")
+	t.Setenv("GXDOCGEN_TEST_EMPTY", "")
+
+	tests := []struct {
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{"docs/${GXDOCGEN_TEST_TEAM}", "docs/billing", false},
+		{"${GXDOCGEN_TEST_EMPTY:-fallback}", "fallback", false},
+		{"${GXDOCGEN_TEST_UNSET:-}", "", false},
+		{"no references", "no references", false},
+		{"${GXDOCGEN_TEST_UNSET}", "", true},
+	}
+
+	for _, tt := range tests {
+		result, err := ExpandEnv(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ExpandEnv(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("ExpandEnv(%q) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestLoad_ExpandsEnvironment(t *testing.T) {
+	t.Setenv("GXDOCGEN_TEST_TAG", "@ticket")
+	t.Setenv("GXDOCGEN_TEST_EXCLUDE", "^Test")
+
+	path := filepath.Join(t.TempDir(), "gxdocgen.json")
+	content := `{
+  "output": "docs/{kb}",
+  "customTags": {"${GXDOCGEN_TEST_TAG}": "Ticket"},
+  "exclude": ["${GXDOCGEN_TEST_EXCLUDE}"],
+  "badges": {"green": 90}
+}`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if cfg.CustomTags["@ticket"] != "Ticket" {
+		t.Errorf("Expected expanded custom tag key, got %v", cfg.CustomTags)
+	}
+	if len(cfg.Exclude) != 1 || cfg.Exclude[0] != "^Test" {
+		t.Errorf("Expected expanded exclude pattern, got %v", cfg.Exclude)
+	}
+	if cfg.Output != "docs/{kb}" || cfg.Badges.Green != 90 {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+}
+
+func TestExpandTemplate(t *testing.T) {
+	vars := TemplateVars{KB: "SalesKB", Version: "1.2.0", Date: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)}
+
+	result := ExpandTemplate("docs/{kb}/{version}-{date}", vars)
+	if expected := "docs/SalesKB/1.2.0-2026-03-05"; result != expected {
+		t.Errorf("ExpandTemplate() = %q, expected %q", result, expected)
+	}
+}
+
+func TestParseSize(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"512", 512, false},
+		{"64KB", 64 << 10, false},
+		{"8mb", 8 << 20, false},
+		{"1 G", 1 << 30, false},
+		{"100B", 100, false},
+		{"-5MB", 0, true},
+		{"lots", 0, true},
+	}
+
+	for _, tt := range tests {
+		result, err := ParseSize(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseSize(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("ParseSize(%q) = %d, want %d", tt.input, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// envRegex matches ${VAR} and ${VAR:-default}
+var envRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// ExpandEnv replaces ${VAR} with the value of the environment variable VAR.
+// ${VAR:-default} falls back to default when VAR is unset or empty; a bare
+// ${VAR} that is unset is an error, so typos do not go unnoticed.
+func ExpandEnv(value string) (string, error) {
+	var missing []string
+	result := envRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		m := envRegex.FindStringSubmatch(ref)
+		if v := os.Getenv(m[1]); v != "" {
+			return v
+		}
+		if strings.Contains(ref, ":-") {
+			return m[2]
+		}
+		missing = append(missing, m[1])
+		return ""
+	})
+
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variable not set: %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// expandValue applies ExpandEnv to every string (keys included) in a decoded JSON value
+func expandValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return ExpandEnv(v)
+	case []interface{}:
+		for i, item := range v {
+			expanded, err := expandValue(item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+		return v, nil
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			expandedKey, err := ExpandEnv(key)
+			if err != nil {
+				return nil, err
+			}
+			expanded, err := expandValue(item)
+			if err != nil {
+				return nil, err
+			}
+			result[expandedKey] = expanded
+		}
+		return result, nil
+	default:
+		return v, nil
+	}
+}
+
+// TemplateVars are the values available to {placeholder} templates
+type TemplateVars struct {
+	KB      string
+	Version string
+	Date    time.Time
+}
+
+// ExpandTemplate replaces {kb}, {date} (YYYY-MM-DD) and {version} in value
+func ExpandTemplate(value string, vars TemplateVars) string {
+	return strings.NewReplacer(
+		"{kb}", vars.KB,
+		"{date}", vars.Date.Format("2006-01-02"),
+		"{version}", vars.Version,
+	).Replace(value)
+}
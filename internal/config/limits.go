@@ -0,0 +1,45 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits maps size suffixes to byte multipliers, longest suffix first
+var sizeUnits = []struct {
+	suffix     string
+	multiplier int
+}{
+	{"KB", 1 << 10},
+	{"MB", 1 << 20},
+	{"GB", 1 << 30},
+	{"K", 1 << 10},
+	{"M", 1 << 20},
+	{"G", 1 << 30},
+	{"B", 1},
+}
+
+// ParseSize parses a byte size such as "512", "64KB" or "8MB" (binary
+// units, case-insensitive). An empty string means 0.
+func ParseSize(value string) (int, error) {
+	text := strings.ToUpper(strings.TrimSpace(value))
+	if text == "" {
+		return 0, nil
+	}
+
+	multiplier := 1
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(text, unit.suffix) {
+			multiplier = unit.multiplier
+			text = strings.TrimSpace(strings.TrimSuffix(text, unit.suffix))
+			break
+		}
+	}
+
+	n, err := strconv.Atoi(text)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size '%s' (use e.g. 512KB or 8MB)", value)
+	}
+	return n * multiplier, nil
+}
@@ -0,0 +1,389 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// Parameter change kinds
+const (
+	ParamAdded     = "added"
+	ParamRemoved   = "removed"
+	ParamDirection = "direction"
+	ParamType      = "type"
+	ParamMoved     = "moved"
+)
+
+// Result lists the differences between two exports
+type Result struct {
+	Added   []model.GXObject
+	Removed []model.GXObject
+	Changed []Change
+}
+
+// Change describes an object present in both exports that differs
+type Change struct {
+	Name string
+	Type string
+	Old  model.GXObject
+	New  model.GXObject
+
+	// SignatureChanged is true when parameters were added, removed or altered
+	SignatureChanged bool
+
+	// ParamChanges details each parameter difference
+	ParamChanges []ParamChange
+
+	// SourceChanged is true when the source code differs, ignoring
+	// comment-only and whitespace-only edits
+	SourceChanged bool
+
+	// RulesChanged is true when the rules differ, ignoring comment-only
+	// and whitespace-only edits
+	RulesChanged bool
+
+	// VariablesChanged is true when variables were added, removed or
+	// retyped
+	VariablesChanged bool
+
+	// DocChanged is true when the annotations differ
+	DocChanged bool
+}
+
+// ParamChange describes a single parameter difference
+type ParamChange struct {
+	Kind         string `json:"kind"`
+	Name         string `json:"name"`
+	OldDirection string `json:"oldDirection,omitempty"`
+	NewDirection string `json:"newDirection,omitempty"`
+	OldType      string `json:"oldType,omitempty"`
+	NewType      string `json:"newType,omitempty"`
+	// OldPosition and NewPosition are 1-based, set for moved parameters
+	OldPosition int `json:"oldPosition,omitempty"`
+	NewPosition int `json:"newPosition,omitempty"`
+}
+
+// BreakingChange is a difference that can break existing callers
+type BreakingChange struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+// Report is the machine-readable form of a Result, without object sources
+type Report struct {
+	Added    []ObjectSummary  `json:"added"`
+	Removed  []ObjectSummary  `json:"removed"`
+	Changed  []ChangeSummary  `json:"changed"`
+	Breaking []BreakingChange `json:"breaking"`
+}
+
+// ObjectSummary identifies an added or removed object
+type ObjectSummary struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Signature string `json:"signature,omitempty"`
+}
+
+// ChangeSummary describes a changed object
+type ChangeSummary struct {
+	Name             string        `json:"name"`
+	Type             string        `json:"type"`
+	OldSignature     string        `json:"oldSignature,omitempty"`
+	NewSignature     string        `json:"newSignature,omitempty"`
+	SignatureChanged bool          `json:"signatureChanged"`
+	Breaking         bool          `json:"breaking"`
+	SourceChanged    bool          `json:"sourceChanged"`
+	RulesChanged     bool          `json:"rulesChanged"`
+	VariablesChanged bool          `json:"variablesChanged"`
+	DocChanged       bool          `json:"docChanged"`
+	ParamChanges     []ParamChange `json:"paramChanges,omitempty"`
+}
+
+// Compare matches objects by type and name and reports what was added,
+// removed or changed between the old and new exports. Results are sorted by name.
+func Compare(oldObjects, newObjects []model.GXObject) Result {
+	oldIndex := indexObjects(oldObjects)
+	newIndex := indexObjects(newObjects)
+
+	var result Result
+	for key, newObj := range newIndex {
+		oldObj, exists := oldIndex[key]
+		if !exists {
+			result.Added = append(result.Added, newObj)
+			continue
+		}
+		if change, changed := compareObjects(oldObj, newObj); changed {
+			result.Changed = append(result.Changed, change)
+		}
+	}
+	for key, oldObj := range oldIndex {
+		if _, exists := newIndex[key]; !exists {
+			result.Removed = append(result.Removed, oldObj)
+		}
+	}
+
+	sortObjects(result.Added)
+	sortObjects(result.Removed)
+	sort.Slice(result.Changed, func(i, j int) bool {
+		return result.Changed[i].Name < result.Changed[j].Name
+	})
+
+	return result
+}
+
+// IsEmpty reports whether the exports are equivalent
+func (r Result) IsEmpty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// Report returns the machine-readable form of the result
+func (r Result) Report() Report {
+	report := Report{
+		Added:    summarizeObjects(r.Added),
+		Removed:  summarizeObjects(r.Removed),
+		Changed:  make([]ChangeSummary, 0, len(r.Changed)),
+		Breaking: r.BreakingChanges(),
+	}
+	for _, c := range r.Changed {
+		summary := ChangeSummary{
+			Name:             c.Name,
+			Type:             c.Type,
+			SignatureChanged: c.SignatureChanged,
+			Breaking:         c.IsBreaking(),
+			SourceChanged:    c.SourceChanged,
+			RulesChanged:     c.RulesChanged,
+			VariablesChanged: c.VariablesChanged,
+			DocChanged:       c.DocChanged,
+			ParamChanges:     c.ParamChanges,
+		}
+		if c.SignatureChanged {
+			summary.OldSignature = c.Old.ParmSignature
+			summary.NewSignature = c.New.ParmSignature
+		}
+		report.Changed = append(report.Changed, summary)
+	}
+	return report
+}
+
+// BreakingChanges lists removed objects and signature changes that break
+// existing callers, never nil
+func (r Result) BreakingChanges() []BreakingChange {
+	breaking := make([]BreakingChange, 0)
+	for _, obj := range r.Removed {
+		breaking = append(breaking, BreakingChange{Name: obj.Path, Type: obj.Type, Reason: "Object removed"})
+	}
+	for _, c := range r.Changed {
+		for _, pc := range c.ParamChanges {
+			if pc.IsBreaking() {
+				breaking = append(breaking, BreakingChange{Name: c.Name, Type: c.Type, Reason: pc.String()})
+			}
+		}
+	}
+	sort.SliceStable(breaking, func(i, j int) bool {
+		return breaking[i].Name < breaking[j].Name
+	})
+	return breaking
+}
+
+// CodeChanged reports whether the implementation changed (source, rules or
+// variables), as opposed to documentation-only edits
+func (c Change) CodeChanged() bool {
+	return c.SourceChanged || c.RulesChanged || c.VariablesChanged
+}
+
+// CodeParts lists the changed implementation parts ("rules", "source",
+// "variables")
+func (c Change) CodeParts() []string {
+	var parts []string
+	if c.RulesChanged {
+		parts = append(parts, "rules")
+	}
+	if c.SourceChanged {
+		parts = append(parts, "source")
+	}
+	if c.VariablesChanged {
+		parts = append(parts, "variables")
+	}
+	return parts
+}
+
+// IsBreaking reports whether any parameter change breaks existing callers
+func (c Change) IsBreaking() bool {
+	for _, pc := range c.ParamChanges {
+		if pc.IsBreaking() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsBreaking reports whether callers written against the old signature can
+// break. GeneXus calls pass every parameter by position, so added, removed
+// and moved parameters break them, as do changed directions or types.
+func (c ParamChange) IsBreaking() bool {
+	switch c.Kind {
+	case ParamAdded, ParamRemoved, ParamMoved, ParamDirection, ParamType:
+		return true
+	}
+	return false
+}
+
+// summarizeObjects returns the summaries of objects, never nil
+func summarizeObjects(objects []model.GXObject) []ObjectSummary {
+	summaries := make([]ObjectSummary, 0, len(objects))
+	for _, obj := range objects {
+		summaries = append(summaries, ObjectSummary{Name: obj.Path, Type: obj.Type, Signature: obj.ParmSignature})
+	}
+	return summaries
+}
+
+// indexObjects keys objects by type and name
+func indexObjects(objects []model.GXObject) map[string]model.GXObject {
+	index := make(map[string]model.GXObject)
+	for _, obj := range objects {
+		index[obj.Type+"|"+obj.Path] = obj
+	}
+	return index
+}
+
+// sortObjects orders objects by name
+func sortObjects(objects []model.GXObject) {
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Path < objects[j].Path
+	})
+}
+
+// compareObjects reports the differences between two versions of an object
+func compareObjects(oldObj, newObj model.GXObject) (Change, bool) {
+	change := Change{
+		Name: newObj.Path,
+		Type: newObj.Type,
+		Old:  oldObj,
+		New:  newObj,
+	}
+
+	change.ParamChanges = CompareParameters(oldObj.Parameters, newObj.Parameters)
+	change.SignatureChanged = len(change.ParamChanges) > 0
+	change.SourceChanged = codeOnly(oldObj.SourceCode) != codeOnly(newObj.SourceCode)
+	change.RulesChanged = codeOnly(oldObj.Rules) != codeOnly(newObj.Rules)
+	change.VariablesChanged = variablesChanged(oldObj.Variables, newObj.Variables)
+	change.DocChanged = docFingerprint(oldObj.Documentation) != docFingerprint(newObj.Documentation)
+
+	return change, change.SignatureChanged || change.CodeChanged() || change.DocChanged
+}
+
+// CompareParameters lists added, removed and modified parameters, matched
+// by name. Calls pass parameters by position, so the parameters kept in
+// both are also compared in order, and one that now comes before or after
+// another kept parameter is reported as moved.
+func CompareParameters(oldParams, newParams []model.ParameterDoc) []ParamChange {
+	oldByName := make(map[string]model.ParameterDoc)
+	for _, p := range oldParams {
+		oldByName[p.Name] = p
+	}
+	newByName := make(map[string]model.ParameterDoc)
+	for _, p := range newParams {
+		newByName[p.Name] = p
+	}
+
+	var changes []ParamChange
+	for _, p := range oldParams {
+		if _, exists := newByName[p.Name]; !exists {
+			changes = append(changes, ParamChange{Kind: ParamRemoved, Name: p.Name, OldDirection: p.Direction, OldType: p.Type})
+		}
+	}
+	for _, p := range newParams {
+		old, exists := oldByName[p.Name]
+		if !exists {
+			changes = append(changes, ParamChange{Kind: ParamAdded, Name: p.Name, NewDirection: p.Direction, NewType: p.Type})
+			continue
+		}
+		if old.Direction != p.Direction {
+			changes = append(changes, ParamChange{Kind: ParamDirection, Name: p.Name, OldDirection: old.Direction, NewDirection: p.Direction})
+		}
+		if old.Type != p.Type {
+			changes = append(changes, ParamChange{Kind: ParamType, Name: p.Name, OldType: old.Type, NewType: p.Type})
+		}
+	}
+
+	oldKept, newKept := keptPositions(oldParams, newByName), keptPositions(newParams, oldByName)
+	for i, kept := range newKept {
+		if i < len(oldKept) && oldKept[i].name != kept.name {
+			changes = append(changes, ParamChange{Kind: ParamMoved, Name: kept.name, OldPosition: positionOf(oldKept, kept.name), NewPosition: kept.position})
+		}
+	}
+
+	return changes
+}
+
+// keptParam is a parameter present in both signatures with its 1-based
+// position in one of them
+type keptParam struct {
+	name     string
+	position int
+}
+
+// keptPositions lists the parameters of params also found in other, in order
+func keptPositions(params []model.ParameterDoc, other map[string]model.ParameterDoc) []keptParam {
+	var kept []keptParam
+	for i, p := range params {
+		if _, exists := other[p.Name]; exists {
+			kept = append(kept, keptParam{name: p.Name, position: i + 1})
+		}
+	}
+	return kept
+}
+
+// positionOf returns the position of the kept parameter named name
+func positionOf(kept []keptParam, name string) int {
+	for _, k := range kept {
+		if k.name == name {
+			return k.position
+		}
+	}
+	return 0
+}
+
+// docFingerprint summarizes the fields of a DocComment relevant to readers
+func docFingerprint(doc *model.DocComment) string {
+	if doc == nil {
+		return ""
+	}
+	parts := []string{doc.Package, doc.Summary, doc.Description, doc.Return, doc.DeprecationNote, doc.Since}
+	if doc.Deprecated {
+		parts = append(parts, "deprecated")
+	}
+	for _, p := range doc.Parameters {
+		parts = append(parts, p.Name+" "+p.Direction+" "+p.Type+" "+p.Description)
+	}
+	return strings.Join(parts, "\x00")
+}
+
+// String describes the parameter change in a human-readable sentence
+func (c ParamChange) String() string {
+	switch c.Kind {
+	case ParamAdded:
+		return "Added parameter `" + c.Name + "` (" + strings.TrimSpace(c.NewDirection+" "+c.NewType) + ")"
+	case ParamRemoved:
+		return "Removed parameter `" + c.Name + "`"
+	case ParamDirection:
+		return "Direction of `" + c.Name + "` changed from " + c.OldDirection + " to " + c.NewDirection
+	case ParamType:
+		return "Type of `" + c.Name + "` changed from " + valueOrDash(c.OldType) + " to " + valueOrDash(c.NewType)
+	case ParamMoved:
+		return fmt.Sprintf("Parameter `%s` moved from position %d to %d", c.Name, c.OldPosition, c.NewPosition)
+	}
+	return c.Kind + " `" + c.Name + "`"
+}
+
+// valueOrDash returns "-" for empty values
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
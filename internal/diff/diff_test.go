@@ -0,0 +1,155 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestCompare(t *testing.T) {
+	oldObjects := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", SourceCode: "&X = 1", Parameters: []model.ParameterDoc{
+			{Name: "UserID", Direction: "IN", Type: "Numeric"},
+			{Name: "Total", Direction: "OUT"},
+		}},
+		{Path: "DeleteUser", Type: "Procedure"},
+		{Path: "Unchanged", Type: "Procedure", SourceCode: "&Y = 2"},
+	}
+	newObjects := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", SourceCode: "&X = 1", Parameters: []model.ParameterDoc{
+			{Name: "UserID", Direction: "IN", Type: "Character"},
+			{Name: "Total", Direction: "INOUT"},
+			{Name: "Messages", Direction: "OUT"},
+		}},
+		{Path: "RemoveUser", Type: "Procedure"},
+		{Path: "Unchanged", Type: "Procedure", SourceCode: "&Y = 2\n"},
+	}
+
+	result := Compare(oldObjects, newObjects)
+
+	if len(result.Added) != 1 || result.Added[0].Path != "RemoveUser" {
+		t.Errorf("Expected RemoveUser to be added, got %+v", result.Added)
+	}
+	if len(result.Removed) != 1 || result.Removed[0].Path != "DeleteUser" {
+		t.Errorf("Expected DeleteUser to be removed, got %+v", result.Removed)
+	}
+	if len(result.Changed) != 1 {
+		t.Fatalf("Expected 1 changed object, got %d", len(result.Changed))
+	}
+
+	change := result.Changed[0]
+	if !change.SignatureChanged || change.SourceChanged {
+		t.Errorf("Expected signature-only change, got %+v", change)
+	}
+
+	kinds := make(map[string]string)
+	for _, pc := range change.ParamChanges {
+		kinds[pc.Name] = pc.Kind
+	}
+	expected := map[string]string{"UserID": ParamType, "Total": ParamDirection, "Messages": ParamAdded}
+	for name, kind := range expected {
+		if kinds[name] != kind {
+			t.Errorf("Expected %s change for %s, got %q", kind, name, kinds[name])
+		}
+	}
+}
+
+func TestParamChangeString(t *testing.T) {
+	pc := ParamChange{Kind: ParamDirection, Name: "Total", OldDirection: "OUT", NewDirection: "INOUT"}
+	expected := "Direction of `Total` changed from OUT to INOUT"
+	if pc.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, pc.String())
+	}
+}
+
+func TestReport(t *testing.T) {
+	oldObjects := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", ParmSignature: "parm(in:&Id);", Parameters: []model.ParameterDoc{{Name: "Id", Direction: "IN"}}},
+		{Path: "Legacy", Type: "Procedure"},
+	}
+	newObjects := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", ParmSignature: "parm(inout:&Id);", Parameters: []model.ParameterDoc{{Name: "Id", Direction: "INOUT"}}},
+	}
+
+	report := Compare(oldObjects, newObjects).Report()
+	if len(report.Added) != 0 || len(report.Removed) != 1 || report.Removed[0].Name != "Legacy" {
+		t.Errorf("Unexpected added/removed: %+v", report)
+	}
+	if len(report.Changed) != 1 {
+		t.Fatalf("Expected 1 changed object, got %d", len(report.Changed))
+	}
+	change := report.Changed[0]
+	if change.OldSignature != "parm(in:&Id);" || change.NewSignature != "parm(inout:&Id);" || len(change.ParamChanges) != 1 {
+		t.Errorf("Unexpected change: %+v", change)
+	}
+}
+
+func TestBreakingChanges(t *testing.T) {
+	oldObjects := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", Parameters: []model.ParameterDoc{{Name: "Id", Direction: "IN"}, {Name: "Name", Direction: "OUT"}}},
+		{Path: "AddOnly", Type: "Procedure", Parameters: []model.ParameterDoc{{Name: "Id", Direction: "IN"}}},
+		{Path: "Legacy", Type: "Procedure"},
+	}
+	newObjects := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", Parameters: []model.ParameterDoc{{Name: "Id", Direction: "INOUT"}}},
+		{Path: "AddOnly", Type: "Procedure", Parameters: []model.ParameterDoc{{Name: "Id", Direction: "IN"}, {Name: "Extra", Direction: "OUT"}}},
+	}
+
+	result := Compare(oldObjects, newObjects)
+	breaking := result.BreakingChanges()
+	if len(breaking) != 4 {
+		t.Fatalf("Expected 4 breaking changes, got %+v", breaking)
+	}
+	expected := []string{"AddOnly", "GetUser", "GetUser", "Legacy"}
+	for i, name := range expected {
+		if breaking[i].Name != name {
+			t.Errorf("Expected %s at position %d, got %s", name, i, breaking[i].Name)
+		}
+	}
+	if breaking[0].Reason != "Added parameter `Extra` (OUT)" {
+		t.Errorf("Expected the added parameter to break callers, got %q", breaking[0].Reason)
+	}
+	for _, change := range result.Changed {
+		if !change.IsBreaking() {
+			t.Errorf("Expected %s to be breaking", change.Name)
+		}
+	}
+}
+
+func TestCompareParametersReordered(t *testing.T) {
+	oldParams := []model.ParameterDoc{
+		{Name: "Id", Direction: "IN"},
+		{Name: "Name", Direction: "IN"},
+		{Name: "Total", Direction: "OUT"},
+	}
+	newParams := []model.ParameterDoc{
+		{Name: "Name", Direction: "IN"},
+		{Name: "Id", Direction: "IN"},
+		{Name: "Total", Direction: "OUT"},
+		{Name: "Messages", Direction: "OUT"},
+	}
+
+	changes := CompareParameters(oldParams, newParams)
+	expected := []ParamChange{
+		{Kind: ParamAdded, Name: "Messages", NewDirection: "OUT"},
+		{Kind: ParamMoved, Name: "Name", OldPosition: 2, NewPosition: 1},
+		{Kind: ParamMoved, Name: "Id", OldPosition: 1, NewPosition: 2},
+	}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, changes)
+	}
+	for _, pc := range changes {
+		if !pc.IsBreaking() {
+			t.Errorf("Expected %s to be breaking", pc)
+		}
+	}
+	if got := changes[1].String(); got != "Parameter `Name` moved from position 2 to 1" {
+		t.Errorf("Unexpected description: %q", got)
+	}
+
+	// Appending keeps every other parameter in place
+	if changes := CompareParameters(oldParams, append(oldParams[:3:3], model.ParameterDoc{Name: "Extra"})); len(changes) != 1 || changes[0].Kind != ParamAdded {
+		t.Errorf("Expected only the added parameter, got %+v", changes)
+	}
+}
@@ -0,0 +1,96 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// codeOnly returns source without comments, blank lines and insignificant
+// whitespace, so edits to comments or indentation compare equal. String
+// literals are kept as written.
+func codeOnly(source string) string {
+	var (
+		out     strings.Builder
+		quote   rune
+		block   bool
+		line    bool
+		pending bool
+	)
+	runes := []rune(strings.ReplaceAll(source, "\r\n", "\n"))
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case block:
+			if r == '*' && next == '/' {
+				block = false
+				i++
+			}
+			continue
+		case line:
+			if r != '\n' {
+				continue
+			}
+			line = false
+		case quote != 0:
+			out.WriteRune(r)
+			if r == quote {
+				quote = 0
+			}
+			continue
+		case r == '/' && next == '*':
+			block = true
+			i++
+			continue
+		case r == '/' && next == '/':
+			line = true
+			i++
+			continue
+		}
+
+		switch {
+		case r == '\n':
+			pending = false
+			if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+				out.WriteRune('\n')
+			}
+		case r == ' ' || r == '\t' || r == '\r':
+			pending = true
+		default:
+			if pending && out.Len() > 0 && !strings.HasSuffix(out.String(), "\n") {
+				out.WriteRune(' ')
+			}
+			pending = false
+			if r == '\'' || r == '"' {
+				quote = r
+			}
+			out.WriteRune(r)
+		}
+	}
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+// variablesChanged reports whether variables were added, removed or had
+// their type or collection flag changed. Description edits are
+// documentation and do not count.
+func variablesChanged(oldVars, newVars []model.Variable) bool {
+	if len(oldVars) != len(newVars) {
+		return true
+	}
+	oldByName := make(map[string]model.Variable)
+	for _, v := range oldVars {
+		oldByName[strings.ToLower(v.Name)] = v
+	}
+	for _, v := range newVars {
+		old, exists := oldByName[strings.ToLower(v.Name)]
+		if !exists || old.Type != v.Type || old.IsCollection != v.IsCollection {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestCodeOnly(t *testing.T) {
+	base := "&Total = 0\nFor Each Order\n  &Total += OrderAmount\nEndFor"
+	same := []string{
+		"/**\n * @summary Sums orders\n */\n&Total = 0\nFor Each Order\n\t&Total  +=  OrderAmount // running total\nEndFor\n",
+		"&Total = 0\r\n\r\nFor Each Order /* all */\r\n    &Total += OrderAmount\r\nEndFor",
+	}
+	for _, source := range same {
+		if codeOnly(source) != codeOnly(base) {
+			t.Errorf("Expected %q to compare equal:\n%q\n%q", source, codeOnly(source), codeOnly(base))
+		}
+	}
+
+	different := []string{
+		"&Total = 1\nFor Each Order\n  &Total += OrderAmount\nEndFor",
+		"&Total = 0 For Each Order\n  &Total += OrderAmount\nEndFor",
+	}
+	for _, source := range different {
+		if codeOnly(source) == codeOnly(base) {
+			t.Errorf("Expected %q to differ", source)
+		}
+	}
+
+	if codeOnly("&Msg = '// not a comment'") != "&Msg = '// not a comment'" {
+		t.Error("Expected string literals to be kept")
+	}
+}
+
+func TestCompareClassifiesParts(t *testing.T) {
+	oldObjects := []model.GXObject{
+		{Path: "DocOnly", Type: "Procedure", SourceCode: "/** @summary Old */\n&X = 1",
+			Documentation: &model.DocComment{Summary: "Old"}},
+		{Path: "Rules", Type: "Procedure", Rules: "parm(in:&X);"},
+		{Path: "Vars", Type: "Procedure", Variables: []model.Variable{{Name: "X", Type: "Numeric", Description: "Old"}}},
+		{Path: "VarDoc", Type: "Procedure", Variables: []model.Variable{{Name: "X", Type: "Numeric", Description: "Old"}}},
+	}
+	newObjects := []model.GXObject{
+		{Path: "DocOnly", Type: "Procedure", SourceCode: "/** @summary New */\n&X = 1",
+			Documentation: &model.DocComment{Summary: "New"}},
+		{Path: "Rules", Type: "Procedure", Rules: "parm(in:&X);\nerror('x') if &X = 0;"},
+		{Path: "Vars", Type: "Procedure", Variables: []model.Variable{{Name: "X", Type: "Numeric", IsCollection: true}}},
+		{Path: "VarDoc", Type: "Procedure", Variables: []model.Variable{{Name: "X", Type: "Numeric", Description: "New"}}},
+	}
+
+	changes := make(map[string]Change)
+	for _, c := range Compare(oldObjects, newObjects).Changed {
+		changes[c.Name] = c
+	}
+
+	if c := changes["DocOnly"]; !c.DocChanged || c.CodeChanged() {
+		t.Errorf("Expected a documentation-only change, got %+v", c)
+	}
+	if c := changes["Rules"]; !c.RulesChanged || c.SourceChanged || c.VariablesChanged {
+		t.Errorf("Expected a rules change, got %+v", c)
+	}
+	if c := changes["Vars"]; !c.VariablesChanged || c.RulesChanged || c.SourceChanged {
+		t.Errorf("Expected a variables change, got %+v", c)
+	}
+	if _, changed := changes["VarDoc"]; changed {
+		t.Error("Expected variable description edits to be ignored")
+	}
+}
@@ -0,0 +1,128 @@
+package fixture
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// Options controls the shape of a synthetic export
+type Options struct {
+	// KB is the knowledge base name written to the export
+	KB string
+	// Procedures is the number of procedures to generate
+	Procedures int
+	// Transactions is the number of transactions to generate
+	Transactions int
+	// Packages is the number of @package groups procedures are spread over
+	Packages int
+}
+
+// withDefaults fills in zero values
+func (o Options) withDefaults() Options {
+	if o.KB == "" {
+		o.KB = "FixtureKB"
+	}
+	if o.Packages < 1 {
+		o.Packages = 1
+	}
+	return o
+}
+
+// Write writes a synthetic XPZ archive with a single export.xml entry. The
+// output is deterministic: the same options always produce the same archive.
+func Write(w io.Writer, opts Options) error {
+	opts = opts.withDefaults()
+	archive := xpz.NewWriter(w)
+	if err := archive.WriteExport("export.xml", opts.KB, Objects(opts)); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// Objects returns the objects of a synthetic KB.
+//
+// Procedures are spread round-robin over packages and each calls the next
+// one in its package. Every fifth procedure has no doc comment and every
+// tenth, starting with the third, is deprecated in favor of its successor,
+// so coverage, call graph and migration pages all have something to show.
+func Objects(opts Options) []model.GXObject {
+	opts = opts.withDefaults()
+
+	var objects []model.GXObject
+	for i := 1; i <= opts.Procedures; i++ {
+		objects = append(objects, procedure(i, opts))
+	}
+	for i := 1; i <= opts.Transactions; i++ {
+		objects = append(objects, transaction(i))
+	}
+	return objects
+}
+
+// transaction returns the i-th transaction. Each one after the first
+// references the previous one through a foreign key.
+func transaction(i int) model.GXObject {
+	name := fmt.Sprintf("Trn%04d", i)
+	level := model.Level{
+		Name: name,
+		Attributes: []model.Attribute{
+			{Name: name + "Id", Type: "Numeric", Description: "Record identifier", Key: true},
+			{Name: name + "Name", Type: "Character", Description: "Record name"},
+		},
+	}
+	if i > 1 {
+		level.Attributes = append(level.Attributes, model.Attribute{Name: fmt.Sprintf("Trn%04dId", i-1)})
+	}
+	return model.GXObject{
+		Type:           "Transaction",
+		Path:           name,
+		XMLDescription: fmt.Sprintf("Synthetic transaction %d", i),
+		Documentation:  &model.DocComment{Author: "fixture"},
+		Levels:         []model.Level{level},
+	}
+}
+
+// procedureName returns the name of the i-th procedure
+func procedureName(i int) string {
+	return fmt.Sprintf("Proc%04d", i)
+}
+
+// procedure returns the i-th procedure
+func procedure(i int, opts Options) model.GXObject {
+	pkg := fmt.Sprintf("pkg%02d", (i-1)%opts.Packages+1)
+
+	var source strings.Builder
+	if i%5 != 0 {
+		source.WriteString("/**\n")
+		source.WriteString(fmt.Sprintf(" * @package %s\n", pkg))
+		source.WriteString(fmt.Sprintf(" * @summary Synthetic procedure %d\n", i))
+		source.WriteString(" * @description Generated by the fixture command for testing.\n")
+		source.WriteString(" * @param Id IN Numeric - Record identifier\n")
+		source.WriteString(" * @param Result OUT Numeric - Computed result\n")
+		source.WriteString(" * @return Result - The computed value\n")
+		if i%10 == 3 && i+opts.Packages <= opts.Procedures {
+			source.WriteString(fmt.Sprintf(" * @deprecated Use %s instead\n", procedureName(i+opts.Packages)))
+		}
+		source.WriteString(" * @since 1.0\n")
+		source.WriteString(" */\n")
+	}
+	source.WriteString("&Result = &Id * 2\n")
+	if next := i + opts.Packages; next <= opts.Procedures {
+		source.WriteString(fmt.Sprintf("%s.Call(&Id, &Result)\n", procedureName(next)))
+	}
+
+	return model.GXObject{
+		Type:           "Procedure",
+		Path:           procedureName(i),
+		SourceCode:     source.String(),
+		XMLDescription: fmt.Sprintf("Synthetic procedure %d", i),
+		Parameters: []model.ParameterDoc{
+			{Name: "Id", Direction: "IN", Type: "Numeric", Description: "Record identifier"},
+			{Name: "Result", Direction: "OUT", Type: "Numeric", Description: "Computed result"},
+		},
+		Documentation: &model.DocComment{Package: pkg, Author: "fixture"},
+	}
+}
@@ -0,0 +1,76 @@
+package fixture
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+func TestWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixture.xpz")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(file, Options{KB: "Demo", Procedures: 20, Transactions: 2, Packages: 3}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	file.Close()
+
+	result, err := xpz.Extract(path)
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if result.KBName != "Demo" {
+		t.Errorf("Expected KB name 'Demo', got '%s'", result.KBName)
+	}
+	if len(result.Objects) != 22 {
+		t.Fatalf("Expected 20 procedures and 2 transactions, got %d objects", len(result.Objects))
+	}
+
+	documented, deprecated := 0, 0
+	for _, obj := range result.Objects {
+		if obj.IsDocumented() {
+			documented++
+			if obj.Documentation.Deprecated {
+				deprecated++
+			}
+		}
+	}
+	if documented != 16 {
+		t.Errorf("Expected 16 documented procedures, got %d", documented)
+	}
+	if deprecated != 2 {
+		t.Errorf("Expected 2 deprecated procedures, got %d", deprecated)
+	}
+
+	first := result.Objects[0]
+	if first.Path != "Proc0001" || first.Documentation.Package != "pkg01" || len(first.Parameters) != 2 {
+		t.Errorf("Unexpected first procedure: %+v", first)
+	}
+
+	trn := result.Objects[21]
+	if trn.Type != "Transaction" || len(trn.Levels) != 1 || len(trn.Levels[0].Attributes) != 3 {
+		t.Fatalf("Unexpected transaction: %+v", trn)
+	}
+	if fk := trn.Levels[0].Attributes[2]; fk.Name != "Trn0001Id" || fk.References != "Trn0001" || fk.Type != "Numeric" {
+		t.Errorf("Expected a foreign key to Trn0001, got %+v", fk)
+	}
+}
+
+func TestWriteDeterministic(t *testing.T) {
+	opts := Options{Procedures: 5, Transactions: 1}
+	var first, second bytes.Buffer
+	if err := Write(&first, opts); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(&second, opts); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(first.Bytes(), second.Bytes()) {
+		t.Error("Write should produce identical output for identical options")
+	}
+}
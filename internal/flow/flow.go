@@ -0,0 +1,159 @@
+package flow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+)
+
+// Pre-compiled statement patterns, matched against trimmed source lines
+var (
+	forEachRegex    = regexp.MustCompile(`(?i)^for\s+each\b\s*([A-Za-z_]\w*)?`)
+	forInRegex      = regexp.MustCompile(`(?i)^for\s+&(\w+)\s+in\s+(&?[\w.]+)`)
+	forRangeRegex   = regexp.MustCompile(`(?i)^for\s+&(\w+)\s*=`)
+	doWhileRegex    = regexp.MustCompile(`(?i)^do\s+while\b\s*(.*)$`)
+	ifRegex         = regexp.MustCompile(`(?i)^if\b`)
+	doCaseRegex     = regexp.MustCompile(`(?i)^do\s+case\b`)
+	returnRegex     = regexp.MustCompile(`(?i)^return\b`)
+	errorRegex      = regexp.MustCompile(`(?i)^(?:error|msg)\s*\(`)
+	newRegex        = regexp.MustCompile(`(?i)^new\b`)
+	deleteRegex     = regexp.MustCompile(`(?i)^delete\b`)
+	commitRegex     = regexp.MustCompile(`(?i)^commit\b`)
+	rollbackRegex   = regexp.MustCompile(`(?i)^rollback\b`)
+	endBlockRegex   = regexp.MustCompile(`(?i)^(?:endif|endfor|enddo|endcase)\b`)
+	startBlockRegex = regexp.MustCompile(`(?i)^(?:if|for|do\s+while|do\s+case)\b`)
+)
+
+// Summary is a heuristic description of a procedure's control flow
+type Summary struct {
+	// Loops describes each main loop (e.g., "For Each Order")
+	Loops []string
+
+	// Calls lists invoked objects in order of appearance
+	Calls []string
+
+	// Conditions counts If and Do Case statements
+	Conditions int
+
+	// EarlyExits counts Return statements nested inside a block
+	EarlyExits int
+
+	// Errors counts Error()/Msg() statements
+	Errors int
+
+	// Inserts, Deletes, Commits and Rollbacks flag database operations
+	Inserts, Deletes, Commits, Rollbacks bool
+}
+
+// Analyze builds a flow summary from GeneXus procedure source
+func Analyze(source string) Summary {
+	source = graph.StripComments(source)
+	summary := Summary{Calls: graph.ExtractCalls(source)}
+
+	depth := 0
+	for _, line := range strings.Split(source, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if endBlockRegex.MatchString(line) {
+			if depth > 0 {
+				depth--
+			}
+			continue
+		}
+
+		switch {
+		case forEachRegex.MatchString(line):
+			if depth == 0 {
+				loop := "For Each"
+				if table := forEachRegex.FindStringSubmatch(line)[1]; table != "" {
+					loop += " " + table
+				}
+				summary.Loops = append(summary.Loops, loop)
+			}
+		case forInRegex.MatchString(line):
+			if depth == 0 {
+				m := forInRegex.FindStringSubmatch(line)
+				summary.Loops = append(summary.Loops, fmt.Sprintf("For &%s in %s", m[1], m[2]))
+			}
+		case forRangeRegex.MatchString(line):
+			if depth == 0 {
+				summary.Loops = append(summary.Loops, fmt.Sprintf("For &%s", forRangeRegex.FindStringSubmatch(line)[1]))
+			}
+		case doWhileRegex.MatchString(line):
+			if depth == 0 {
+				summary.Loops = append(summary.Loops, strings.TrimSpace("Do While "+doWhileRegex.FindStringSubmatch(line)[1]))
+			}
+		case ifRegex.MatchString(line), doCaseRegex.MatchString(line):
+			summary.Conditions++
+		case returnRegex.MatchString(line):
+			if depth > 0 {
+				summary.EarlyExits++
+			}
+		case errorRegex.MatchString(line):
+			summary.Errors++
+		case newRegex.MatchString(line):
+			summary.Inserts = true
+		case deleteRegex.MatchString(line):
+			summary.Deletes = true
+		case commitRegex.MatchString(line):
+			summary.Commits = true
+		case rollbackRegex.MatchString(line):
+			summary.Rollbacks = true
+		}
+
+		if startBlockRegex.MatchString(line) {
+			depth++
+		}
+	}
+
+	return summary
+}
+
+// IsEmpty reports whether the analysis found nothing worth describing
+func (s Summary) IsEmpty() bool {
+	return len(s.Lines()) == 0
+}
+
+// Lines renders the summary as short sentences, most significant first
+func (s Summary) Lines() []string {
+	var lines []string
+	for _, loop := range s.Loops {
+		lines = append(lines, "Loops: "+loop)
+	}
+	if len(s.Calls) > 0 {
+		lines = append(lines, "Calls "+strings.Join(s.Calls, ", "))
+	}
+	if s.Conditions > 0 {
+		lines = append(lines, fmt.Sprintf("Branches on %d condition(s)", s.Conditions))
+	}
+
+	var writes []string
+	if s.Inserts {
+		writes = append(writes, "inserts records")
+	}
+	if s.Deletes {
+		writes = append(writes, "deletes records")
+	}
+	if s.Commits {
+		writes = append(writes, "commits")
+	}
+	if s.Rollbacks {
+		writes = append(writes, "rolls back")
+	}
+	if len(writes) > 0 {
+		lines = append(lines, "Database: "+strings.Join(writes, ", "))
+	}
+
+	if s.EarlyExits > 0 {
+		lines = append(lines, fmt.Sprintf("Exits early in %d place(s) (Return)", s.EarlyExits))
+	}
+	if s.Errors > 0 {
+		lines = append(lines, fmt.Sprintf("Raises %d error message(s)", s.Errors))
+	}
+	return lines
+}
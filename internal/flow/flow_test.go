@@ -0,0 +1,71 @@
+package flow
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	source := `// For each Ignored
+&Total = 0
+If &UserID.IsEmpty()
+	Error("Missing user")
+	Return
+EndIf
+For each Order
+	where CustomerId = &UserID
+	&Total += OrderAmount
+	For each OrderLine
+		Delete
+	EndFor
+EndFor
+For &Item in &Items
+	Do Case
+		Case &Item.Kind = 1
+			LoadUser.Call(&Item.Id)
+	EndCase
+EndFor
+Do While &Retry < 3
+	&Retry += 1
+EndDo
+New
+	LogId = &LogId
+EndNew
+Commit
+Return`
+
+	summary := Analyze(source)
+
+	expected := Summary{
+		Loops:      []string{"For Each Order", "For &Item in &Items", "Do While &Retry < 3"},
+		Calls:      []string{"LoadUser"},
+		Conditions: 2,
+		EarlyExits: 1,
+		Errors:     1,
+		Inserts:    true,
+		Deletes:    true,
+		Commits:    true,
+	}
+	if !reflect.DeepEqual(summary, expected) {
+		t.Errorf("Analyze() = %+v, expected %+v", summary, expected)
+	}
+}
+
+func TestSummaryLines(t *testing.T) {
+	summary := Summary{Loops: []string{"For Each Order"}, Calls: []string{"A", "B"}, Conditions: 1, Commits: true, EarlyExits: 2}
+
+	expected := []string{
+		"Loops: For Each Order",
+		"Calls A, B",
+		"Branches on 1 condition(s)",
+		"Database: commits",
+		"Exits early in 2 place(s) (Return)",
+	}
+	if lines := summary.Lines(); !reflect.DeepEqual(lines, expected) {
+		t.Errorf("Lines() = %q, expected %q", lines, expected)
+	}
+
+	if !(Summary{}).IsEmpty() {
+		t.Error("expected empty summary")
+	}
+}
@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// builtinTags are the annotation tags reported on the usage page, in the
+// order they appear in the annotation standard
+var builtinTags = []string{
+	"@summary", "@description", "@param", "@return", "@package", "@author",
+	"@created", "@since", "@tag", "@see", "@deprecated", "@maps",
+	"@example", "@example-request", "@example-response", "@docgroup",
+}
+
+// AnnotationUsage counts how many procedures use each annotation tag
+type AnnotationUsage struct {
+	Procedures int                      `json:"procedures"`
+	Tags       []TagCount               `json:"tags"`
+	Packages   []PackageAnnotationUsage `json:"packages"`
+}
+
+// PackageAnnotationUsage is the annotation usage of a single package
+type PackageAnnotationUsage struct {
+	Package    string     `json:"package"`
+	Procedures int        `json:"procedures"`
+	Tags       []TagCount `json:"tags"`
+}
+
+// TagCount is the number and percentage of procedures using a tag
+type TagCount struct {
+	Tag     string  `json:"tag"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+// usedTags returns the tags present in a procedure's /** */ block
+func usedTags(proc model.GXObject) map[string]bool {
+	used := make(map[string]bool)
+	doc := proc.Documentation
+	if !proc.IsDocumented() {
+		return used
+	}
+	set := func(tag string, present bool) {
+		if present {
+			used[tag] = true
+		}
+	}
+	set("@summary", doc.Summary != "")
+	set("@description", doc.Description != "")
+	set("@param", len(doc.Parameters) > 0)
+	set("@return", doc.Return != "")
+	set("@package", doc.Package != "")
+	set("@author", doc.Author != "")
+	set("@created", doc.Created != "")
+	set("@since", doc.Since != "")
+	set("@tag", len(doc.Tags) > 0)
+	set("@see", len(doc.See) > 0)
+	set("@deprecated", doc.Deprecated)
+	set("@maps", len(doc.ParamMaps) > 0)
+	set("@example", len(doc.Examples) > 0)
+	set("@example-request", doc.ExampleRequest != "")
+	set("@example-response", doc.ExampleResponse != "")
+	set("@docgroup", doc.DocGroup != "")
+	for _, field := range doc.CustomFields {
+		used[field.Tag] = true
+	}
+	return used
+}
+
+// computeAnnotationUsage counts tag usage across procedures and per package.
+// Custom tags follow the built-in ones.
+func computeAnnotationUsage(procedures []model.GXObject, order *collation) AnnotationUsage {
+	total := make(map[string]int)
+	perPackage := make(map[string]map[string]int)
+	packageSize := make(map[string]int)
+	custom := make(map[string]bool)
+
+	for _, proc := range procedures {
+		pkg := "root"
+		if proc.Documentation != nil && proc.Documentation.Package != "" {
+			pkg = proc.Documentation.Package
+		}
+		if perPackage[pkg] == nil {
+			perPackage[pkg] = make(map[string]int)
+		}
+		packageSize[pkg]++
+		for tag := range usedTags(proc) {
+			total[tag]++
+			perPackage[pkg][tag]++
+			custom[tag] = true
+		}
+	}
+
+	tags := append([]string(nil), builtinTags...)
+	for _, tag := range builtinTags {
+		delete(custom, tag)
+	}
+	tags = append(tags, sortedKeys(custom, order)...)
+
+	counts := func(used map[string]int, procedures int) []TagCount {
+		result := make([]TagCount, 0, len(tags))
+		for _, tag := range tags {
+			result = append(result, TagCount{Tag: tag, Count: used[tag], Percent: percentage(used[tag], procedures)})
+		}
+		return result
+	}
+
+	usage := AnnotationUsage{
+		Procedures: len(procedures),
+		Tags:       counts(total, len(procedures)),
+		Packages:   make([]PackageAnnotationUsage, 0, len(perPackage)),
+	}
+	for _, pkg := range sortedKeys(perPackage, order) {
+		usage.Packages = append(usage.Packages, PackageAnnotationUsage{
+			Package:    pkg,
+			Procedures: packageSize[pkg],
+			Tags:       counts(perPackage[pkg], packageSize[pkg]),
+		})
+	}
+	return usage
+}
+
+// writeAnnotationReports writes annotations.md and annotations.json, which
+// show how often each tag is used to guide documentation standards rollout
+func writeAnnotationReports(usage AnnotationUsage, s *site) error {
+	data, err := json.MarshalIndent(usage, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := s.out.WriteFile("annotations.json", append(data, '\n')); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Annotation Usage") + "\n\n")
+	sb.WriteString(s.format.sprintf(s.text.T("Share of the %d procedure(s) whose `/** */` block uses each tag.")+"\n\n", usage.Procedures))
+
+	sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.text.T("Tag"), s.text.T("Procedures"), s.text.T("Usage")))
+	sb.WriteString("|-----|------------|-------|\n")
+	for _, tc := range usage.Tags {
+		sb.WriteString(s.format.sprintf("| `%s` | %d | %.1f%% |\n", tc.Tag, tc.Count, tc.Percent))
+	}
+	sb.WriteString("\n")
+
+	if len(usage.Packages) > 0 {
+		sb.WriteString("## " + s.text.T("By Package") + "\n\n")
+		header := fmt.Sprintf("| %s | %s |", s.text.T("Package"), s.text.T("Procedures"))
+		separator := "|---------|------------|"
+		for _, tc := range usage.Tags {
+			header += " `" + tc.Tag + "` |"
+			separator += "------|"
+		}
+		sb.WriteString(header + "\n" + separator + "\n")
+		for _, pu := range usage.Packages {
+			sb.WriteString(s.format.sprintf("| %s | %d |", escapeTableCell(pu.Package), pu.Procedures))
+			for _, tc := range pu.Tags {
+				sb.WriteString(s.format.sprintf(" %.0f%% |", tc.Percent))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("annotations.md", []byte(sb.String()))
+}
@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestComputeAnnotationUsage(t *testing.T) {
+	procedures := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", Documentation: &model.DocComment{
+			Package: "users", Summary: "Get", Return: "User",
+			Parameters:   []model.ParameterDoc{{Name: "Id"}},
+			CustomFields: []model.CustomField{{Tag: "@ticket", Value: "GX-1"}},
+		}},
+		{Path: "SaveUser", Type: "Procedure", Documentation: &model.DocComment{Package: "users", Summary: "Save"}},
+		{Path: "CalcTotal", Type: "Procedure", Documentation: &model.DocComment{Summary: "Calc", IsAutoGenerated: true}},
+		{Path: "Legacy", Type: "Procedure"},
+	}
+
+	usage := computeAnnotationUsage(procedures, nil)
+
+	counts := make(map[string]TagCount)
+	for _, tc := range usage.Tags {
+		counts[tc.Tag] = tc
+	}
+	if usage.Procedures != 4 || counts["@summary"].Count != 2 || counts["@summary"].Percent != 50 {
+		t.Errorf("Unexpected @summary usage: %+v", counts["@summary"])
+	}
+	if counts["@return"].Count != 1 || counts["@return"].Percent != 25 {
+		t.Errorf("Unexpected @return usage: %+v", counts["@return"])
+	}
+	if last := usage.Tags[len(usage.Tags)-1]; last.Tag != "@ticket" || last.Count != 1 {
+		t.Errorf("Expected custom tag @ticket last, got %+v", last)
+	}
+
+	if len(usage.Packages) != 2 || usage.Packages[0].Package != "root" || usage.Packages[1].Package != "users" {
+		t.Fatalf("Unexpected packages: %+v", usage.Packages)
+	}
+	if users := usage.Packages[1]; users.Procedures != 2 || users.Tags[0].Percent != 100 {
+		t.Errorf("Unexpected users usage: %+v", users)
+	}
+}
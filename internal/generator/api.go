@@ -0,0 +1,95 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// apiGroup lists the public objects sharing a @tag
+type apiGroup struct {
+	// Tag is the first @tag of the objects; empty for untagged objects
+	Tag     string
+	Objects []model.GXObject
+}
+
+// isPublic reports whether an object is part of the public API: a main
+// program or a service exposed to external consumers
+func isPublic(obj model.GXObject) bool {
+	return obj.IsMain || obj.Exposure != ""
+}
+
+// collectPublicAPI groups the main programs and exposed services among
+// procedures by their first @tag, untagged objects last
+func collectPublicAPI(procedures []model.GXObject, order *collation) []apiGroup {
+	groups := make(map[string]*apiGroup)
+	for _, proc := range procedures {
+		if !isPublic(proc) {
+			continue
+		}
+		tag := ""
+		if doc := proc.Documentation; doc != nil && len(doc.Tags) > 0 {
+			tag = doc.Tags[0]
+		}
+		if groups[tag] == nil {
+			groups[tag] = &apiGroup{Tag: tag}
+		}
+		groups[tag].Objects = append(groups[tag].Objects, proc)
+	}
+
+	var result []apiGroup
+	for _, tag := range sortedKeys(groups, order) {
+		if tag != "" {
+			result = append(result, *groups[tag])
+		}
+	}
+	if untagged := groups[""]; untagged != nil {
+		result = append(result, *untagged)
+	}
+	return result
+}
+
+// apiKind describes how a public object is reached, e.g. "Main program, HTTP"
+func apiKind(obj model.GXObject, s *site) string {
+	var kinds []string
+	if obj.IsMain {
+		kinds = append(kinds, s.text.T("Main program"))
+	}
+	if obj.Exposure != "" {
+		kinds = append(kinds, obj.Exposure)
+	}
+	return strings.Join(kinds, ", ")
+}
+
+// generateAPIPage writes api.md, the entry point for external consumers
+// listing only main programs and exposed services
+func generateAPIPage(groups []apiGroup, s *site) error {
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Public API") + "\n\n")
+	sb.WriteString(fmt.Sprintf(s.text.T("Main programs and services exposed to external consumers. Internal objects are listed in the [full index](%s)."), "./"+s.readme) + "\n\n")
+
+	for _, group := range groups {
+		tag := group.Tag
+		if tag == "" {
+			tag = s.text.T("Untagged")
+		}
+		sb.WriteString("## " + tag + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.text.T("Name"), s.text.T("Exposed as"), s.text.T("Summary")))
+		sb.WriteString("|------|------------|---------|\n")
+		for _, obj := range group.Objects {
+			summary := "-"
+			if obj.Documentation != nil && obj.Documentation.Summary != "" {
+				summary = escapeTableCell(obj.Documentation.Summary)
+			}
+			sb.WriteString(fmt.Sprintf("| [%s](./%s) | %s | %s |\n", obj.Path, s.procedureFile(obj), apiKind(obj, s), summary))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("api.md", []byte(sb.String()))
+}
@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestPublicAPIPage(t *testing.T) {
+	procedures := []model.GXObject{
+		{Path: "Internal", Type: "Procedure"},
+		{Path: "Batch", Type: "Procedure", IsMain: true, Exposure: "Command Line"},
+		{Path: "GetOrder", Type: "Procedure", Exposure: "REST", Documentation: &model.DocComment{Summary: "Gets an order", Tags: []string{"Orders"}}},
+	}
+	out := storage.NewMemory()
+	s := newSite(procedures, procedures, "KB", out, Options{}, nil)
+	if len(s.api) != 2 || s.api[0].Tag != "Orders" || s.api[1].Tag != "" {
+		t.Fatalf("Expected tagged groups first and untagged last, got %+v", s.api)
+	}
+	if !strings.HasPrefix(strings.SplitN(s.tableOfContents(), "\n\n", 2)[1], "- [Public API](./api.md)") {
+		t.Error("Expected the public API first in the table of contents")
+	}
+
+	if err := generateAPIPage(s.api, s); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := fs.ReadFile(out, "api.md")
+	page := string(data)
+	for _, want := range []string{
+		"## Orders\n",
+		"| [GetOrder](./GetOrder.md) | REST | Gets an order |",
+		"## Untagged\n",
+		"| [Batch](./Batch.md) | Main program, Command Line | - |",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected %q in api.md:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "[Internal]") {
+		t.Errorf("Expected internal objects to be left out:\n%s", page)
+	}
+}
@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Default badge thresholds, in percent/score points
+const (
+	DefaultBadgeGreen  = 80
+	DefaultBadgeYellow = 60
+)
+
+// Badge colors
+const (
+	badgeGreen  = "#4c1"
+	badgeYellow = "#dfb317"
+	badgeRed    = "#e05d44"
+	badgeLabel  = "#555"
+)
+
+// BadgeThresholds sets the minimum values for green and yellow badges;
+// anything below yellow is red
+type BadgeThresholds struct {
+	Green  float64
+	Yellow float64
+}
+
+// withDefaults fills unset thresholds with the defaults
+func (t BadgeThresholds) withDefaults() BadgeThresholds {
+	if t.Green == 0 {
+		t.Green = DefaultBadgeGreen
+	}
+	if t.Yellow == 0 {
+		t.Yellow = DefaultBadgeYellow
+	}
+	return t
+}
+
+// color returns the badge color of a value
+func (t BadgeThresholds) color(value float64) string {
+	switch {
+	case value >= t.Green:
+		return badgeGreen
+	case value >= t.Yellow:
+		return badgeYellow
+	default:
+		return badgeRed
+	}
+}
+
+// badgeSVG renders a flat two-part badge ("label | value")
+func badgeSVG(label, value, color string) string {
+	// Approximate Verdana 11px glyph width plus padding
+	textWidth := func(text string) int {
+		return len([]rune(text))*7 + 10
+	}
+	lw, vw := textWidth(label), textWidth(value)
+	total := lw + vw
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`, total, xmlEscape(label), xmlEscape(value)))
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="20" rx="3" fill="%s"/>`, total, badgeLabel))
+	sb.WriteString(fmt.Sprintf(`<rect x="%d" width="%d" height="20" rx="3" fill="%s"/>`, lw, vw, color))
+	sb.WriteString(fmt.Sprintf(`<rect x="%d" width="4" height="20" fill="%s"/>`, lw, color))
+	sb.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,sans-serif" font-size="11">`)
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="14">%s</text>`, lw/2, xmlEscape(label)))
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="14">%s</text>`, lw+vw/2, xmlEscape(value)))
+	sb.WriteString("</g></svg>\n")
+	return sb.String()
+}
+
+// xmlEscape escapes text for SVG content and attributes
+func xmlEscape(text string) string {
+	replacer := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	return replacer.Replace(text)
+}
+
+// writeBadges writes health and coverage badges for the KB and every module
+// to badges/, plus badges.md with Markdown snippets to embed them
+func writeBadges(health Health, coverage Coverage, s *site) error {
+	thresholds := s.opts.Badges.withDefaults()
+
+	type badgeRow struct {
+		module, slug      string
+		score, percentage float64
+	}
+	rows := []badgeRow{{"KB", "kb", health.Score, coverage.Percent}}
+
+	coverageByModule := make(map[string]float64)
+	for _, pc := range coverage.Packages {
+		coverageByModule[pc.Package] = pc.Percent
+	}
+	for _, m := range health.Modules {
+		rows = append(rows, badgeRow{m.Module, "module-" + sanitizePackageName(m.Module), m.Score, coverageByModule[m.Module]})
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Badges") + "\n\n")
+	sb.WriteString(s.format.sprintf("Green from %.0f, yellow from %.0f, red below. Copy a snippet into a module README to show its documentation quality.\n\n", thresholds.Green, thresholds.Yellow))
+	sb.WriteString("| Module | Health | Coverage | Markdown |\n")
+	sb.WriteString("|--------|--------|----------|----------|\n")
+
+	for _, row := range rows {
+		healthFile := row.slug + "-health.svg"
+		coverageFile := row.slug + "-coverage.svg"
+
+		badges := map[string]string{
+			healthFile:   badgeSVG("doc health", fmt.Sprintf("%.0f", row.score), thresholds.color(row.score)),
+			coverageFile: badgeSVG("doc coverage", fmt.Sprintf("%.0f%%", row.percentage), thresholds.color(row.percentage)),
+		}
+		for _, name := range sortedKeys(badges, nil) {
+			if err := s.out.WriteFile("badges/"+name, []byte(badges[name])); err != nil {
+				return err
+			}
+		}
+
+		snippet := fmt.Sprintf("`![doc health](badges/%s) ![doc coverage](badges/%s)`", healthFile, coverageFile)
+		sb.WriteString(fmt.Sprintf("| %s | ![health](./badges/%s) | ![coverage](./badges/%s) | %s |\n",
+			escapeTableCell(row.module), healthFile, coverageFile, snippet))
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("badges.md", []byte(sb.String()))
+}
@@ -0,0 +1,47 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBadgeThresholds(t *testing.T) {
+	thresholds := BadgeThresholds{}.withDefaults()
+	if thresholds.Green != DefaultBadgeGreen || thresholds.Yellow != DefaultBadgeYellow {
+		t.Fatalf("Unexpected defaults: %+v", thresholds)
+	}
+
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{100, badgeGreen},
+		{80, badgeGreen},
+		{79.9, badgeYellow},
+		{60, badgeYellow},
+		{59.9, badgeRed},
+	}
+	for _, tt := range tests {
+		if color := thresholds.color(tt.value); color != tt.expected {
+			t.Errorf("color(%.1f) = %s, expected %s", tt.value, color, tt.expected)
+		}
+	}
+
+	custom := BadgeThresholds{Green: 95}.withDefaults()
+	if custom.color(90) != badgeYellow {
+		t.Errorf("Expected custom green threshold to apply")
+	}
+}
+
+func TestBadgeSVG(t *testing.T) {
+	svg := badgeSVG("doc coverage", "75%", badgeYellow)
+
+	for _, want := range []string{`aria-label="doc coverage: 75%"`, `fill="` + badgeYellow + `"`, ">75%</text>"} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Badge SVG missing %q: %s", want, svg)
+		}
+	}
+	if !strings.Contains(badgeSVG("a<b", "1", badgeRed), "a&lt;b") {
+		t.Error("Expected badge text to be escaped")
+	}
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+// stateFile keeps object hashes and the changelog between runs. Unlike the
+// manifest it survives generator upgrades, so the history is never lost.
+const stateFile = ".gxdocgen-state.json"
+
+// maxChangelogEntries caps the runs kept in the changelog
+const maxChangelogEntries = 50
+
+// runState is the content of the state file
+type runState struct {
+	// Since is the time of the first recorded run
+	Since time.Time `json:"since"`
+	// Objects maps "Type|Name" to the object hash of the last run
+	Objects   map[string]string `json:"objects"`
+	Changelog []changelogEntry  `json:"changelog"`
+}
+
+// changelogEntry lists the objects changed since the previous run
+type changelogEntry struct {
+	Date     time.Time         `json:"date"`
+	Added    []changelogObject `json:"added"`
+	Modified []changelogObject `json:"modified"`
+	Deleted  []changelogObject `json:"deleted"`
+}
+
+// changelogObject identifies an object in the changelog
+type changelogObject struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// loadState reads the state of a previous run. A missing or unreadable file
+// yields nil, which starts a new history.
+func loadState(out storage.Storage) *runState {
+	data, err := fs.ReadFile(out, stateFile)
+	if err != nil {
+		return nil
+	}
+	var state runState
+	if err := json.Unmarshal(data, &state); err != nil || state.Objects == nil {
+		return nil
+	}
+	return &state
+}
+
+// save writes the state to the output
+func (st *runState) save(out storage.Storage) error {
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	return out.WriteFile(stateFile, append(data, '\n'))
+}
+
+// updateState compares objects against the previous state and returns the
+// new state, with a changelog entry when anything changed. The first run
+// only records a baseline.
+func updateState(previous *runState, objects []model.GXObject, now time.Time, order *collation) *runState {
+	current := &runState{Since: now, Objects: make(map[string]string, len(objects))}
+	byKey := make(map[string]model.GXObject, len(objects))
+	for _, obj := range objects {
+		key := obj.Type + "|" + obj.Path
+		current.Objects[key] = objectHash(obj)
+		byKey[key] = obj
+	}
+	if previous == nil {
+		return current
+	}
+	current.Since = previous.Since
+	current.Changelog = previous.Changelog
+
+	var entry changelogEntry
+	for _, key := range sortedKeys(current.Objects, order) {
+		obj := byKey[key]
+		oldHash, existed := previous.Objects[key]
+		switch {
+		case !existed:
+			entry.Added = append(entry.Added, changelogObject{Name: obj.Path, Type: obj.Type})
+		case oldHash != current.Objects[key]:
+			entry.Modified = append(entry.Modified, changelogObject{Name: obj.Path, Type: obj.Type})
+		}
+	}
+	for _, key := range sortedKeys(previous.Objects, order) {
+		if _, exists := current.Objects[key]; !exists {
+			parts := strings.SplitN(key, "|", 2)
+			entry.Deleted = append(entry.Deleted, changelogObject{Name: parts[1], Type: parts[0]})
+		}
+	}
+
+	if len(entry.Added)+len(entry.Modified)+len(entry.Deleted) > 0 {
+		entry.Date = now
+		current.Changelog = append([]changelogEntry{entry}, current.Changelog...)
+		if len(current.Changelog) > maxChangelogEntries {
+			current.Changelog = current.Changelog[:maxChangelogEntries]
+		}
+	}
+	return current
+}
+
+// writeChangelog updates the state file and writes CHANGELOG.md, the
+// "What's New" page listing new, modified and deleted objects per run
+func writeChangelog(objects []model.GXObject, s *site) error {
+	state := updateState(loadState(s.out), objects, s.opts.generatedAt(), s.order)
+	if err := state.save(s.out); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("What's New") + "\n\n")
+	sb.WriteString(fmt.Sprintf("Changes between documentation runs, tracked since %s.\n\n", s.format.Date(state.Since)))
+	if len(state.Changelog) == 0 {
+		sb.WriteString("*No changes recorded yet. Changes show up here after the next run.*\n")
+	}
+	for _, entry := range state.Changelog {
+		sb.WriteString("## " + s.format.DateTime(entry.Date) + "\n\n")
+		writeChangelogSection(&sb, "New", entry.Added, s)
+		writeChangelogSection(&sb, "Modified", entry.Modified, s)
+		writeChangelogSection(&sb, "Deleted", entry.Deleted, nil)
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("CHANGELOG.md", []byte(sb.String()))
+}
+
+// writeChangelogSection lists objects under a heading. Procedures still in
+// the KB link to their page when s is not nil.
+func writeChangelogSection(sb *strings.Builder, title string, objects []changelogObject, s *site) {
+	if len(objects) == 0 {
+		return
+	}
+	sb.WriteString("### " + title + "\n\n")
+	for _, obj := range objects {
+		name, objType := obj.Name, obj.Type
+		if s != nil {
+			if proc, ok := s.kb.ByName(obj.Name); ok && proc.Type == "Procedure" {
+				name = fmt.Sprintf("[%s](./%s)", obj.Name, s.procedureFile(proc))
+			}
+			objType = s.label(objType)
+		}
+		sb.WriteString(fmt.Sprintf("- %s (%s)\n", name, objType))
+	}
+	sb.WriteString("\n")
+}
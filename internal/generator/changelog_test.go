@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestUpdateState(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 7)
+
+	v1 := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", Hash: "a"},
+		{Path: "DeleteUser", Type: "Procedure", Hash: "b"},
+		{Path: "User", Type: "Transaction", Hash: "c"},
+	}
+	state := updateState(nil, v1, first, nil)
+	if len(state.Changelog) != 0 || len(state.Objects) != 3 {
+		t.Fatalf("First run should only record a baseline, got %+v", state)
+	}
+
+	v2 := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", Hash: "a2"},
+		{Path: "User", Type: "Transaction", Hash: "c"},
+		{Path: "RemoveUser", Type: "Procedure", Hash: "d"},
+	}
+	state = updateState(state, v2, second, nil)
+	if len(state.Changelog) != 1 || !state.Since.Equal(first) {
+		t.Fatalf("Expected one changelog entry since the first run, got %+v", state)
+	}
+	entry := state.Changelog[0]
+	if !entry.Date.Equal(second) {
+		t.Errorf("Expected entry dated %v, got %v", second, entry.Date)
+	}
+	if len(entry.Added) != 1 || entry.Added[0].Name != "RemoveUser" {
+		t.Errorf("Unexpected added objects: %+v", entry.Added)
+	}
+	if len(entry.Modified) != 1 || entry.Modified[0].Name != "GetUser" {
+		t.Errorf("Unexpected modified objects: %+v", entry.Modified)
+	}
+	if len(entry.Deleted) != 1 || entry.Deleted[0] != (changelogObject{Name: "DeleteUser", Type: "Procedure"}) {
+		t.Errorf("Unexpected deleted objects: %+v", entry.Deleted)
+	}
+
+	// An unchanged export adds no entry
+	if state = updateState(state, v2, second.AddDate(0, 0, 1), nil); len(state.Changelog) != 1 {
+		t.Errorf("Expected no new entry for an unchanged export, got %d entries", len(state.Changelog))
+	}
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// Cheat sheet grouping modes
+const (
+	CheatsheetByPackage = "package"
+	CheatsheetByTag     = "tag"
+)
+
+// cheatsheetGroup is a titled set of procedures on the cheat sheet
+type cheatsheetGroup struct {
+	Title      string
+	Procedures []model.GXObject
+}
+
+// groupForCheatsheet groups procedures by package or by @tag. Procedures
+// with several tags appear in each of them.
+func groupForCheatsheet(procedures []model.GXObject, groupBy string, order *collation) []cheatsheetGroup {
+	groups := make(map[string][]model.GXObject)
+	for _, proc := range procedures {
+		var keys []string
+		if groupBy == CheatsheetByTag {
+			if proc.Documentation != nil {
+				keys = proc.Documentation.Tags
+			}
+			if len(keys) == 0 {
+				keys = []string{"untagged"}
+			}
+		} else {
+			pkg := "root"
+			if proc.Documentation != nil && proc.Documentation.Package != "" {
+				pkg = proc.Documentation.Package
+			}
+			keys = []string{pkg}
+		}
+		for _, key := range keys {
+			groups[key] = append(groups[key], proc)
+		}
+	}
+
+	var result []cheatsheetGroup
+	for title, procs := range groups {
+		sort.SliceStable(procs, func(i, j int) bool {
+			return order.less(procs[i].Path, procs[j].Path)
+		})
+		result = append(result, cheatsheetGroup{Title: title, Procedures: procs})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return order.less(result[i].Title, result[j].Title)
+	})
+	return result
+}
+
+// cheatsheetSummary returns the one-line summary shown for a procedure
+func cheatsheetSummary(proc model.GXObject) string {
+	summary := proc.Name
+	if proc.Documentation != nil && proc.Documentation.Summary != "" {
+		summary = proc.Documentation.Summary
+	}
+	if idx := strings.Index(summary, "\n"); idx != -1 {
+		summary = summary[:idx]
+	}
+	return strings.TrimSpace(summary)
+}
+
+// oneLine collapses all whitespace, including line breaks, into single spaces
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// generateCheatsheet writes cheatsheet.md and cheatsheet.pdf, a compact
+// quick reference of procedure names, summaries and signatures
+func generateCheatsheet(procedures []model.GXObject, kbName, groupBy string, s *site) error {
+	groups := groupForCheatsheet(procedures, groupBy, s.order)
+
+	title := "Cheat Sheet"
+	if kbName != "" {
+		title = kbName + " Cheat Sheet"
+	}
+
+	// Markdown version
+	var sb strings.Builder
+	sb.WriteString("# " + title + "\n\n")
+	for _, group := range groups {
+		sb.WriteString("## " + group.Title + "\n\n")
+		sb.WriteString("| Procedure | Summary | Signature |\n")
+		sb.WriteString("|-----------|---------|-----------|\n")
+		for _, proc := range group.Procedures {
+			sb.WriteString(fmt.Sprintf("| %s | %s | `%s` |\n",
+				escapeTableCell(proc.Path), escapeTableCell(cheatsheetSummary(proc)), escapeTableCell(oneLine(proc.ParmSignature))))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	if err := s.out.WriteFile("cheatsheet.md", []byte(sb.String())); err != nil {
+		return err
+	}
+
+	// PDF version
+	pdf := newPDFDocument()
+	pdf.addLine(pdfFontBold, 16, title)
+	pdf.addSpace(6)
+	for _, group := range groups {
+		pdf.addSpace(4)
+		pdf.addLine(pdfFontBold, 11, group.Title)
+		for _, proc := range group.Procedures {
+			pdf.addLine(pdfFontBold, 8, proc.Path+"  -  "+cheatsheetSummary(proc))
+			if proc.ParmSignature != "" {
+				pdf.addLine(pdfFontMono, 7, "    "+oneLine(proc.ParmSignature))
+			}
+		}
+	}
+	pdf.addSpace(8)
+	pdf.addLine(pdfFontRegular, 7, fmt.Sprintf("Generated by GXDocGen v%s", version))
+
+	return s.out.WriteFile("cheatsheet.pdf", pdf.bytes())
+}
@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// Coverage summarizes how many procedures carry /** */ documentation
+type Coverage struct {
+	Total      int               `json:"total"`
+	Documented int               `json:"documented"`
+	Percent    float64           `json:"percent"`
+	Packages   []PackageCoverage `json:"packages"`
+}
+
+// PackageCoverage is the documentation coverage of a single package
+type PackageCoverage struct {
+	Package      string   `json:"package"`
+	Total        int      `json:"total"`
+	Documented   int      `json:"documented"`
+	Percent      float64  `json:"percent"`
+	Undocumented []string `json:"undocumented"`
+}
+
+// ComputeCoverage calculates documented vs undocumented procedures per
+// package. Names are listed in byte order and @nodoc objects are left out,
+// as in the generated pages.
+func ComputeCoverage(objects []model.GXObject) Coverage {
+	return computeCoverage(applyDocAnnotations(objects), nil)
+}
+
+// computeCoverage is ComputeCoverage listing names in the given order
+func computeCoverage(objects []model.GXObject, order *collation) Coverage {
+	packages := make(map[string]*PackageCoverage)
+	var cov Coverage
+
+	for _, obj := range objects {
+		if obj.Type != "Procedure" {
+			continue
+		}
+
+		pkg := "root"
+		if obj.Documentation != nil && obj.Documentation.Package != "" {
+			pkg = obj.Documentation.Package
+		}
+		pc, exists := packages[pkg]
+		if !exists {
+			pc = &PackageCoverage{Package: pkg, Undocumented: make([]string, 0)}
+			packages[pkg] = pc
+		}
+
+		cov.Total++
+		pc.Total++
+		if obj.IsDocumented() {
+			cov.Documented++
+			pc.Documented++
+		} else {
+			pc.Undocumented = append(pc.Undocumented, obj.Path)
+		}
+	}
+
+	cov.Percent = percentage(cov.Documented, cov.Total)
+	cov.Packages = make([]PackageCoverage, 0, len(packages))
+	for _, pc := range packages {
+		pc.Percent = percentage(pc.Documented, pc.Total)
+		order.sortStrings(pc.Undocumented)
+		cov.Packages = append(cov.Packages, *pc)
+	}
+	sort.Slice(cov.Packages, func(i, j int) bool {
+		return order.less(cov.Packages[i].Package, cov.Packages[j].Package)
+	})
+
+	return cov
+}
+
+// percentage returns part/total as a percentage rounded to one decimal place.
+// An empty set counts as fully covered.
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 100
+	}
+	return float64(int(float64(part)*1000/float64(total)+0.5)) / 10
+}
+
+// logCoverage prints the coverage summary to the console
+func logCoverage(cov Coverage) {
+	utils.Info("Documentation coverage: %.1f%% (%d/%d procedures)", cov.Percent, cov.Documented, cov.Total)
+	for _, pc := range cov.Packages {
+		utils.Info("  %-30s %5.1f%% (%d/%d)", pc.Package, pc.Percent, pc.Documented, pc.Total)
+	}
+}
+
+// writeCoverageReports writes coverage.md and coverage.json to the output directory
+func writeCoverageReports(cov Coverage, s *site) error {
+	data, err := json.MarshalIndent(cov, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := s.out.WriteFile("coverage.json", append(data, '\n')); err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Documentation Coverage") + "\n\n")
+	sb.WriteString(s.format.sprintf(s.text.T("**%.1f%%** of procedures are documented (%d/%d).")+"\n\n", cov.Percent, cov.Documented, cov.Total))
+
+	if len(cov.Packages) > 0 {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", s.text.T("Package"), s.text.T("Documented"), s.text.T("Total"), s.text.T("Coverage"), s.text.T("Undocumented")))
+		sb.WriteString("|---------|------------|-------|----------|--------------|\n")
+		for _, pc := range cov.Packages {
+			undocumented := "-"
+			if len(pc.Undocumented) > 0 {
+				undocumented = strings.Join(pc.Undocumented, ", ")
+			}
+			sb.WriteString(s.format.sprintf("| %s | %d | %d | %.1f%% | %s |\n",
+				escapeTableCell(pc.Package), pc.Documented, pc.Total, pc.Percent, escapeTableCell(undocumented)))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("coverage.md", []byte(sb.String()))
+}
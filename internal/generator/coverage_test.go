@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestComputeCoverage(t *testing.T) {
+	objects := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", Documentation: &model.DocComment{Package: "users"}},
+		{Path: "DeleteUser", Type: "Procedure", Documentation: &model.DocComment{Package: "users", IsAutoGenerated: true}},
+		{Path: "CalcTotal", Type: "Procedure", Documentation: &model.DocComment{Package: "billing"}},
+		{Path: "Customer", Type: "Transaction"},
+	}
+
+	cov := ComputeCoverage(objects)
+
+	if cov.Total != 3 || cov.Documented != 2 {
+		t.Fatalf("Expected 2/3 documented, got %d/%d", cov.Documented, cov.Total)
+	}
+	if cov.Percent != 66.7 {
+		t.Errorf("Expected 66.7%%, got %.1f%%", cov.Percent)
+	}
+	if len(cov.Packages) != 2 {
+		t.Fatalf("Expected 2 packages, got %d", len(cov.Packages))
+	}
+
+	users := cov.Packages[1]
+	if users.Package != "users" || users.Percent != 50 {
+		t.Errorf("Unexpected users coverage: %+v", users)
+	}
+	if len(users.Undocumented) != 1 || users.Undocumented[0] != "DeleteUser" {
+		t.Errorf("Expected DeleteUser to be undocumented, got %v", users.Undocumented)
+	}
+}
+
+func TestComputeCoverage_Empty(t *testing.T) {
+	if cov := ComputeCoverage(nil); cov.Percent != 100 {
+		t.Errorf("Expected empty KB to be fully covered, got %.1f%%", cov.Percent)
+	}
+}
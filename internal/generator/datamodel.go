@@ -0,0 +1,182 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// attributeUsage is a Transaction attribute and the Transactions using it
+type attributeUsage struct {
+	model.Attribute
+	Transactions []string
+	// KeyOf lists the Transactions whose primary key includes the attribute
+	KeyOf []string
+}
+
+// collectTransactions returns the Transactions with a structure, sorted by name
+func collectTransactions(objects []model.GXObject, order *collation) []model.GXObject {
+	byName := make(map[string]model.GXObject)
+	for _, obj := range objects {
+		if obj.Type == "Transaction" && len(obj.Levels) > 0 {
+			byName[obj.Path] = obj
+		}
+	}
+	var transactions []model.GXObject
+	for _, name := range sortedKeys(byName, order) {
+		transactions = append(transactions, byName[name])
+	}
+	return transactions
+}
+
+// collectAttributes merges the attributes of all transactions by name. The
+// first type and description found are kept.
+func collectAttributes(transactions []model.GXObject, order *collation) []attributeUsage {
+	usages := make(map[string]*attributeUsage)
+	var visit func(trn string, levels []model.Level, first bool)
+	visit = func(trn string, levels []model.Level, first bool) {
+		for _, level := range levels {
+			for _, attr := range level.Attributes {
+				usage, exists := usages[attr.Name]
+				if !exists {
+					usage = &attributeUsage{Attribute: model.Attribute{Name: attr.Name}}
+					usages[attr.Name] = usage
+				}
+				if usage.Type == "" {
+					usage.Type = attr.Type
+				}
+				if usage.Description == "" {
+					usage.Description = attr.Description
+				}
+				if n := len(usage.Transactions); n == 0 || usage.Transactions[n-1] != trn {
+					usage.Transactions = append(usage.Transactions, trn)
+				}
+				if first && attr.Key {
+					usage.KeyOf = append(usage.KeyOf, trn)
+				}
+			}
+			visit(trn, level.Levels, false)
+		}
+	}
+	for _, trn := range transactions {
+		visit(trn.Path, trn.Levels, true)
+	}
+
+	var result []attributeUsage
+	for _, name := range sortedKeys(usages, order) {
+		result = append(result, *usages[name])
+	}
+	return result
+}
+
+// transactionLink links to the data model section of a transaction
+func transactionLink(name string) string {
+	return fmt.Sprintf("[%s](./datamodel.md#%s)", name, headingAnchor(name))
+}
+
+// generateDataModelPage writes datamodel.md, with the levels, keys and
+// foreign keys of every Transaction
+func generateDataModelPage(transactions []model.GXObject, s *site) error {
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Data Model") + "\n\n")
+	sb.WriteString("→ [" + s.text.T("Attributes") + "](./attributes.md)\n\n")
+
+	for _, trn := range transactions {
+		sb.WriteString("## " + trn.Path + "\n\n")
+		if trn.XMLDescription != "" && trn.XMLDescription != trn.Path {
+			sb.WriteString(trn.XMLDescription + "\n\n")
+		}
+		sb.WriteString(s.screenshotsSection(trn, "datamodel.md", "###"))
+		for _, level := range trn.Levels {
+			// The first level is usually named after the transaction
+			heading := "###"
+			if level.Name == trn.Path && level.Description == "" {
+				heading = ""
+			}
+			writeLevelTable(&sb, level, heading, s)
+		}
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("datamodel.md", []byte(sb.String()))
+}
+
+// writeLevelTable renders the attribute table of a level under heading
+// (none when empty), then its nested levels one heading level deeper
+func writeLevelTable(sb *strings.Builder, level model.Level, heading string, s *site) {
+	if heading != "" {
+		title := level.Name
+		if level.Description != "" && level.Description != level.Name {
+			title += " - " + level.Description
+		}
+		sb.WriteString(heading + " " + title + "\n\n")
+	}
+	sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", s.text.T("Attribute"), s.text.T("Type"), s.text.T("Key"), s.text.T("References"), s.text.T("Description")))
+	sb.WriteString("|-----------|------|-----|------------|-------------|\n")
+	for _, attr := range level.Attributes {
+		var keys []string
+		if attr.Key {
+			keys = append(keys, "PK")
+		}
+		references := "-"
+		if attr.References != "" {
+			keys = append(keys, "FK")
+			references = transactionLink(attr.References)
+		}
+		key := strings.Join(keys, ", ")
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s | %s |\n",
+			attr.Name, orDash(s.dataType(attr.Type)), orDash(key), references, orDash(escapeTableCell(attr.Description))))
+	}
+	sb.WriteString("\n")
+
+	switch {
+	case heading == "":
+		heading = "###"
+	case len(heading) < 6:
+		heading += "#"
+	}
+	for _, nested := range level.Levels {
+		writeLevelTable(sb, nested, heading, s)
+	}
+}
+
+// generateAttributesPage writes attributes.md, a reference of every
+// Transaction attribute and the Transactions using it
+func generateAttributesPage(attributes []attributeUsage, s *site) error {
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Attributes") + "\n\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", s.text.T("Attribute"), s.text.T("Type"), s.text.T("Description"), s.text.T("Transactions")))
+	sb.WriteString("|-----------|------|-------------|--------------|\n")
+	for _, attr := range attributes {
+		var links []string
+		for _, trn := range attr.Transactions {
+			link := transactionLink(trn)
+			for _, keyOf := range attr.KeyOf {
+				if keyOf == trn {
+					link += " (PK)"
+				}
+			}
+			links = append(links, link)
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n",
+			attr.Name, orDash(s.dataType(attr.Type)), orDash(escapeTableCell(attr.Description)), strings.Join(links, ", ")))
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("attributes.md", []byte(sb.String()))
+}
+
+// orDash returns text, or "-" for an empty table cell
+func orDash(text string) string {
+	if text == "" {
+		return "-"
+	}
+	return text
+}
@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestDataModelPages(t *testing.T) {
+	objects := []model.GXObject{
+		{Path: "Invoice", Type: "Transaction", Levels: []model.Level{{
+			Name: "Invoice",
+			Attributes: []model.Attribute{
+				{Name: "InvoiceId", Type: "Numeric", Key: true},
+				{Name: "CustomerId", Type: "Numeric", References: "Customer"},
+			},
+			Levels: []model.Level{{Name: "Line", Description: "Invoice lines", Attributes: []model.Attribute{
+				{Name: "LineId", Key: true},
+				{Name: "Amount", Description: "Line | amount"},
+			}}},
+		}}},
+		{Path: "Customer", Type: "Transaction", Levels: []model.Level{{
+			Name:       "Customer",
+			Attributes: []model.Attribute{{Name: "CustomerId", Type: "Numeric", Description: "Customer id", Key: true}},
+		}}},
+		{Path: "Empty", Type: "Transaction"},
+	}
+	out := storage.NewMemory()
+	s := newSite(objects, nil, "KB", out, Options{}, nil)
+	if len(s.transactions) != 2 || s.transactions[0].Path != "Customer" {
+		t.Fatalf("Expected the transactions with a structure, sorted, got %+v", s.transactions)
+	}
+
+	if err := generateDataModelPage(s.transactions, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := generateAttributesPage(collectAttributes(s.transactions, s.order), s); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ := fs.ReadFile(out, "datamodel.md")
+	page := string(data)
+	for _, want := range []string{
+		"| `CustomerId` | Numeric | FK | [Customer](./datamodel.md#customer) | - |",
+		"### Line - Invoice lines\n",
+		"| `Amount` | - | - | - | Line \\| amount |",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected %q in datamodel.md:\n%s", want, page)
+		}
+	}
+	if strings.Contains(page, "### Invoice\n") {
+		t.Error("Expected no heading for a first level named after its transaction")
+	}
+
+	data, _ = fs.ReadFile(out, "attributes.md")
+	attributes := string(data)
+	want := "| `CustomerId` | Numeric | Customer id | [Customer](./datamodel.md#customer) (PK), [Invoice](./datamodel.md#invoice) |"
+	if !strings.Contains(attributes, want) {
+		t.Errorf("Expected %q in attributes.md:\n%s", want, attributes)
+	}
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// maxHotSpots limits the rows shown in the coupling hot spot tables
+const maxHotSpots = 10
+
+// dependencyEdge is a reference from a procedure to another KB object
+type dependencyEdge struct {
+	From   string
+	To     string
+	ToType string
+}
+
+// collectDependencyEdges merges call graph edges and variable type references
+func collectDependencyEdges(procedures []model.GXObject, calls *graph.Graph) []dependencyEdge {
+	var edges []dependencyEdge
+	for _, proc := range procedures {
+		for _, callee := range calls.Callees(proc.Path) {
+			edges = append(edges, dependencyEdge{From: proc.Path, To: callee, ToType: "Procedure"})
+		}
+		for _, dep := range proc.Dependencies {
+			edges = append(edges, dependencyEdge{From: proc.Path, To: dep.Name, ToType: dep.Type})
+		}
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool {
+		if edges[i].From != edges[j].From {
+			return edges[i].From < edges[j].From
+		}
+		return edges[i].To < edges[j].To
+	})
+	return edges
+}
+
+// generateDependencyPage writes dependencies.md with a KB-wide Mermaid
+// dependency diagram and fan-in/fan-out tables to spot coupling hot spots
+func generateDependencyPage(procedures []model.GXObject, s *site) error {
+	edges := collectDependencyEdges(procedures, s.calls)
+
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Object Dependencies") + "\n\n")
+
+	if len(edges) == 0 {
+		sb.WriteString("*" + s.text.T("No dependencies between objects were found.") + "*\n")
+	} else {
+		sb.WriteString("## " + s.text.T("Dependency Graph") + "\n\n")
+		sb.WriteString(s.diagram(mermaidDependencyGraph(edges), s.text.T("Dependency Graph"), "dependencies.md"))
+		sb.WriteString("\n")
+
+		// Fan-in: how many procedures depend on each object
+		type usage struct {
+			Name  string
+			Type  string
+			Count int
+		}
+		fanIn := make(map[string]*usage)
+		fanOut := make(map[string]int)
+		for _, e := range edges {
+			key := e.ToType + "|" + e.To
+			if fanIn[key] == nil {
+				fanIn[key] = &usage{Name: e.To, Type: e.ToType}
+			}
+			fanIn[key].Count++
+			fanOut[e.From]++
+		}
+
+		var mostUsed []*usage
+		for _, u := range fanIn {
+			mostUsed = append(mostUsed, u)
+		}
+		sort.Slice(mostUsed, func(i, j int) bool {
+			if mostUsed[i].Count != mostUsed[j].Count {
+				return mostUsed[i].Count > mostUsed[j].Count
+			}
+			return mostUsed[i].Name < mostUsed[j].Name
+		})
+
+		sb.WriteString("## " + s.text.T("Most Referenced Objects") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.text.T("Object"), s.text.T("Type"), s.text.T("Referenced By")))
+		sb.WriteString("|--------|------|---------------|\n")
+		for i, u := range mostUsed {
+			if i == maxHotSpots {
+				break
+			}
+			sb.WriteString(s.format.sprintf("| %s | %s | %d |\n", escapeTableCell(u.Name), u.Type, u.Count))
+		}
+		sb.WriteString("\n")
+
+		var heaviest []string
+		for name := range fanOut {
+			heaviest = append(heaviest, name)
+		}
+		sort.Slice(heaviest, func(i, j int) bool {
+			if fanOut[heaviest[i]] != fanOut[heaviest[j]] {
+				return fanOut[heaviest[i]] > fanOut[heaviest[j]]
+			}
+			return heaviest[i] < heaviest[j]
+		})
+
+		sb.WriteString("## " + s.text.T("Procedures With Most Dependencies") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", s.text.T("Procedure"), s.text.T("Dependencies")))
+		sb.WriteString("|-----------|--------------|\n")
+		for i, name := range heaviest {
+			if i == maxHotSpots {
+				break
+			}
+			sb.WriteString(s.format.sprintf("| %s | %d |\n", escapeTableCell(name), fanOut[name]))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("dependencies.md", []byte(sb.String()))
+}
+
+// mermaidDependencyGraph renders dependency edges as a Mermaid "graph LR"
+// diagram, using a different node shape per object type
+func mermaidDependencyGraph(edges []dependencyEdge) string {
+	ids := make(map[string]string)
+	var sb strings.Builder
+	sb.WriteString("```mermaid\n")
+	sb.WriteString("graph LR\n")
+
+	node := func(name, objType string) string {
+		key := objType + "|" + name
+		if id, exists := ids[key]; exists {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(ids))
+		ids[key] = id
+
+		label := mermaidLabel(name)
+		switch objType {
+		case "SDT":
+			return fmt.Sprintf("%s[/\"%s\"/]", id, label)
+		case "Transaction":
+			return fmt.Sprintf("%s[(\"%s\")]", id, label)
+		default:
+			return fmt.Sprintf("%s[\"%s\"]", id, label)
+		}
+	}
+
+	for _, e := range edges {
+		sb.WriteString(fmt.Sprintf("    %s --> %s\n", node(e.From, "Procedure"), node(e.To, e.ToType)))
+	}
+
+	sb.WriteString("```\n")
+	return sb.String()
+}
@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// mermaidCallGraph renders a Mermaid "graph TD" diagram with the callers and
+// callees of a procedure. Returns an empty string when there are no calls.
+func mermaidCallGraph(name string, calls *graph.Graph) string {
+	callers := calls.Callers(name)
+	callees := calls.Callees(name)
+	if len(callers) == 0 && len(callees) == 0 {
+		return ""
+	}
+
+	// Node IDs are generated so object names never clash with Mermaid syntax
+	ids := map[string]string{name: "n0"}
+	nodeID := func(node string) string {
+		if id, exists := ids[node]; exists {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(ids))
+		ids[node] = id
+		return id
+	}
+
+	var sb strings.Builder
+	sb.WriteString("```mermaid\n")
+	sb.WriteString("graph TD\n")
+	sb.WriteString(fmt.Sprintf("    n0[\"%s\"]\n", mermaidLabel(name)))
+	for _, caller := range callers {
+		sb.WriteString(fmt.Sprintf("    %s[\"%s\"] --> n0\n", nodeID(caller), mermaidLabel(caller)))
+	}
+	for _, callee := range callees {
+		sb.WriteString(fmt.Sprintf("    n0 --> %s[\"%s\"]\n", nodeID(callee), mermaidLabel(callee)))
+	}
+	sb.WriteString("    style n0 stroke-width:3px\n")
+	sb.WriteString("```\n")
+
+	return sb.String()
+}
+
+// mermaidLabel escapes characters that would break a quoted Mermaid label
+func mermaidLabel(label string) string {
+	return strings.ReplaceAll(label, "\"", "#quot;")
+}
+
+// callListsSection renders the "Called by" and "Calls" link lists of a
+// procedure page. Returns "" when the procedure has no calls.
+func callListsSection(proc model.GXObject, s *site) string {
+	callers := s.calls.Callers(proc.Path)
+	callees := s.calls.Callees(proc.Path)
+	if len(callers) == 0 && len(callees) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	for _, list := range []struct {
+		heading string
+		names   []string
+	}{{"Called by", callers}, {"Calls", callees}} {
+		if len(list.names) == 0 {
+			continue
+		}
+		sb.WriteString("### " + s.text.T(list.heading) + "\n\n")
+		for _, name := range list.names {
+			sb.WriteString("- " + s.objectLink(proc, name) + "\n")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// objectLink links to the page of the named object from a procedure page,
+// or renders the name as code when the object has no page
+func (s *site) objectLink(from model.GXObject, name string) string {
+	if target, ok := s.kb.ByName(name); ok && target.Type == "Procedure" {
+		return fmt.Sprintf("[%s](%s%s)", name, s.rootPrefix(from), s.procedureFile(target))
+	}
+	return "`" + name + "`"
+}
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestCallListsSection(t *testing.T) {
+	objects := []model.GXObject{
+		{Path: "Main", Type: "Procedure", SourceCode: "Helper.Call()\nCustomer.Call()"},
+		{Path: "Helper", Type: "Procedure", Documentation: &model.DocComment{Package: "Utils"}},
+		{Path: "Customer", Type: "Transaction"},
+		{Path: "Caller", Type: "Procedure", SourceCode: "Main.Call()"},
+	}
+	s := &site{calls: graph.Build(objects), kb: model.NewKnowledgeBase("Test", objects, nil)}
+
+	got := callListsSection(objects[0], s)
+	for _, want := range []string{"### Called by\n\n- [Caller](./Caller.md)\n", "### Calls\n\n- [Helper](./Utils/Helper.md)\n- `Customer`\n"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected %q in:\n%s", want, got)
+		}
+	}
+
+	if got := callListsSection(objects[1], s); !strings.Contains(got, "- [Main](../Main.md)") || strings.Contains(got, "### Calls") {
+		t.Errorf("Unexpected section for Helper:\n%s", got)
+	}
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/diff"
+)
+
+// RenderDiff renders the differences between two exports as Markdown.
+// oldLabel and newLabel name the compared exports in the title.
+func RenderDiff(result diff.Result, oldLabel, newLabel string) string {
+	var sb strings.Builder
+	sb.WriteString("# Export Diff\n\n")
+	sb.WriteString(fmt.Sprintf("Comparing `%s` to `%s`.\n\n", oldLabel, newLabel))
+
+	if result.IsEmpty() {
+		sb.WriteString("*No changes between the two exports.*\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Added | Removed | Changed |\n")
+	sb.WriteString("|-------|---------|---------|\n")
+	sb.WriteString(fmt.Sprintf("| %d | %d | %d |\n\n", len(result.Added), len(result.Removed), len(result.Changed)))
+
+	if breaking := result.BreakingChanges(); len(breaking) > 0 {
+		sb.WriteString("## Breaking Changes\n\n")
+		sb.WriteString("| Name | Type | Change |\n")
+		sb.WriteString("|------|------|--------|\n")
+		for _, b := range breaking {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", escapeTableCell(b.Name), b.Type, escapeTableCell(b.Reason)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(result.Added) > 0 {
+		sb.WriteString("## Added Objects\n\n")
+		sb.WriteString("| Name | Type | Signature |\n")
+		sb.WriteString("|------|------|-----------|\n")
+		for _, obj := range result.Added {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+				escapeTableCell(obj.Path), obj.Type, signatureCell(obj.ParmSignature)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(result.Removed) > 0 {
+		sb.WriteString("## Removed Objects\n\n")
+		sb.WriteString("| Name | Type | Signature |\n")
+		sb.WriteString("|------|------|-----------|\n")
+		for _, obj := range result.Removed {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+				escapeTableCell(obj.Path), obj.Type, signatureCell(obj.ParmSignature)))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(result.Changed) > 0 {
+		sb.WriteString("## Changed Objects\n\n")
+		sb.WriteString("| Name | Type | Signature | Rules | Source | Variables | Docs |\n")
+		sb.WriteString("|------|------|-----------|-------|--------|-----------|------|\n")
+		for _, change := range result.Changed {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s |\n",
+				escapeTableCell(change.Name), change.Type, checkMark(change.SignatureChanged),
+				checkMark(change.RulesChanged), checkMark(change.SourceChanged), checkMark(change.VariablesChanged),
+				checkMark(change.DocChanged)))
+		}
+		sb.WriteString("\n")
+
+		for _, change := range result.Changed {
+			if !change.SignatureChanged {
+				continue
+			}
+			sb.WriteString("### " + change.Name + "\n\n")
+			sb.WriteString("```genexus\n")
+			sb.WriteString("// before\n" + oneLine(change.Old.ParmSignature) + "\n")
+			sb.WriteString("// after\n" + oneLine(change.New.ParmSignature) + "\n")
+			sb.WriteString("```\n\n")
+			for _, pc := range change.ParamChanges {
+				sb.WriteString("- " + pc.String() + "\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	sb.WriteString("---\n")
+	sb.WriteString(fmt.Sprintf("Generated by GXDocGen v%s\n", version))
+	return sb.String()
+}
+
+// signatureCell renders a Parm signature for a table cell, or "-"
+func signatureCell(signature string) string {
+	if signature = oneLine(signature); signature == "" {
+		return "-"
+	}
+	return "`" + escapeTableCell(signature) + "`"
+}
+
+// checkMark renders a changed flag for a table cell
+func checkMark(changed bool) string {
+	if changed {
+		return "changed"
+	}
+	return "-"
+}
@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// domainUsage lists the values of a domain referenced across the KB and the
+// procedures that use each of them
+type domainUsage struct {
+	Name   string
+	Values []domainValueUsage
+}
+
+// domainValueUsage is a single domain value and the procedures using it
+type domainValueUsage struct {
+	Value      string
+	Procedures []model.GXObject
+}
+
+// collectDomainUsage groups domain value references by domain. Names are
+// matched case-insensitively, keeping the first spelling seen.
+func collectDomainUsage(procedures []model.GXObject, order *collation) []domainUsage {
+	domains := make(map[string]*domainUsage)
+	values := make(map[string]*domainValueUsage)
+	var valueOrder []string
+
+	for _, proc := range procedures {
+		for _, dv := range proc.DomainValues {
+			domainKey := strings.ToLower(dv.Domain)
+			if _, exists := domains[domainKey]; !exists {
+				domains[domainKey] = &domainUsage{Name: dv.Domain}
+			}
+			valueKey := domainKey + "." + strings.ToLower(dv.Value)
+			if _, exists := values[valueKey]; !exists {
+				values[valueKey] = &domainValueUsage{Value: dv.Value}
+				valueOrder = append(valueOrder, valueKey)
+			}
+			values[valueKey].Procedures = append(values[valueKey].Procedures, proc)
+		}
+	}
+
+	order.sortStrings(valueOrder)
+	for _, key := range valueOrder {
+		domainKey := key[:strings.Index(key, ".")]
+		domains[domainKey].Values = append(domains[domainKey].Values, *values[key])
+	}
+
+	var result []domainUsage
+	for _, key := range sortedKeys(domains, order) {
+		result = append(result, *domains[key])
+	}
+	return result
+}
+
+// domainAnchor returns the domains.md heading anchor of a domain
+func domainAnchor(domain string) string {
+	return strings.ToLower(domain)
+}
+
+// domainValuesSection renders the procedure page list of domain values,
+// linked to the KB-wide domains page
+func domainValuesSection(proc model.GXObject, s *site) string {
+	if len(proc.DomainValues) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + s.text.T("Domain Values") + "\n\n")
+	for _, dv := range proc.DomainValues {
+		sb.WriteString(fmt.Sprintf("- [`%s.%s`](%sdomains.md#%s)\n", dv.Domain, dv.Value, s.rootPrefix(proc), domainAnchor(dv.Domain)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// generateDomainPage writes domains.md, listing every enumerated domain
+// value found in procedure sources and where it is used
+func generateDomainPage(domains []domainUsage, s *site) error {
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Domain Values") + "\n\n")
+	sb.WriteString("Enumerated domain values compared against or assigned in procedure sources.\n\n")
+
+	for _, domain := range domains {
+		sb.WriteString("## " + domain.Name + "\n\n")
+		sb.WriteString("| Value | Used by |\n")
+		sb.WriteString("|-------|---------|\n")
+		for _, value := range domain.Values {
+			var links []string
+			for _, proc := range value.Procedures {
+				links = append(links, fmt.Sprintf("[%s](./%s)", proc.Path, s.procedureFile(proc)))
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | %s |\n", value.Value, strings.Join(links, ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("domains.md", []byte(sb.String()))
+}
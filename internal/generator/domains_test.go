@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestCollectDomainUsage(t *testing.T) {
+	procedures := []model.GXObject{
+		{Path: "Activate", DomainValues: []model.DomainValue{{Domain: "Status", Value: "Active"}}},
+		{Path: "Suspend", DomainValues: []model.DomainValue{
+			{Domain: "status", Value: "Suspended"},
+			{Domain: "OrderType", Value: "Express"},
+		}},
+		{Path: "Check", DomainValues: []model.DomainValue{{Domain: "STATUS", Value: "ACTIVE"}}},
+	}
+
+	domains := collectDomainUsage(procedures, nil)
+
+	if len(domains) != 2 || domains[0].Name != "OrderType" || domains[1].Name != "Status" {
+		t.Fatalf("Unexpected domains: %+v", domains)
+	}
+	status := domains[1]
+	if len(status.Values) != 2 || status.Values[0].Value != "Active" || status.Values[1].Value != "Suspended" {
+		t.Fatalf("Unexpected Status values: %+v", status.Values)
+	}
+	if users := status.Values[0].Procedures; len(users) != 2 || users[0].Path != "Activate" || users[1].Path != "Check" {
+		t.Errorf("Unexpected users of Status.Active: %+v", users)
+	}
+}
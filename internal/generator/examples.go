@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+)
+
+// maxExampleUsages caps the call sites shown on a procedure page
+const maxExampleUsages = 2
+
+// exampleUsages renders the "Example Usages" section from real call sites
+// in other objects, one per caller. Returns "" when the procedure is never called.
+func exampleUsages(name string, s *site) string {
+	var sb strings.Builder
+	count := 0
+	for _, caller := range s.calls.Callers(name) {
+		obj, _ := s.kb.ByName(caller)
+		sites := graph.CallSites(obj.SourceCode, name)
+		if len(sites) == 0 {
+			continue
+		}
+		if count == 0 {
+			sb.WriteString("## " + s.text.T("Example Usages") + "\n\n")
+		}
+		sb.WriteString(fmt.Sprintf("From `%s`:\n\n", caller))
+		sb.WriteString("```genexus\n" + sites[0] + "\n```\n\n")
+
+		count++
+		if count == maxExampleUsages {
+			break
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,26 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/flow"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// flowSummarySection renders a heuristic control-flow summary, marked as
+// machine-generated. Returns "" when the analysis finds nothing.
+func flowSummarySection(proc model.GXObject, s *site) string {
+	lines := flow.Analyze(proc.SourceCode).Lines()
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + s.text.T("Flow Summary") + "\n\n")
+	sb.WriteString("> 🤖 *" + s.text.T("Machine-generated from static analysis of the source. Review it before relying on it.") + "*\n\n")
+	for _, line := range lines {
+		sb.WriteString("- " + line + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
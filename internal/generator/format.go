@@ -0,0 +1,90 @@
+package generator
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// dateLayouts maps languages to their short date layout. Region-specific
+// entries take precedence over the base language.
+var dateLayouts = map[string]string{
+	"de":    "02.01.2006",
+	"en":    "01/02/2006",
+	"en-GB": "02/01/2006",
+	"es":    "02/01/2006",
+	"fr":    "02/01/2006",
+	"it":    "02/01/2006",
+	"pt":    "02/01/2006",
+}
+
+// isoDate is the layout of @created dates and of dates without --lang
+const isoDate = "2006-01-02"
+
+// formatter renders counts, percentages and dates for the language of the
+// generated documentation. The zero language keeps ISO dates and plain
+// numbers.
+type formatter struct {
+	printer    *message.Printer
+	dateLayout string
+}
+
+// newFormatter returns the formatter for a BCP 47 language such as "pt-BR"
+func newFormatter(lang string) (*formatter, error) {
+	f := &formatter{dateLayout: isoDate}
+	if lang == "" {
+		return f, nil
+	}
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return nil, fmt.Errorf("invalid language %q: %w", lang, err)
+	}
+	f.printer = message.NewPrinter(tag)
+
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	if layout, ok := dateLayouts[base.String()+"-"+region.String()]; ok {
+		f.dateLayout = layout
+	} else if layout, ok := dateLayouts[base.String()]; ok {
+		f.dateLayout = layout
+	}
+	return f, nil
+}
+
+// sprintf formats like fmt.Sprintf with localized numbers
+func (f *formatter) sprintf(format string, args ...interface{}) string {
+	if f == nil || f.printer == nil {
+		return fmt.Sprintf(format, args...)
+	}
+	return f.printer.Sprintf(format, args...)
+}
+
+// Int formats a count, with digit grouping when a language is set
+func (f *formatter) Int(n int) string {
+	return f.sprintf("%d", n)
+}
+
+// Date formats the date part of t
+func (f *formatter) Date(t time.Time) string {
+	if f == nil {
+		return t.Format(isoDate)
+	}
+	return t.Format(f.dateLayout)
+}
+
+// DateTime formats t as a date followed by the 24-hour time
+func (f *formatter) DateTime(t time.Time) string {
+	return f.Date(t) + " " + t.Format("15:04:05")
+}
+
+// DocDate formats an ISO date from an annotation such as @created. Other
+// values are returned unchanged.
+func (f *formatter) DocDate(value string) string {
+	t, err := time.Parse(isoDate, value)
+	if err != nil {
+		return value
+	}
+	return f.Date(t)
+}
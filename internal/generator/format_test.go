@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatter(t *testing.T) {
+	date := time.Date(2024, 12, 31, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		lang    string
+		date    string
+		count   string
+		percent string
+		docDate string
+	}{
+		{"", "2024-12-31 18:30:00", "1234", "85.5%", "2024-01-15"},
+		{"pt-BR", "31/12/2024 18:30:00", "1.234", "85,5%", "15/01/2024"},
+		{"en", "12/31/2024 18:30:00", "1,234", "85.5%", "01/15/2024"},
+		{"en-GB", "31/12/2024 18:30:00", "1,234", "85.5%", "15/01/2024"},
+		{"de", "31.12.2024 18:30:00", "1.234", "85,5%", "15.01.2024"},
+	}
+
+	for _, tt := range tests {
+		f, err := newFormatter(tt.lang)
+		if err != nil {
+			t.Fatalf("Language %q: %v", tt.lang, err)
+		}
+		if got := f.DateTime(date); got != tt.date {
+			t.Errorf("Language %q: DateTime = %q, expected %q", tt.lang, got, tt.date)
+		}
+		if got := f.Int(1234); got != tt.count {
+			t.Errorf("Language %q: Int = %q, expected %q", tt.lang, got, tt.count)
+		}
+		if got := f.sprintf("%.1f%%", 85.5); got != tt.percent {
+			t.Errorf("Language %q: percentage = %q, expected %q", tt.lang, got, tt.percent)
+		}
+		if got := f.DocDate("2024-01-15"); got != tt.docDate {
+			t.Errorf("Language %q: DocDate = %q, expected %q", tt.lang, got, tt.docDate)
+		}
+	}
+
+	if f, _ := newFormatter("pt-BR"); f.DocDate("Jan 2024") != "Jan 2024" {
+		t.Error("DocDate should keep values that are not ISO dates")
+	}
+	if _, err := newFormatter("not a language!"); err == nil {
+		t.Error("Expected an error for an invalid language")
+	}
+}
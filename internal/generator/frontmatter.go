@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+// FrontMatterFields lists the front matter fields, in the order they are written
+var FrontMatterFields = []string{"title", "tags", "package", "date"}
+
+// ParseFrontMatter parses a comma-separated list of front matter fields.
+// "all" selects every field.
+func ParseFrontMatter(list string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(list, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		switch {
+		case field == "":
+			continue
+		case field == "all":
+			return FrontMatterFields, nil
+		case !slices.Contains(FrontMatterFields, field):
+			return nil, fmt.Errorf("unknown front matter field '%s' (expected %s)", field, strings.Join(FrontMatterFields, ", "))
+		case !slices.Contains(fields, field):
+			fields = append(fields, field)
+		}
+	}
+	return fields, nil
+}
+
+// frontMatterStorage prepends YAML front matter to every Markdown file, so
+// static site generators such as Jekyll or Eleventy pick up the pages as-is
+type frontMatterStorage struct {
+	storage.Storage
+	s *site
+
+	// pages maps procedure page paths to their object
+	pages map[string]model.GXObject
+}
+
+// withFrontMatter wraps out when Options.FrontMatter selects any field
+func withFrontMatter(out storage.Storage, procedures []model.GXObject, s *site) storage.Storage {
+	if len(s.opts.FrontMatter) == 0 {
+		return out
+	}
+	pages := make(map[string]model.GXObject, len(procedures))
+	for _, proc := range procedures {
+		pages[s.procedureFile(proc)] = proc
+	}
+	return &frontMatterStorage{Storage: out, s: s, pages: pages}
+}
+
+func (f *frontMatterStorage) WriteFile(name string, data []byte) error {
+	if !strings.HasSuffix(name, ".md") {
+		return f.Storage.WriteFile(name, data)
+	}
+	return f.Storage.WriteFile(name, append([]byte(f.frontMatter(name, data)), data...))
+}
+
+// frontMatter renders the selected fields of a page. The title is the first
+// heading; tags and package are known for procedure and package pages.
+func (f *frontMatterStorage) frontMatter(name string, data []byte) string {
+	var tags []string
+	pkg := ""
+	if proc, ok := f.pages[name]; ok {
+		if proc.Documentation != nil {
+			tags = proc.Documentation.Tags
+			pkg = proc.Documentation.Package
+		}
+	} else {
+		for pkgName, file := range f.s.packageFiles {
+			if file == name {
+				pkg = pkgName
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	for _, field := range f.s.opts.FrontMatter {
+		switch field {
+		case "title":
+			if title := pageTitle(data); title != "" {
+				sb.WriteString("title: " + strconv.Quote(title) + "\n")
+			}
+		case "tags":
+			if len(tags) > 0 {
+				quoted := make([]string, len(tags))
+				for i, tag := range tags {
+					quoted[i] = strconv.Quote(tag)
+				}
+				sb.WriteString("tags: [" + strings.Join(quoted, ", ") + "]\n")
+			}
+		case "package":
+			if pkg != "" {
+				sb.WriteString("package: " + strconv.Quote(pkg) + "\n")
+			}
+		case "date":
+			sb.WriteString("date: " + f.s.opts.generatedAt().Format("2006-01-02") + "\n")
+		}
+	}
+	sb.WriteString("---\n\n")
+	return sb.String()
+}
+
+// pageTitle returns the text of the first top-level heading of a page
+func pageTitle(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		if title, ok := strings.CutPrefix(line, "# "); ok {
+			return strings.TrimSpace(title)
+		}
+	}
+	return ""
+}
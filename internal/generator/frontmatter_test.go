@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestParseFrontMatter(t *testing.T) {
+	fields, err := ParseFrontMatter(" Title, tags,title ")
+	if err != nil || strings.Join(fields, ",") != "title,tags" {
+		t.Errorf("Expected [title tags], got %v (%v)", fields, err)
+	}
+	if fields, _ := ParseFrontMatter("all"); len(fields) != len(FrontMatterFields) {
+		t.Errorf("Expected 'all' to select every field, got %v", fields)
+	}
+	if _, err := ParseFrontMatter("title,author"); err == nil {
+		t.Error("Expected an error for an unknown field")
+	}
+}
+
+func TestGenerateDocsFrontMatter(t *testing.T) {
+	objects := []model.GXObject{{
+		Name: "GetUser", Path: "GetUser", Type: "Procedure",
+		Documentation: &model.DocComment{Summary: "Get a user", Package: "Users", Tags: []string{"users", "read: only"}},
+	}}
+	out := storage.NewMemory()
+	opts := Options{
+		Storage:     out,
+		FrontMatter: FrontMatterFields,
+		Timestamp:   time.Date(2024, 12, 31, 10, 0, 0, 0, time.UTC),
+	}
+	if err := GenerateDocs(objects, "KB", "", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	page, _ := fs.ReadFile(out, "Users/GetUser.md")
+	want := "---\ntitle: \"Get a user\"\ntags: [\"users\", \"read: only\"]\npackage: \"Users\"\ndate: 2024-12-31\n---\n\n# Get a user\n"
+	if !strings.HasPrefix(string(page), want) {
+		t.Errorf("Expected front matter:\n%s\ngot:\n%s", want, page)
+	}
+
+	index, _ := fs.ReadFile(out, "KB.md")
+	if !strings.HasPrefix(string(index), "---\ntitle: \"KB Documentation\"\ndate: 2024-12-31\n---\n") {
+		t.Errorf("Expected title and date only on the index, got:\n%s", index)
+	}
+
+	summary, _ := fs.ReadFile(out, "summary.json")
+	if strings.HasPrefix(string(summary), "---") {
+		t.Error("Expected no front matter outside Markdown files")
+	}
+}
@@ -0,0 +1,232 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// maxProcedureLines is the source size above which a procedure counts as oversized
+const maxProcedureLines = 500
+
+// namingConventionRegex matches PascalCase object names
+var namingConventionRegex = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// Health score weights; they add up to 1
+const (
+	weightCoverage   = 0.40
+	weightDeprecated = 0.15
+	weightDead       = 0.15
+	weightOversized  = 0.15
+	weightNaming     = 0.15
+)
+
+// Kinds of health issues
+const (
+	IssueUndocumented = "undocumented"
+	IssueDeprecated   = "deprecated"
+	IssueDead         = "dead"
+	IssueOversized    = "oversized"
+	IssueNaming       = "naming"
+)
+
+// HealthMetrics counts the health indicators of a set of procedures
+type HealthMetrics struct {
+	Score            float64 `json:"score"`
+	Procedures       int     `json:"procedures"`
+	Documented       int     `json:"documented"`
+	Deprecated       int     `json:"deprecated"`
+	Dead             int     `json:"dead"`
+	Oversized        int     `json:"oversized"`
+	NamingViolations int     `json:"namingViolations"`
+}
+
+// ModuleHealth is the health of a single package
+type ModuleHealth struct {
+	Module string `json:"module"`
+	HealthMetrics
+}
+
+// HealthIssue is a single procedure lowering the health score
+type HealthIssue struct {
+	Object string `json:"object"`
+	Module string `json:"module"`
+	Kind   string `json:"kind"`
+}
+
+// Health is the composite KB health score with a per-module breakdown
+type Health struct {
+	HealthMetrics
+	Modules []ModuleHealth `json:"modules"`
+	Issues  []HealthIssue  `json:"issues"`
+}
+
+// ComputeHealth scores procedures on documentation coverage, deprecated
+// ratio, dead (never called) objects, oversized sources and naming
+// violations. Names are listed in byte order.
+func ComputeHealth(objects []model.GXObject) Health {
+	return computeHealth(objects, nil)
+}
+
+// computeHealth is ComputeHealth listing names in the given order
+func computeHealth(objects []model.GXObject, order *collation) Health {
+	calls := graph.Build(objects)
+	modules := make(map[string]*ModuleHealth)
+	health := Health{Issues: make([]HealthIssue, 0)}
+
+	for _, obj := range objects {
+		if obj.Type != "Procedure" {
+			continue
+		}
+
+		module := "root"
+		if obj.Documentation != nil && obj.Documentation.Package != "" {
+			module = obj.Documentation.Package
+		}
+		mh, exists := modules[module]
+		if !exists {
+			mh = &ModuleHealth{Module: module}
+			modules[module] = mh
+		}
+
+		var issues []string
+		if !obj.IsDocumented() {
+			issues = append(issues, IssueUndocumented)
+		}
+		if obj.Documentation != nil && obj.Documentation.Deprecated {
+			issues = append(issues, IssueDeprecated)
+		}
+		if len(calls.Callers(obj.Path)) == 0 {
+			issues = append(issues, IssueDead)
+		}
+		if sourceLines(obj.SourceCode) > maxProcedureLines {
+			issues = append(issues, IssueOversized)
+		}
+		if !namingConventionRegex.MatchString(obj.Path) {
+			issues = append(issues, IssueNaming)
+		}
+
+		for _, metrics := range []*HealthMetrics{&health.HealthMetrics, &mh.HealthMetrics} {
+			metrics.Procedures++
+			for _, issue := range issues {
+				switch issue {
+				case IssueDeprecated:
+					metrics.Deprecated++
+				case IssueDead:
+					metrics.Dead++
+				case IssueOversized:
+					metrics.Oversized++
+				case IssueNaming:
+					metrics.NamingViolations++
+				}
+			}
+			if obj.IsDocumented() {
+				metrics.Documented++
+			}
+		}
+		for _, issue := range issues {
+			health.Issues = append(health.Issues, HealthIssue{Object: obj.Path, Module: module, Kind: issue})
+		}
+	}
+
+	health.Score = healthScore(health.HealthMetrics)
+	health.Modules = make([]ModuleHealth, 0, len(modules))
+	for _, module := range sortedKeys(modules, order) {
+		mh := modules[module]
+		mh.Score = healthScore(mh.HealthMetrics)
+		health.Modules = append(health.Modules, *mh)
+	}
+	sort.SliceStable(health.Issues, func(i, j int) bool {
+		if health.Issues[i].Kind != health.Issues[j].Kind {
+			return health.Issues[i].Kind < health.Issues[j].Kind
+		}
+		return order.less(health.Issues[i].Object, health.Issues[j].Object)
+	})
+
+	return health
+}
+
+// healthScore combines the weighted indicators into a 0-100 score
+func healthScore(m HealthMetrics) float64 {
+	if m.Procedures == 0 {
+		return 100
+	}
+	ratio := func(count int) float64 {
+		return float64(count) / float64(m.Procedures)
+	}
+
+	score := weightCoverage*ratio(m.Documented) +
+		weightDeprecated*(1-ratio(m.Deprecated)) +
+		weightDead*(1-ratio(m.Dead)) +
+		weightOversized*(1-ratio(m.Oversized)) +
+		weightNaming*(1-ratio(m.NamingViolations))
+	return float64(int(score*1000+0.5)) / 10
+}
+
+// sourceLines counts the non-blank lines of a source
+func sourceLines(source string) int {
+	count := 0
+	for _, line := range strings.Split(source, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// writeHealthPage writes health.md, the KB health scorecard
+func writeHealthPage(health Health, s *site) error {
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("KB Health") + "\n\n")
+	sb.WriteString(s.format.sprintf("**"+s.text.T("Health score: %.1f / 100")+"**\n\n", health.Score))
+
+	sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n", s.text.T("Indicator"), s.text.T("Weight"), s.text.T("Count"), s.text.T("Procedures")))
+	sb.WriteString("|-----------|--------|-------|------------|\n")
+	rows := []struct {
+		label  string
+		weight float64
+		count  int
+	}{
+		{s.text.T("Documented"), weightCoverage, health.Documented},
+		{s.text.T("Deprecated"), weightDeprecated, health.Deprecated},
+		{s.text.T("Dead (never called)"), weightDead, health.Dead},
+		{s.format.sprintf(s.text.T("Oversized (> %d lines)"), maxProcedureLines), weightOversized, health.Oversized},
+		{s.text.T("Naming violations"), weightNaming, health.NamingViolations},
+	}
+	for _, row := range rows {
+		sb.WriteString(s.format.sprintf("| %s | %.0f%% | %d | %d |\n", row.label, row.weight*100, row.count, health.Procedures))
+	}
+	sb.WriteString("\n")
+
+	if len(health.Modules) > 0 {
+		sb.WriteString("## " + s.text.T("Modules") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s | %s | %s |\n", s.text.T("Module"), s.text.T("Score"), s.text.T("Procedures"),
+			s.text.T("Documented"), s.text.T("Deprecated"), s.text.T("Dead"), s.text.T("Oversized"), s.text.T("Naming")))
+		sb.WriteString("|--------|-------|------------|------------|------------|------|-----------|--------|\n")
+		for _, m := range health.Modules {
+			sb.WriteString(s.format.sprintf("| %s | %.1f | %d | %d | %d | %d | %d | %d |\n",
+				escapeTableCell(m.Module), m.Score, m.Procedures, m.Documented, m.Deprecated, m.Dead, m.Oversized, m.NamingViolations))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(health.Issues) > 0 {
+		sb.WriteString("## " + s.text.T("Issues") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.text.T("Procedure"), s.text.T("Module"), s.text.T("Issue")))
+		sb.WriteString("|-----------|--------|-------|\n")
+		for _, issue := range health.Issues {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", escapeTableCell(issue.Object), escapeTableCell(issue.Module), issue.Kind))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("health.md", []byte(sb.String()))
+}
@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestComputeHealth(t *testing.T) {
+	objects := []model.GXObject{
+		{Path: "GetUser", Type: "Procedure", SourceCode: "CalcTotal.Call()",
+			Documentation: &model.DocComment{Package: "users"}},
+		{Path: "CalcTotal", Type: "Procedure", SourceCode: "&Total = 0",
+			Documentation: &model.DocComment{Package: "billing", Deprecated: true}},
+		{Path: "old_report", Type: "Procedure", SourceCode: strings.Repeat("&X += 1\n", maxProcedureLines+1),
+			Documentation: &model.DocComment{Package: "billing", IsAutoGenerated: true}},
+	}
+
+	health := ComputeHealth(objects)
+
+	want := HealthMetrics{Procedures: 3, Documented: 2, Deprecated: 1, Dead: 2, Oversized: 1, NamingViolations: 1}
+	want.Score = healthScore(want)
+	if health.HealthMetrics != want {
+		t.Errorf("Unexpected KB metrics: %+v, expected %+v", health.HealthMetrics, want)
+	}
+
+	if len(health.Modules) != 2 || health.Modules[0].Module != "billing" || health.Modules[0].Procedures != 2 {
+		t.Errorf("Unexpected modules: %+v", health.Modules)
+	}
+	if len(health.Issues) != 6 || health.Issues[0].Kind != IssueDead {
+		t.Errorf("Unexpected issues: %+v", health.Issues)
+	}
+}
+
+func TestHealthScore(t *testing.T) {
+	if score := healthScore(HealthMetrics{}); score != 100 {
+		t.Errorf("Expected empty KB to score 100, got %.1f", score)
+	}
+	if score := healthScore(HealthMetrics{Procedures: 2, Documented: 2}); score != 100 {
+		t.Errorf("Expected perfect KB to score 100, got %.1f", score)
+	}
+	if score := healthScore(HealthMetrics{Procedures: 2, Documented: 1, Dead: 1}); score != 72.5 {
+		t.Errorf("Expected 72.5, got %.1f", score)
+	}
+}
@@ -0,0 +1,363 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// catalogs holds the built-in translations of generated headings and labels,
+// keyed by base language. Keys are the English text.
+var catalogs = map[string]map[string]string{
+	"pt": {
+		"%s Documentation":                  "Documentação de %s",
+		"GeneXus Documentation":             "Documentação GeneXus",
+		"Generated on":                      "Gerado em",
+		"Total Objects":                     "Total de objetos",
+		"Table of Contents":                 "Sumário",
+		"Object Statistics":                 "Estatísticas de objetos",
+		"Packages":                          "Pacotes",
+		"Package":                           "Pacote",
+		"Procedures":                        "Procedimentos",
+		"Extracted Objects":                 "Objetos extraídos",
+		"No objects found in the XPZ file.": "Nenhum objeto encontrado no arquivo XPZ.",
+		"Name":                              "Nome",
+		"Type":                              "Tipo",
+		"Count":                             "Quantidade",
+		"Path":                              "Caminho",
+		"Summary":                           "Resumo",
+		"Signature":                         "Assinatura",
+		"DEPRECATED":                        "OBSOLETO",
+		"see the [migration guide](%s)":     "veja o [guia de migração](%s)",
+		"Description":                       "Descrição",
+		"Parameters":                        "Parâmetros",
+		"Direction":                         "Direção",
+		"Return":                            "Retorno",
+		"Local Variables":                   "Variáveis locais",
+		"Collection":                        "Coleção",
+		"Yes":                               "Sim",
+		"Call Graph":                        "Grafo de chamadas",
+		"Called by":                         "Chamado por",
+		"Calls":                             "Chama",
+		"Example Usages":                    "Exemplos de uso",
+		"Examples":                          "Exemplos",
+		"Source Code":                       "Código-fonte",
+		"Show source":                       "Mostrar código-fonte",
+		"Custom Fields":                     "Campos personalizados",
+		"Field":                             "Campo",
+		"Value":                             "Valor",
+		"Author":                            "Autor",
+		"Created":                           "Criado em",
+		"Since":                             "Desde",
+		"Auto-generated from XML metadata. Add `/** */` annotations for detailed documentation.": "Gerado automaticamente a partir dos metadados XML. Adicione anotações `/** */` para uma documentação detalhada.",
+		"Package: %s":               "Pacote: %s",
+		"Back to index":             "Voltar ao índice",
+		"Generated by GXDocGen v%s": "Gerado pelo GXDocGen v%s",
+		"What's New":                "Novidades",
+		"Documentation Coverage":    "Cobertura da documentação",
+		"Annotation Usage":          "Uso de anotações",
+		"KB Health":                 "Saúde da KB",
+		"Object Dependencies":       "Dependências entre objetos",
+		"Domain Values":             "Valores de domínio",
+		"Data Model":                "Modelo de dados",
+		"Attributes":                "Atributos",
+		"Attribute":                 "Atributo",
+		"Key":                       "Chave",
+		"References":                "Referência",
+		"Transactions":              "Transações",
+		"Public API":                "API pública",
+		"Main program":              "Programa principal",
+		"Exposed as":                "Exposto como",
+		"Untagged":                  "Sem tag",
+		"Main programs and services exposed to external consumers. Internal objects are listed in the [full index](%s).": "Programas principais e serviços expostos a consumidores externos. Os objetos internos estão no [índice completo](%s).",
+		"Migration Guide":                     "Guia de migração",
+		"Cheat Sheet":                         "Guia rápido",
+		"Badges":                              "Selos",
+		"Architecture Layers":                 "Camadas da arquitetura",
+		"Layer":                               "Camada",
+		"Objects":                             "Objetos",
+		"Unclassified":                        "Não classificados",
+		"Calls Between Layers":                "Chamadas entre camadas",
+		"From":                                "De",
+		"To":                                  "Para",
+		"Number of Calls":                     "Número de chamadas",
+		"No calls between layers were found.": "Nenhuma chamada entre camadas foi encontrada.",
+		"Cross-Layer Warnings":                "Avisos entre camadas",
+		"No call goes from a lower layer to a higher one.": "Nenhuma chamada vai de uma camada inferior para uma superior.",
+		"Knowledge Base Info":                              "Informações da KB",
+		"Property":                                         "Propriedade",
+		"Knowledge Base":                                   "Base de conhecimento",
+		"GeneXus Version":                                  "Versão do GeneXus",
+		"Export Date":                                      "Data da exportação",
+		"Environment":                                      "Ambiente",
+		"Modules":                                          "Módulos",
+		"Returned through the OUT parameter &%s.": "Retornado pelo parâmetro OUT &%s.",
+		"IN":            "Entrada",
+		"OUT":           "Saída",
+		"INOUT":         "Entrada/Saída",
+		"Unknown":       "Desconhecido",
+		"Procedure":     "Procedimento",
+		"Transaction":   "Transação",
+		"Numeric":       "Numérico",
+		"Character":     "Caractere",
+		"Date":          "Data",
+		"DateTime":      "Data e hora",
+		"Boolean":       "Booleano",
+		"Unknown Types": "Tipos desconhecidos",
+		"Type GUID":     "GUID do tipo",
+		"Screenshots":   "Capturas de tela",
+		"Objects of these types were not documented. Map a GUID to a type name under `objectTypes` in the config file, and please report new GUIDs so they can be supported.": "Os objetos destes tipos não foram documentados. Associe um GUID a um nome de tipo em `objectTypes` no arquivo de configuração e informe novos GUIDs para que possam ser suportados.",
+		"Health score: %.1f / 100": "Pontuação de saúde: %.1f / 100",
+		"Indicator":                "Indicador",
+		"Weight":                   "Peso",
+		"Documented":               "Documentados",
+		"Deprecated":               "Obsoletos",
+		"Dead (never called)":      "Mortos (nunca chamados)",
+		"Oversized (> %d lines)":   "Extensos (> %d linhas)",
+		"Naming violations":        "Violações de nomenclatura",
+		"Module":                   "Módulo",
+		"Score":                    "Pontuação",
+		"Dead":                     "Mortos",
+		"Oversized":                "Extensos",
+		"Naming":                   "Nomenclatura",
+		"Issues":                   "Problemas",
+		"Issue":                    "Problema",
+		"No dependencies between objects were found.": "Nenhuma dependência entre objetos foi encontrada.",
+		"Dependency Graph":                  "Grafo de dependências",
+		"Most Referenced Objects":           "Objetos mais referenciados",
+		"Object":                            "Objeto",
+		"Referenced By":                     "Referenciado por",
+		"Procedures With Most Dependencies": "Procedimentos com mais dependências",
+		"Dependencies":                      "Dependências",
+		"Flow Summary":                      "Resumo do fluxo",
+		"Machine-generated from static analysis of the source. Review it before relying on it.": "Gerado automaticamente por análise estática do código-fonte. Revise-o antes de confiar nele.",
+		"Share of the %d procedure(s) whose `/** */` block uses each tag.":                      "Parcela dos %d procedimento(s) cujo bloco `/** */` usa cada tag.",
+		"Tag":        "Tag",
+		"Usage":      "Uso",
+		"By Package": "Por pacote",
+		"**%.1f%%** of procedures are documented (%d/%d).": "**%.1f%%** dos procedimentos estão documentados (%d/%d).",
+		"Total":        "Total",
+		"Coverage":     "Cobertura",
+		"Undocumented": "Não documentados",
+	},
+	"es": {
+		"%s Documentation":                  "Documentación de %s",
+		"GeneXus Documentation":             "Documentación GeneXus",
+		"Generated on":                      "Generado el",
+		"Total Objects":                     "Total de objetos",
+		"Table of Contents":                 "Índice",
+		"Object Statistics":                 "Estadísticas de objetos",
+		"Packages":                          "Paquetes",
+		"Package":                           "Paquete",
+		"Procedures":                        "Procedimientos",
+		"Extracted Objects":                 "Objetos extraídos",
+		"No objects found in the XPZ file.": "No se encontraron objetos en el archivo XPZ.",
+		"Name":                              "Nombre",
+		"Type":                              "Tipo",
+		"Count":                             "Cantidad",
+		"Path":                              "Ruta",
+		"Summary":                           "Resumen",
+		"Signature":                         "Firma",
+		"DEPRECATED":                        "OBSOLETO",
+		"see the [migration guide](%s)":     "consulte la [guía de migración](%s)",
+		"Description":                       "Descripción",
+		"Parameters":                        "Parámetros",
+		"Direction":                         "Dirección",
+		"Return":                            "Retorno",
+		"Local Variables":                   "Variables locales",
+		"Collection":                        "Colección",
+		"Yes":                               "Sí",
+		"Call Graph":                        "Grafo de llamadas",
+		"Called by":                         "Llamado por",
+		"Calls":                             "Llama a",
+		"Example Usages":                    "Ejemplos de uso",
+		"Examples":                          "Ejemplos",
+		"Source Code":                       "Código fuente",
+		"Show source":                       "Mostrar código fuente",
+		"Custom Fields":                     "Campos personalizados",
+		"Field":                             "Campo",
+		"Value":                             "Valor",
+		"Author":                            "Autor",
+		"Created":                           "Creado el",
+		"Since":                             "Desde",
+		"Auto-generated from XML metadata. Add `/** */` annotations for detailed documentation.": "Generado automáticamente a partir de los metadatos XML. Agregue anotaciones `/** */` para una documentación detallada.",
+		"Package: %s":               "Paquete: %s",
+		"Back to index":             "Volver al índice",
+		"Generated by GXDocGen v%s": "Generado por GXDocGen v%s",
+		"What's New":                "Novedades",
+		"Documentation Coverage":    "Cobertura de la documentación",
+		"Annotation Usage":          "Uso de anotaciones",
+		"KB Health":                 "Salud de la KB",
+		"Object Dependencies":       "Dependencias entre objetos",
+		"Domain Values":             "Valores de dominio",
+		"Data Model":                "Modelo de datos",
+		"Attributes":                "Atributos",
+		"Attribute":                 "Atributo",
+		"Key":                       "Clave",
+		"References":                "Referencia",
+		"Transactions":              "Transacciones",
+		"Public API":                "API pública",
+		"Main program":              "Programa principal",
+		"Exposed as":                "Expuesto como",
+		"Untagged":                  "Sin etiqueta",
+		"Main programs and services exposed to external consumers. Internal objects are listed in the [full index](%s).": "Programas principales y servicios expuestos a consumidores externos. Los objetos internos están en el [índice completo](%s).",
+		"Migration Guide":                     "Guía de migración",
+		"Cheat Sheet":                         "Guía rápida",
+		"Badges":                              "Insignias",
+		"Architecture Layers":                 "Capas de la arquitectura",
+		"Layer":                               "Capa",
+		"Objects":                             "Objetos",
+		"Unclassified":                        "Sin clasificar",
+		"Calls Between Layers":                "Llamadas entre capas",
+		"From":                                "Desde",
+		"To":                                  "Hacia",
+		"Number of Calls":                     "Número de llamadas",
+		"No calls between layers were found.": "No se encontraron llamadas entre capas.",
+		"Cross-Layer Warnings":                "Advertencias entre capas",
+		"No call goes from a lower layer to a higher one.": "Ninguna llamada va de una capa inferior a una superior.",
+		"Knowledge Base Info":                              "Información de la KB",
+		"Property":                                         "Propiedad",
+		"Knowledge Base":                                   "Base de conocimiento",
+		"GeneXus Version":                                  "Versión de GeneXus",
+		"Export Date":                                      "Fecha de exportación",
+		"Environment":                                      "Ambiente",
+		"Modules":                                          "Módulos",
+		"Returned through the OUT parameter &%s.": "Devuelto a través del parámetro OUT &%s.",
+		"IN":            "Entrada",
+		"OUT":           "Salida",
+		"INOUT":         "Entrada/Salida",
+		"Unknown":       "Desconocido",
+		"Procedure":     "Procedimiento",
+		"Transaction":   "Transacción",
+		"Numeric":       "Numérico",
+		"Character":     "Carácter",
+		"Date":          "Fecha",
+		"DateTime":      "Fecha y hora",
+		"Boolean":       "Booleano",
+		"Unknown Types": "Tipos desconocidos",
+		"Type GUID":     "GUID del tipo",
+		"Screenshots":   "Capturas de pantalla",
+		"Objects of these types were not documented. Map a GUID to a type name under `objectTypes` in the config file, and please report new GUIDs so they can be supported.": "Los objetos de estos tipos no se documentaron. Asocie un GUID a un nombre de tipo en `objectTypes` del archivo de configuración e informe los GUID nuevos para que puedan ser soportados.",
+		"Health score: %.1f / 100": "Puntuación de salud: %.1f / 100",
+		"Indicator":                "Indicador",
+		"Weight":                   "Peso",
+		"Documented":               "Documentados",
+		"Deprecated":               "Obsoletos",
+		"Dead (never called)":      "Muertos (nunca llamados)",
+		"Oversized (> %d lines)":   "Extensos (> %d líneas)",
+		"Naming violations":        "Infracciones de nomenclatura",
+		"Module":                   "Módulo",
+		"Score":                    "Puntuación",
+		"Dead":                     "Muertos",
+		"Oversized":                "Extensos",
+		"Naming":                   "Nomenclatura",
+		"Issues":                   "Problemas",
+		"Issue":                    "Problema",
+		"No dependencies between objects were found.": "No se encontraron dependencias entre objetos.",
+		"Dependency Graph":                  "Grafo de dependencias",
+		"Most Referenced Objects":           "Objetos más referenciados",
+		"Object":                            "Objeto",
+		"Referenced By":                     "Referenciado por",
+		"Procedures With Most Dependencies": "Procedimientos con más dependencias",
+		"Dependencies":                      "Dependencias",
+		"Flow Summary":                      "Resumen del flujo",
+		"Machine-generated from static analysis of the source. Review it before relying on it.": "Generado automáticamente por análisis estático del código fuente. Revíselo antes de confiar en él.",
+		"Share of the %d procedure(s) whose `/** */` block uses each tag.":                      "Proporción de los %d procedimiento(s) cuyo bloque `/** */` usa cada etiqueta.",
+		"Tag":        "Etiqueta",
+		"Usage":      "Uso",
+		"By Package": "Por paquete",
+		"**%.1f%%** of procedures are documented (%d/%d).": "**%.1f%%** de los procedimientos están documentados (%d/%d).",
+		"Total":        "Total",
+		"Coverage":     "Cobertura",
+		"Undocumented": "Sin documentar",
+	},
+}
+
+// translator maps English headings and labels to the output language
+type translator map[string]string
+
+// newTranslator returns the translations for a BCP 47 language such as
+// "pt-BR". Custom entries override the built-in catalog; languages without
+// a catalog fall back to English unless custom entries cover them.
+func newTranslator(lang string, custom map[string]string) translator {
+	t := make(translator)
+	base := strings.ToLower(strings.SplitN(strings.ReplaceAll(lang, "_", "-"), "-", 2)[0])
+	for english, text := range catalogs[base] {
+		t[english] = text
+	}
+	for english, text := range custom {
+		t[english] = text
+	}
+	return t
+}
+
+// T returns the translation of text, or text itself when there is none
+func (t translator) T(text string) string {
+	if translated, ok := t[text]; ok && translated != "" {
+		return translated
+	}
+	return text
+}
+
+// LoadTranslations reads a JSON file mapping English headings and labels to
+// custom translations, e.g. {"Parameters": "Parâmetros"}
+func LoadTranslations(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translations file: %w", err)
+	}
+	var translations map[string]string
+	if err := json.Unmarshal(data, &translations); err != nil {
+		return nil, fmt.Errorf("failed to parse translations file %s: %w", path, err)
+	}
+	return translations, nil
+}
+
+// label returns the display label of an enum-like value: a parameter
+// direction, an object or data type name, or "Unknown". Values are shown as
+// GeneXus writes them unless Options.LocalizeValues is set.
+func (s *site) label(value string) string {
+	if !s.opts.LocalizeValues {
+		return value
+	}
+	return s.text.T(value)
+}
+
+// dataType returns the display label of a data type such as "Numeric(8.0)",
+// translating the base type and keeping its length
+func (s *site) dataType(value string) string {
+	if base, size, sized := strings.Cut(value, "("); sized {
+		return s.label(base) + "(" + size
+	}
+	return s.label(value)
+}
+
+// authorLabel returns the display label of an author; the extractor writes
+// "Unknown" for objects without a user
+func (s *site) authorLabel(author string) string {
+	if author == "Unknown" {
+		return s.label(author)
+	}
+	return author
+}
+
+// headingAnchor returns the anchor GitHub generates for a Markdown heading
+func headingAnchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteRune('-')
+		}
+	}
+	return sb.String()
+}
+
+// footer renders the "Generated by" line closing every page
+func (s *site) footer() string {
+	return "\n" + fmt.Sprintf(s.text.T("Generated by GXDocGen v%s"), version) + "\n"
+}
@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestTranslator(t *testing.T) {
+	tests := []struct {
+		lang   string
+		custom map[string]string
+		text   string
+		want   string
+	}{
+		{"", nil, "Parameters", "Parameters"},
+		{"en", nil, "Parameters", "Parameters"},
+		{"pt-BR", nil, "Parameters", "Parâmetros"},
+		{"es", nil, "Parameters", "Parámetros"},
+		{"pt-BR", map[string]string{"Parameters": "Argumentos"}, "Parameters", "Argumentos"},
+		{"de", map[string]string{"Parameters": "Parameter"}, "Parameters", "Parameter"},
+		{"pt-BR", nil, "Unknown heading", "Unknown heading"},
+	}
+
+	for _, tt := range tests {
+		if got := newTranslator(tt.lang, tt.custom).T(tt.text); got != tt.want {
+			t.Errorf("Language %q: expected %q, got %q", tt.lang, tt.want, got)
+		}
+	}
+}
+
+func TestHeadingAnchor(t *testing.T) {
+	tests := map[string]string{
+		"Object Statistics":       "object-statistics",
+		"Estatísticas de objetos": "estatísticas-de-objetos",
+		"What's New":              "whats-new",
+	}
+	for heading, want := range tests {
+		if got := headingAnchor(heading); got != want {
+			t.Errorf("%q: expected %q, got %q", heading, want, got)
+		}
+	}
+}
+
+func TestLocalizedValues(t *testing.T) {
+	s := &site{text: newTranslator("pt-BR", nil), opts: Options{LocalizeValues: true}}
+	tests := map[string]string{
+		"INOUT":        "Entrada/Saída",
+		"Procedure":    "Procedimento",
+		"Numeric(8.0)": "Numérico(8.0)",
+		"VarChar(40)":  "VarChar(40)",
+	}
+	for value, want := range tests {
+		if got := s.dataType(value); got != want {
+			t.Errorf("%q: expected %q, got %q", value, want, got)
+		}
+	}
+	if got := s.authorLabel("Unknown"); got != "Desconhecido" {
+		t.Errorf("Expected a translated unknown author, got %q", got)
+	}
+	if got := s.authorLabel("Procedure"); got != "Procedure" {
+		t.Errorf("Expected author names untouched, got %q", got)
+	}
+
+	s.opts.LocalizeValues = false
+	if got := s.label("IN"); got != "IN" {
+		t.Errorf("Expected values untouched without LocalizeValues, got %q", got)
+	}
+}
+
+func TestGenerateDocsTranslatesReportPages(t *testing.T) {
+	objects := []model.GXObject{
+		{Name: "PlaceOrder", Path: "PlaceOrder", Type: "Procedure", Parent: "Sales",
+			SourceCode:    "For each Order\n\tLoadOrder.Call()\nEndFor",
+			Documentation: &model.DocComment{Package: "sales", Description: "Places an order."}},
+		{Name: "LoadOrder", Path: "LoadOrder", Type: "Procedure", SourceCode: "For each Order\nEndFor"},
+	}
+
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out, Lang: "pt-BR"}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+	pages := map[string][]string{
+		"health.md":       {"Pontuação de saúde:", "| Indicador | Peso | Quantidade | Procedimentos |", "## Módulos", "| Módulo | Pontuação |"},
+		"dependencies.md": {"## Grafo de dependências", "## Objetos mais referenciados", "| Objeto | Tipo | Referenciado por |", "| Procedimento | Dependências |"},
+		"annotations.md":  {"| Tag | Procedimentos | Uso |", "## Por pacote", "| Pacote | Procedimentos |"},
+		"coverage.md":     {"dos procedimentos estão documentados", "| Pacote | Documentados | Total | Cobertura | Não documentados |"},
+		"LoadOrder.md":    {"## Resumo do fluxo", "Gerado automaticamente por análise estática"},
+	}
+	for name, wants := range pages {
+		page, err := fs.ReadFile(out, name)
+		if err != nil {
+			t.Fatalf("Expected %s: %v", name, err)
+		}
+		for _, want := range wants {
+			if !strings.Contains(string(page), want) {
+				t.Errorf("Expected %q in %s:\n%s", want, name, page)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/layers"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// generateLayersPage writes layers.md with the objects per architecture
+// layer, the calls between layers and the calls going up the layering
+func generateLayersPage(objects []model.GXObject, s *site) error {
+	classifier := s.opts.Layers
+
+	counts := make(map[string]int)
+	unclassified := 0
+	for _, obj := range objects {
+		if layer := classifier.Layer(obj); layer != "" {
+			counts[layer]++
+		} else {
+			unclassified++
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Architecture Layers") + "\n\n")
+	sb.WriteString("| " + s.text.T("Layer") + " | " + s.text.T("Objects") + " |\n")
+	sb.WriteString("|-------|---------|\n")
+	for _, layer := range classifier.Layers() {
+		sb.WriteString(s.format.sprintf("| %s | %d |\n", escapeTableCell(layer), counts[layer]))
+	}
+	sb.WriteString(s.format.sprintf("| *%s* | %d |\n\n", s.text.T("Unclassified"), unclassified))
+
+	deps := classifier.Dependencies(objects)
+	calls := make(map[[2]string]int)
+	var upward []layers.Dependency
+	for _, d := range deps {
+		calls[[2]string{d.FromLayer, d.ToLayer}]++
+		if classifier.Upward(d) {
+			upward = append(upward, d)
+		}
+	}
+
+	sb.WriteString("## " + s.text.T("Calls Between Layers") + "\n\n")
+	if len(deps) == 0 {
+		sb.WriteString("*" + s.text.T("No calls between layers were found.") + "*\n\n")
+	} else {
+		sb.WriteString("| " + s.text.T("From") + " | " + s.text.T("To") + " | " + s.text.T("Number of Calls") + " |\n")
+		sb.WriteString("|------|----|-----------------|\n")
+		for _, from := range classifier.Layers() {
+			for _, to := range classifier.Layers() {
+				if n := calls[[2]string{from, to}]; n > 0 {
+					sb.WriteString(s.format.sprintf("| %s | %s | %d |\n", escapeTableCell(from), escapeTableCell(to), n))
+				}
+			}
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## " + s.text.T("Cross-Layer Warnings") + "\n\n")
+	if len(upward) == 0 {
+		sb.WriteString("*" + s.text.T("No call goes from a lower layer to a higher one.") + "*\n")
+	} else {
+		for _, d := range upward {
+			sb.WriteString(fmt.Sprintf("- ⚠️ %s (%s) → %s (%s)\n", s.layerLink(d.From), d.FromLayer, s.layerLink(d.To), d.ToLayer))
+		}
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("layers.md", []byte(sb.String()))
+}
+
+// layerLink links to the page of the named object from layers.md, or
+// renders the name as code when the object has no page
+func (s *site) layerLink(name string) string {
+	if target, ok := s.kb.ByName(name); ok && target.Type == "Procedure" {
+		return fmt.Sprintf("[%s](./%s)", name, s.procedureFile(target))
+	}
+	return "`" + name + "`"
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/layers"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestGenerateLayersPage(t *testing.T) {
+	classifier, err := layers.Compile(layers.Config{
+		Order: []string{"UI", "Service", "Data"},
+		Rules: []layers.Rule{
+			{Layer: "UI", Name: "^Wp"},
+			{Layer: "Data", Name: "^Load"},
+			{Layer: "Service", Module: "^Sales$"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	objects := []model.GXObject{
+		{Name: "WpOrders", Path: "WpOrders", Type: "WebPanel", SourceCode: "PlaceOrder.Call()"},
+		{Name: "PlaceOrder", Path: "PlaceOrder", Type: "Procedure", Parent: "Sales", SourceCode: "LoadOrder.Call()"},
+		{Name: "LoadOrder", Path: "LoadOrder", Type: "Procedure", SourceCode: "WpOrders.Call()"},
+		{Name: "Misc", Path: "Misc", Type: "Procedure"},
+	}
+
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out, Layers: classifier}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+	page, err := fs.ReadFile(out, "layers.md")
+	if err != nil {
+		t.Fatalf("Expected layers.md: %v", err)
+	}
+	content := string(page)
+	for _, want := range []string{
+		"# Architecture Layers", "| UI | 1 |", "| Service | 1 |", "| Data | 1 |", "| *Unclassified* | 1 |",
+		"| UI | Service | 1 |", "| Service | Data | 1 |", "| Data | UI | 1 |",
+		"- ⚠️ [LoadOrder](./LoadOrder.md) (Data) → `WpOrders` (UI)",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in layers.md:\n%s", want, content)
+		}
+	}
+	if strings.Count(content, "⚠️") != 1 {
+		t.Errorf("Expected only the upward call to be flagged:\n%s", content)
+	}
+	if readme, _ := fs.ReadFile(out, "KB.md"); !strings.Contains(string(readme), "[Architecture Layers](./layers.md)") {
+		t.Error("Expected the layers page in the table of contents")
+	}
+
+	out = storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+	if _, err := fs.Stat(out, "layers.md"); err == nil {
+		t.Error("Expected no layers page without layer rules")
+	}
+}
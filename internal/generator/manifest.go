@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+// manifestFile is stored in the output directory to support incremental runs
+const manifestFile = ".gxdocgen-manifest.json"
+
+// manifest records the fingerprint of every generated procedure page
+type manifest struct {
+	Version string            `json:"version"`
+	Pages   map[string]string `json:"pages"`
+}
+
+// loadManifest reads the manifest of a previous run. A missing or unreadable
+// manifest yields an empty one, so every page is regenerated.
+func loadManifest(out storage.Storage) manifest {
+	m := manifest{Pages: make(map[string]string)}
+	data, err := fs.ReadFile(out, manifestFile)
+	if err != nil {
+		return m
+	}
+	if err := json.Unmarshal(data, &m); err != nil || m.Version != version || m.Pages == nil {
+		return manifest{Pages: make(map[string]string)}
+	}
+	return m
+}
+
+// save writes the manifest to the output
+func (m manifest) save(out storage.Storage) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return out.WriteFile(manifestFile, append(data, '\n'))
+}
+
+// objectHash returns the export content hash of an object, falling back to
+// its source and signature when the hash is unknown
+func objectHash(obj model.GXObject) string {
+	if obj.Hash != "" {
+		return obj.Hash
+	}
+	sum := sha256.Sum256([]byte(obj.SourceCode + "\x00" + obj.ParmSignature))
+	return hex.EncodeToString(sum[:])
+}
+
+// pageFingerprint hashes everything a procedure page is rendered from: the
+// object itself, generator options and the neighbors it links to
+func (s *site) pageFingerprint(proc model.GXObject) string {
+	// Options that only affect how the run executes do not change the output
+	renderOpts := s.opts
+	renderOpts.Incremental, renderOpts.Jobs, renderOpts.Timestamp = false, 0, time.Time{}
+	renderOpts.PageTimeout, renderOpts.Skipped, renderOpts.Storage, renderOpts.Timings = 0, nil, nil, nil
+	// The export metadata and unknown types only show in the README, the
+	// layers in layers.md
+	renderOpts.KBInfo, renderOpts.UnknownTypes, renderOpts.Layers = model.KBInfo{}, nil, nil
+
+	parts := []string{
+		objectHash(proc),
+		fmt.Sprintf("%+v", renderOpts),
+		s.readme,
+		s.procedureNav(proc),
+		strings.Join(s.calls.Callees(proc.Path), ","),
+		strings.Join(s.calls.Callers(proc.Path), ","),
+	}
+	if replacement := resolveReplacement(proc, s.kb); replacement != nil {
+		parts = append(parts, replacement.Path)
+	}
+	parts = append(parts, callListsSection(proc, s))
+	for _, shot := range s.screenshotsOf(proc) {
+		parts = append(parts, shot.file)
+	}
+	if s.opts.Examples {
+		for _, caller := range s.calls.Callers(proc.Path) {
+			obj, _ := s.kb.ByName(caller)
+			parts = append(parts, objectHash(obj))
+		}
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])
+}
+
+// fileExists reports whether name exists in out and is a regular file
+func fileExists(out storage.Storage, name string) bool {
+	info, err := fs.Stat(out, name)
+	return err == nil && info.Mode().IsRegular()
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestManifestRoundTrip(t *testing.T) {
+	out := storage.Dir(t.TempDir())
+
+	if m := loadManifest(out); len(m.Pages) != 0 {
+		t.Fatalf("Expected empty manifest, got %+v", m)
+	}
+
+	m := manifest{Version: version, Pages: map[string]string{"users/GetUser.md": "abc"}}
+	if err := m.save(out); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	if loaded := loadManifest(out); loaded.Pages["users/GetUser.md"] != "abc" {
+		t.Errorf("Unexpected manifest after reload: %+v", loaded)
+	}
+
+	// A manifest from another generator version is ignored
+	stale := []byte(`{"version": "0.0.1", "pages": {"users/GetUser.md": "abc"}}`)
+	if err := out.WriteFile(manifestFile, stale); err != nil {
+		t.Fatal(err)
+	}
+	if loaded := loadManifest(out); len(loaded.Pages) != 0 {
+		t.Errorf("Expected stale manifest to be ignored, got %+v", loaded)
+	}
+}
+
+func TestPageFingerprint(t *testing.T) {
+	getUser := model.GXObject{Path: "GetUser", Type: "Procedure", Hash: "h1", SourceCode: "LoadUser.Call()"}
+	loadUser := model.GXObject{Path: "LoadUser", Type: "Procedure", Hash: "h2"}
+
+	fingerprint := func(objects []model.GXObject, opts Options) string {
+		s := newSite(objects, objects, "KB", storage.NewMemory(), opts, nil)
+		return s.pageFingerprint(objects[0])
+	}
+
+	base := fingerprint([]model.GXObject{getUser, loadUser}, Options{})
+	if base != fingerprint([]model.GXObject{getUser, loadUser}, Options{}) {
+		t.Error("Expected fingerprint to be stable")
+	}
+
+	changed := getUser
+	changed.Hash = "h1-changed"
+	if base == fingerprint([]model.GXObject{changed, loadUser}, Options{}) {
+		t.Error("Expected fingerprint to change with the object hash")
+	}
+	if base == fingerprint([]model.GXObject{getUser, loadUser}, Options{Examples: true}) {
+		t.Error("Expected fingerprint to change with generator options")
+	}
+	if base == fingerprint([]model.GXObject{getUser}, Options{}) {
+		t.Error("Expected fingerprint to change when a callee disappears")
+	}
+}
@@ -1,14 +1,17 @@
 package generator
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 	"sort"
 	"strings"
 	"time"
 
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/layers"
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
 )
 
@@ -32,15 +35,173 @@ func sanitizePackageName(pkg string) string {
 	return strings.TrimSpace(pkg)
 }
 
+// escapeTableCell makes text safe to place inside a Markdown table cell.
+// Inline formatting (bold, code, links) is kept, while pipes are escaped and
+// line breaks are turned into <br> so multi-line content stays in one row.
+func escapeTableCell(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "|", "\\|")
+	text = strings.TrimSpace(text)
+	return strings.ReplaceAll(text, "\n", "<br>")
+}
+
+// Output layouts for procedure pages
+const (
+	// LayoutFlat writes pages to <package>/<procedure>.md, one directory
+	// level deep (Sales/Orders → Sales-Orders/<procedure>.md)
+	LayoutFlat = "flat"
+	// LayoutNested mirrors the package hierarchy in directories
+	// (Sales.Orders → Sales/Orders/<procedure>.md)
+	LayoutNested = "nested"
+)
+
+// Options controls optional generator outputs
+type Options struct {
+	// Sort orders object listings: SortByName (default), SortByType or SortByPackage
+	Sort string
+
+	// Layout places procedure pages: LayoutFlat (default) or LayoutNested
+	Layout string
+
+	// Lang is the BCP 47 language (e.g. "pt-BR") used to format counts,
+	// percentages and dates and to translate headings and labels. Empty
+	// keeps English, plain numbers and ISO dates.
+	Lang string
+
+	// Translations overrides built-in heading and label translations, keyed
+	// by the English text (see LoadTranslations)
+	Translations map[string]string
+
+	// LocalizeValues also translates enum-like values (parameter
+	// directions, object and data type names, "Unknown") with Lang, so
+	// localized pages don't mix in English tokens. Off keeps them as
+	// GeneXus writes them.
+	LocalizeValues bool
+
+	// Locale is the BCP 47 locale (e.g. "pt-BR", "es") whose collation
+	// orders alphabetical listings. Empty uses a language-neutral order and
+	// LocaleBytes keeps plain byte order.
+	Locale string
+
+	// Cheatsheet enables cheatsheet.md and cheatsheet.pdf, grouped by
+	// CheatsheetByPackage or CheatsheetByTag. Empty disables the cheat sheet.
+	Cheatsheet string
+
+	// Examples adds call sites harvested from callers as "Example Usages"
+	Examples bool
+
+	// IncludeSource appends the procedure source in a collapsible block
+	IncludeSource bool
+
+	// Incremental skips procedure pages whose fingerprint matches the
+	// manifest of the previous run
+	Incremental bool
+
+	// Jobs is the number of procedure pages rendered concurrently; <= 1
+	// renders sequentially
+	Jobs int
+
+	// BadgesEnabled writes per-module health and coverage badges
+	BadgesEnabled bool
+
+	// Badges sets the green/yellow badge thresholds; zero values use the defaults
+	Badges BadgeThresholds
+
+	// Timestamp is the "Generated on" time; zero means now. Fixed values
+	// make output reproducible (e.g. for snapshot tests).
+	Timestamp time.Time
+
+	// PageTimeout is the longest a single procedure page may take to
+	// render; slower pages are replaced by a placeholder. 0 means no limit.
+	PageTimeout time.Duration
+
+	// FrontMatter lists the YAML front matter fields (see FrontMatterFields)
+	// prepended to every Markdown page. Empty writes no front matter.
+	FrontMatter []string
+
+	// ReturnConventions are the names of OUT parameters that carry the
+	// result of a procedure without @return (see DefaultReturnConventions).
+	// nil uses the defaults; an empty list disables the detection.
+	ReturnConventions []string
+
+	// Timings, when set, records the render time of each procedure page
+	Timings *utils.Timings
+
+	// DiagramCommand, when set, pre-renders Mermaid diagrams to SVG files
+	// under diagrams/ by running this command (e.g. "mmdc -i {input} -o
+	// {output}"), for wikis that don't render Mermaid. Without {input} and
+	// {output} the source is piped to stdin and the SVG read from stdout.
+	DiagramCommand string
+
+	// Assets is a folder of object screenshots, <ObjectName>/*.png, embedded
+	// in the pages of the matching objects; empty disables screenshots
+	Assets string
+
+	// KBInfo is the metadata of the export, shown at the top of the README
+	KBInfo model.KBInfo
+
+	// Layers, when set, classifies objects into architecture layers for
+	// layers.md
+	Layers *layers.Classifier
+
+	// Storage receives the generated files; nil writes them to the output
+	// directory
+	Storage storage.Storage
+
+	// Skipped lists objects left out during extraction, reported in
+	// summary.json together with pages skipped by PageTimeout
+	Skipped []model.SkippedObject
+
+	// UnknownTypes lists the object type GUIDs extraction did not
+	// recognize, reported in the README and summary.json
+	UnknownTypes []model.UnknownType
+}
+
+// generatedAt returns the timestamp shown in generated pages
+func (o Options) generatedAt() time.Time {
+	if o.Timestamp.IsZero() {
+		return time.Now()
+	}
+	return o.Timestamp
+}
+
 // GenerateDocs generates Markdown documentation from extracted GeneXus objects
-func GenerateDocs(objects []model.GXObject, kbName string, outputDir string) error {
+func GenerateDocs(objects []model.GXObject, kbName string, outputDir string, opts Options) error {
+	return GenerateDocsContext(context.Background(), objects, kbName, outputDir, opts)
+}
+
+// GenerateDocsContext is GenerateDocs stopping with ctx.Err() when ctx is
+// cancelled or its deadline passes. Pages written before that are kept.
+func GenerateDocsContext(ctx context.Context, objects []model.GXObject, kbName string, outputDir string, opts Options) error {
 	utils.Info("Generating Markdown documentation in: %s", outputDir)
 
-	// Create output directory if it doesn't exist
-	if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+	out := opts.Storage
+	if out == nil {
+		// Create output directory if it doesn't exist
+		if err := os.MkdirAll(outputDir, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+		out = storage.Dir(outputDir)
+	}
+
+	order, err := newCollation(opts.Locale)
+	if err != nil {
+		return err
+	}
+	format, err := newFormatter(opts.Lang)
+	if err != nil {
+		return err
+	}
+	diagrams, err := newDiagramRenderer(opts.DiagramCommand)
+	if err != nil {
+		return err
 	}
 
+	// Work on a sorted copy so every listing follows the same order
+	objects = applyDocAnnotations(objects)
+	sanitizeObjects(objects)
+	sortObjects(objects, opts.Sort, order)
+
 	// Separate Procedures from other objects
 	var procedures []model.GXObject
 	var otherObjects []model.GXObject
@@ -49,7 +210,7 @@ func GenerateDocs(objects []model.GXObject, kbName string, outputDir string) err
 	for _, obj := range objects {
 		if obj.Type == "Procedure" {
 			procedures = append(procedures, obj)
-			if obj.Documentation == nil {
+			if !obj.IsDocumented() {
 				undocumentedCount++
 				utils.Warning("Procedure '%s' has no documentation comments", obj.Name)
 			}
@@ -58,57 +219,306 @@ func GenerateDocs(objects []model.GXObject, kbName string, outputDir string) err
 		}
 	}
 
-	// Generate individual Procedure documentation files
-	for _, proc := range procedures {
-		if err := generateProcedureDoc(proc, outputDir); err != nil {
-			utils.Warning("Failed to generate docs for %s: %v", proc.Name, err)
+	// Prepare call graph and navigation shared by all pages
+	s := newSite(objects, procedures, kbName, out, opts, order)
+	if opts.Assets != "" {
+		if s.screenshots, err = loadScreenshots(opts.Assets); err != nil {
+			utils.Warning("Failed to read screenshots: %v", err)
+		}
+	}
+	s.format = format
+	s.diagrams = diagrams
+	s.out = withFrontMatter(s.out, procedures, s)
+
+	// Generate individual Procedure documentation files, skipping unchanged
+	// pages in incremental mode
+	previous := manifest{Pages: make(map[string]string)}
+	if opts.Incremental {
+		previous = loadManifest(out)
+	}
+	current := manifest{Version: version, Pages: make(map[string]string)}
+
+	// Pages are rendered concurrently; results are aggregated in procedure
+	// order so the manifest and logs stay deterministic
+	type pageResult struct {
+		page, fingerprint string
+		skipped           bool
+		err               error
+	}
+	progress := utils.NewProgress("Generating pages", len(procedures))
+	results := make([]pageResult, len(procedures))
+	utils.Parallel(len(procedures), opts.Jobs, func(i int) {
+		defer progress.Increment()
+		if ctx.Err() != nil {
+			return
+		}
+		proc := procedures[i]
+		r := pageResult{page: s.procedureFile(proc), fingerprint: s.pageFingerprint(proc)}
+		if previous.Pages[r.page] == r.fingerprint && fileExists(out, r.page) {
+			r.skipped = true
+		} else {
+			r.err = generateProcedureDoc(proc, s)
+		}
+		results[i] = r
+	})
+
+	progress.Finish()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	skipped := 0
+	skippedObjects := append([]model.SkippedObject(nil), opts.Skipped...)
+	var failures []PageFailure
+	for i, r := range results {
+		if errors.Is(r.err, ErrPageTimeout) {
+			utils.Warning("Skipped %s: %v", procedures[i].Path, r.err)
+			skippedObjects = append(skippedObjects, model.SkippedObject{Name: procedures[i].Path, Reason: r.err.Error()})
+			continue
+		}
+		if r.err != nil {
+			utils.Error("Failed to generate %s (%s): %v", r.page, procedures[i].Path, r.err)
+			failures = append(failures, PageFailure{Object: procedures[i].Path, Page: r.page, Error: r.err.Error()})
+			continue
+		}
+		if r.skipped {
+			skipped++
+			utils.Verbose("Unchanged: %s", r.page)
+		} else {
+			utils.Verbose("Wrote %s", r.page)
 		}
+		current.Pages[r.page] = r.fingerprint
+	}
+
+	if opts.Incremental {
+		utils.Info("Incremental: %d unchanged page(s) skipped, %d regenerated", skipped, len(procedures)-skipped)
+	}
+	if err := current.save(out); err != nil {
+		utils.Warning("Failed to write manifest: %v", err)
 	}
 
 	// Generate package index files
-	if err := generatePackageIndexes(procedures, outputDir); err != nil {
+	if err := generatePackageIndexes(s); err != nil {
 		utils.Warning("Failed to generate package indexes: %v", err)
 	}
 
 	// Generate main README file with KB name
-	readmeFilename := "README.md"
-	if kbName != "" {
-		readmeFilename = kbName + ".md"
-	}
-	readmePath := filepath.Join(outputDir, readmeFilename)
-	if err := generateReadme(objects, procedures, kbName, readmePath); err != nil {
+	if err := generateReadme(objects, procedures, kbName, s); err != nil {
 		return fmt.Errorf("failed to generate README.md: %w", err)
 	}
 
+	// Copy the screenshots embedded in object pages
+	if len(s.screenshots) > 0 {
+		if err := copyScreenshots(objects, s); err != nil {
+			utils.Warning("Failed to copy screenshots: %v", err)
+		}
+	}
+
+	// Generate KB-wide dependency page
+	if err := generateDependencyPage(procedures, s); err != nil {
+		utils.Warning("Failed to generate dependency page: %v", err)
+	}
+
+	// Generate architecture layer overview
+	if opts.Layers != nil {
+		if err := generateLayersPage(objects, s); err != nil {
+			utils.Warning("Failed to generate layers page: %v", err)
+		}
+	}
+
+	// Generate domain value page
+	if len(s.domains) > 0 {
+		if err := generateDomainPage(s.domains, s); err != nil {
+			utils.Warning("Failed to generate domain values page: %v", err)
+		}
+	}
+
+	// Generate the public API entry point
+	if len(s.api) > 0 {
+		if err := generateAPIPage(s.api, s); err != nil {
+			utils.Warning("Failed to generate public API page: %v", err)
+		}
+	}
+
+	// Generate data model and attribute reference for Transactions
+	if len(s.transactions) > 0 {
+		if err := generateDataModelPage(s.transactions, s); err != nil {
+			utils.Warning("Failed to generate data model page: %v", err)
+		}
+		if err := generateAttributesPage(collectAttributes(s.transactions, s.order), s); err != nil {
+			utils.Warning("Failed to generate attributes page: %v", err)
+		}
+	}
+
+	// Generate migration guide for deprecated procedures
+	if len(s.migrations) > 0 {
+		if err := generateMigrationGuide(s.migrations, s); err != nil {
+			utils.Warning("Failed to generate migration guide: %v", err)
+		}
+	}
+
+	// Generate printable cheat sheet
+	if opts.Cheatsheet != "" {
+		if err := generateCheatsheet(procedures, kbName, opts.Cheatsheet, s); err != nil {
+			utils.Warning("Failed to generate cheat sheet: %v", err)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	// Generate coverage report
+	coverage := computeCoverage(objects, s.order)
+	if err := writeCoverageReports(coverage, s); err != nil {
+		utils.Warning("Failed to generate coverage report: %v", err)
+	}
+
+	// Generate the "What's New" changelog
+	if err := writeChangelog(objects, s); err != nil {
+		utils.Warning("Failed to generate changelog: %v", err)
+	}
+
+	// Generate annotation usage report
+	if err := writeAnnotationReports(computeAnnotationUsage(procedures, s.order), s); err != nil {
+		utils.Warning("Failed to generate annotation usage report: %v", err)
+	}
+
+	// Generate health scorecard
+	health := computeHealth(objects, s.order)
+	if err := writeHealthPage(health, s); err != nil {
+		utils.Warning("Failed to generate health page: %v", err)
+	}
+
+	// Generate per-module badges
+	if opts.BadgesEnabled {
+		if err := writeBadges(health, coverage, s); err != nil {
+			utils.Warning("Failed to generate badges: %v", err)
+		}
+	}
+
 	utils.Success("Documentation generated successfully at: %s", outputDir)
 	if len(procedures) > 0 {
 		utils.Info("Generated %d Procedure documentation file(s)", len(procedures))
 		if undocumentedCount > 0 {
 			utils.Warning("%d procedure(s) are missing /** */ documentation comments", undocumentedCount)
 		}
+		logCoverage(coverage)
+		utils.Info("KB health score: %.1f/100", health.Score)
+	}
+
+	// Run summary goes last so it counts every warning of the run
+	warnings, errs := utils.Counts()
+	summary := Summary{
+		KB:         kbName,
+		Version:    version,
+		Objects:    len(objects),
+		Procedures: coverage.Total,
+		Documented: coverage.Documented,
+		Coverage:   coverage.Percent,
+		Warnings:   warnings,
+		Errors:     errs,
+		Skipped:    skippedObjects,
+		Failures:   failures,
+		Health:     health,
+
+		UnknownTypes: s.opts.UnknownTypes,
+	}
+	if err := writeSummary(summary, s); err != nil {
+		utils.Warning("Failed to write summary.json: %v", err)
 	}
 	return nil
 }
 
-// generateReadme creates a README.md file listing all extracted objects
-func generateReadme(objects []model.GXObject, procedures []model.GXObject, kbName string, outputPath string) error {
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return err
+// applyDocAnnotations returns a copy of objects without those marked
+// @nodoc, filing objects marked @docgroup under that group
+func applyDocAnnotations(objects []model.GXObject) []model.GXObject {
+	result := make([]model.GXObject, 0, len(objects))
+	for _, obj := range objects {
+		doc := obj.Documentation
+		if doc != nil && doc.NoDoc {
+			utils.Verbose("Excluded %s (@nodoc)", obj.Path)
+			continue
+		}
+		if doc != nil && doc.DocGroup != "" && doc.Package != doc.DocGroup {
+			grouped := *doc
+			grouped.Package = doc.DocGroup
+			obj.Documentation = &grouped
+		}
+		result = append(result, obj)
+	}
+	return result
+}
+
+// kbInfo renders the "Knowledge Base Info" section describing the export
+// the documentation was generated from. Unknown values are left out.
+func (s *site) kbInfo(kbName string) string {
+	info := s.opts.KBInfo
+	if info.IsZero() {
+		return ""
 	}
-	defer file.Close()
 
+	var sb strings.Builder
+	sb.WriteString("## " + s.text.T("Knowledge Base Info") + "\n\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s |\n", s.text.T("Property"), s.text.T("Value")))
+	sb.WriteString("|----------|-------|\n")
+	row := func(label, value string) {
+		if value != "" {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", s.text.T(label), escapeTableCell(value)))
+		}
+	}
+	row("Knowledge Base", kbName)
+	row("GeneXus Version", info.GeneXusVersion)
+	if !info.ExportDate.IsZero() {
+		row("Export Date", s.format.DateTime(info.ExportDate))
+	}
+	row("Environment", info.Environment)
+	row("Modules", strings.Join(info.Modules, ", "))
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// unknownTypesSection renders the "Unknown Types" README section listing
+// the object type GUIDs left out of the documentation, so users can map
+// them or report them
+func (s *site) unknownTypesSection() string {
+	if len(s.opts.UnknownTypes) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + s.text.T("Unknown Types") + "\n\n")
+	sb.WriteString(s.text.T("Objects of these types were not documented. Map a GUID to a type name under `objectTypes` in the config file, and please report new GUIDs so they can be supported.") + "\n\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.text.T("Type GUID"), s.text.T("Objects"), s.text.T("Examples")))
+	sb.WriteString("|-----------|---------|----------|\n")
+	for _, t := range s.opts.UnknownTypes {
+		samples := make([]string, len(t.Samples))
+		for i, name := range t.Samples {
+			samples[i] = "`" + name + "`"
+		}
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", t.GUID, s.format.Int(t.Count), escapeTableCell(strings.Join(samples, ", "))))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// generateReadme creates a README.md file listing all extracted objects
+func generateReadme(objects []model.GXObject, procedures []model.GXObject, kbName string, s *site) error {
 	// Build markdown content
 	var sb strings.Builder
 
 	// Header
 	if kbName != "" {
-		sb.WriteString("# " + kbName + " Documentation\n\n")
+		sb.WriteString("# " + fmt.Sprintf(s.text.T("%s Documentation"), kbName) + "\n\n")
 	} else {
-		sb.WriteString("# GeneXus Documentation\n\n")
+		sb.WriteString("# " + s.text.T("GeneXus Documentation") + "\n\n")
 	}
-	sb.WriteString(fmt.Sprintf("Generated on: %s\n\n", time.Now().Format("2006-01-02 15:04:05")))
-	sb.WriteString(fmt.Sprintf("Total Objects: **%d**\n\n", len(objects)))
+	sb.WriteString(fmt.Sprintf("%s: %s\n\n", s.text.T("Generated on"), s.format.DateTime(s.opts.generatedAt())))
+	sb.WriteString(fmt.Sprintf("%s: **%s**\n\n", s.text.T("Total Objects"), s.format.Int(len(objects))))
+	sb.WriteString(s.kbInfo(kbName))
+
+	// Table of contents
+	sb.WriteString(s.tableOfContents())
 
 	// Statistics by type
 	typeCount := make(map[string]int)
@@ -121,44 +531,44 @@ func generateReadme(objects []model.GXObject, procedures []model.GXObject, kbNam
 	}
 
 	if len(typeCount) > 0 {
-		sb.WriteString("## Object Statistics\n\n")
-		sb.WriteString("| Type | Count |\n")
+		sb.WriteString("## " + s.text.T("Object Statistics") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", s.text.T("Type"), s.text.T("Count")))
 		sb.WriteString("|------|-------|\n")
-		for objType, count := range typeCount {
-			sb.WriteString(fmt.Sprintf("| %s | %d |\n", objType, count))
+		for _, objType := range sortedKeys(typeCount, s.order) {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", s.label(objType), s.format.Int(typeCount[objType])))
 		}
 		sb.WriteString("\n")
 	}
+	sb.WriteString(s.unknownTypesSection())
 
 	// List packages if we have documented procedures
 	if len(procedures) > 0 {
 		packageMap := make(map[string]int)
 		for _, proc := range procedures {
 			if proc.Documentation != nil {
-				pkg := sanitizePackageName(proc.Documentation.Package)
-				packageMap[pkg]++
+				packageMap[s.procedurePackage(proc)]++
 			}
 		}
 
 		if len(packageMap) > 0 {
-			sb.WriteString("## Packages\n\n")
-			sb.WriteString("| Package | Procedures |\n")
+			sb.WriteString("## " + s.text.T("Packages") + "\n\n")
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", s.text.T("Package"), s.text.T("Procedures")))
 			sb.WriteString("|---------|------------|\n")
-			for pkg, count := range packageMap {
-				link := fmt.Sprintf("[%s](./%s.md)", pkg, pkg)
-				sb.WriteString(fmt.Sprintf("| %s | %d |\n", link, count))
+			for _, pkg := range sortedKeys(packageMap, s.order) {
+				link := fmt.Sprintf("[%s](./%s)", pkg, s.packageFile(pkg))
+				sb.WriteString(fmt.Sprintf("| %s | %s |\n", link, s.format.Int(packageMap[pkg])))
 			}
 			sb.WriteString("\n")
 		}
 	}
 
 	// List all objects
-	sb.WriteString("## Extracted Objects\n\n")
+	sb.WriteString("## " + s.text.T("Extracted Objects") + "\n\n")
 
 	if len(objects) == 0 {
-		sb.WriteString("*No objects found in the XPZ file.*\n")
+		sb.WriteString("*" + s.text.T("No objects found in the XPZ file.") + "*\n")
 	} else {
-		sb.WriteString("| Name | Type | Path |\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", s.text.T("Name"), s.text.T("Type"), s.text.T("Path")))
 		sb.WriteString("|------|------|------|\n")
 
 		for _, obj := range objects {
@@ -175,46 +585,37 @@ func generateReadme(objects []model.GXObject, procedures []model.GXObject, kbNam
 				path = "-"
 			}
 
-			sb.WriteString(fmt.Sprintf("| %s | %s | `%s` |\n", name, objType, path))
+			sb.WriteString(fmt.Sprintf("| %s | %s | `%s` |\n", escapeTableCell(name), s.label(objType), path))
 		}
 	}
 
-	sb.WriteString("\n---\n")
-	sb.WriteString(fmt.Sprintf("Generated by GXDocGen v%s\n", version))
+	sb.WriteString("\n---")
+	sb.WriteString(s.footer())
 
-	// Write to file
-	_, err = file.WriteString(sb.String())
-	return err
+	return s.out.WriteFile(s.readme, []byte(sb.String()))
 }
 
 // generateProcedureDoc generates a Markdown file for a single Procedure
-func generateProcedureDoc(proc model.GXObject, outputDir string) error {
-	doc := proc.Documentation
-
-	// Determine package for folder organization
-	packageName := "root"
-	if doc != nil && doc.Package != "" {
-		packageName = sanitizePackageName(doc.Package)
-	}
-
-	// Create package directory (except for root)
-	var procedureDir string
-	if packageName != "root" {
-		procedureDir = filepath.Join(outputDir, packageName)
-		if err := os.MkdirAll(procedureDir, os.ModePerm); err != nil {
-			return fmt.Errorf("failed to create package directory: %w", err)
-		}
-	} else {
-		procedureDir = outputDir
-	}
-
-	// Create filename from procedure name
-	filename := filepath.Join(procedureDir, proc.Path+".md")
-	file, err := os.Create(filename)
-	if err != nil {
+func generateProcedureDoc(proc model.GXObject, s *site) error {
+	// Render within the page time limit, writing a placeholder when the
+	// page times out or fails
+	started := time.Now()
+	page, renderErr := s.renderProcedure(proc)
+	s.opts.Timings.AddObject(sourcePath(proc), "render", time.Since(started))
+	if renderErr != nil {
+		page = placeholderPage(proc, renderErr, s)
+	}
+
+	// Pages of packaged procedures go to the package folder
+	if err := s.out.WriteFile(s.procedureFile(proc), []byte(page)); err != nil {
 		return err
 	}
-	defer file.Close()
+	return renderErr
+}
+
+// renderProcedurePage renders the Markdown page of a procedure
+func renderProcedurePage(proc model.GXObject, s *site) string {
+	doc := proc.Documentation
 
 	var sb strings.Builder
 
@@ -227,18 +628,14 @@ func generateProcedureDoc(proc model.GXObject, outputDir string) error {
 
 	// Package badge
 	if doc != nil && doc.Package != "" {
-		pkgName := sanitizePackageName(doc.Package)
-		// Link to package index in parent directory if we're in a package folder
-		if pkgName != "root" {
-			sb.WriteString("**Package:** [`" + doc.Package + "`](../" + pkgName + ".md)\n\n")
-		} else {
-			sb.WriteString("**Package:** [`" + doc.Package + "`](./" + pkgName + ".md)\n\n")
-		}
+		// Link to the package index, relative to the procedure's folder
+		pkgName := s.procedurePackage(proc)
+		sb.WriteString("**" + s.text.T("Package") + ":** [`" + doc.Package + "`](" + s.rootPrefix(proc) + s.packageFile(pkgName) + ")\n\n")
 	}
 
 	// Function signature
 	if proc.ParmSignature != "" {
-		sb.WriteString("## Signature\n\n")
+		sb.WriteString("## " + s.text.T("Signature") + "\n\n")
 		sb.WriteString("```genexus\n")
 		sb.WriteString(proc.ParmSignature + "\n")
 		sb.WriteString("```\n\n")
@@ -246,10 +643,13 @@ func generateProcedureDoc(proc model.GXObject, outputDir string) error {
 
 	// Deprecation warning
 	if doc != nil && doc.Deprecated {
-		sb.WriteString("⚠️ **DEPRECATED**")
+		sb.WriteString("⚠️ **" + s.text.T("DEPRECATED") + "**")
 		if doc.DeprecationNote != "" {
 			sb.WriteString(": " + doc.DeprecationNote)
 		}
+		if replacement := resolveReplacement(proc, s.kb); replacement != nil {
+			sb.WriteString(" (" + fmt.Sprintf(s.text.T("see the [migration guide](%s)"), s.rootPrefix(proc)+"migration.md") + ")")
+		}
 		sb.WriteString("\n\n")
 	}
 
@@ -262,14 +662,23 @@ func generateProcedureDoc(proc model.GXObject, outputDir string) error {
 	}
 
 	if description != "" {
-		sb.WriteString("## Description\n\n")
+		sb.WriteString("## " + s.text.T("Description") + "\n\n")
 		sb.WriteString(description + "\n\n")
 	}
 
+	// Screenshots from the assets folder
+	sb.WriteString(s.screenshotsSection(proc, s.procedureFile(proc), "##"))
+
+	// Machine-generated flow summary for undocumented procedures
+	if !proc.IsDocumented() {
+		sb.WriteString(flowSummarySection(proc, s))
+	}
+
 	// Parameters
 	if doc != nil && len(doc.Parameters) > 0 {
-		sb.WriteString("## Parameters\n\n")
-		sb.WriteString("| Name | Direction | Type | Description |\n")
+		sb.WriteString("## " + s.text.T("Parameters") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			s.text.T("Name"), s.text.T("Direction"), s.text.T("Type"), s.text.T("Description")))
 		sb.WriteString("|------|-----------|------|-------------|\n")
 
 		for _, param := range doc.Parameters {
@@ -291,59 +700,89 @@ func generateProcedureDoc(proc model.GXObject, outputDir string) error {
 			}
 
 			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
-				name, direction, paramType, desc))
+				escapeTableCell(name), s.label(direction), escapeTableCell(s.dataType(paramType)), escapeTableCell(desc)))
 		}
 		sb.WriteString("\n")
 	}
 
-	// Return type
-	if doc != nil && doc.Return != "" {
-		sb.WriteString("## Return\n\n")
-		sb.WriteString(doc.Return + "\n\n")
+	// Return value, documented or following a return convention
+	sb.WriteString(returnSection(proc, s))
+
+	// Variables declared besides the parameters
+	sb.WriteString(localVariablesSection(proc, s))
+
+	// Enumerated domain values used by the procedure
+	sb.WriteString(domainValuesSection(proc, s))
+
+	// Examples written by the author
+	if doc != nil && len(doc.Examples) > 0 {
+		sb.WriteString("## " + s.text.T("Examples") + "\n\n")
+		for _, example := range doc.Examples {
+			fence := codeFence(example)
+			sb.WriteString(fence + "genexus\n" + example + "\n" + fence + "\n\n")
+		}
+	}
+
+	// Example usages harvested from callers
+	if s.opts.Examples {
+		sb.WriteString(exampleUsages(proc.Path, s))
+	}
+
+	// Call graph
+	if diagram := mermaidCallGraph(proc.Path, s.calls); diagram != "" {
+		sb.WriteString("## " + s.text.T("Call Graph") + "\n\n")
+		sb.WriteString(s.diagram(diagram, s.text.T("Call Graph"), s.procedureFile(proc)) + "\n")
+		sb.WriteString(callListsSection(proc, s))
+	}
+
+	// Custom fields from registered tags
+	if doc != nil && len(doc.CustomFields) > 0 {
+		sb.WriteString("## " + s.text.T("Custom Fields") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", s.text.T("Field"), s.text.T("Value")))
+		sb.WriteString("|-------|-------|\n")
+		for _, field := range doc.CustomFields {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", escapeTableCell(field.Label), escapeTableCell(field.Value)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Source code, collapsed by default
+	if s.opts.IncludeSource {
+		sb.WriteString(sourceSection(proc, s))
 	}
 
 	// Metadata footer
 	sb.WriteString("---\n\n")
 	if doc != nil && !doc.IsAutoGenerated {
 		if doc.Author != "" {
-			sb.WriteString("**Author:** " + doc.Author + "  \n")
+			sb.WriteString("**" + s.text.T("Author") + ":** " + s.authorLabel(doc.Author) + "  \n")
 		}
 		if doc.Created != "" {
-			sb.WriteString("**Created:** " + doc.Created + "  \n")
+			sb.WriteString("**" + s.text.T("Created") + ":** " + s.format.DocDate(doc.Created) + "  \n")
+		}
+		if doc.Since != "" {
+			sb.WriteString("**" + s.text.T("Since") + ":** " + doc.Since + "  \n")
 		}
 	} else if doc != nil && doc.IsAutoGenerated {
 		// Show author even for auto-generated docs
 		if doc.Author != "" {
-			sb.WriteString("**Author:** " + doc.Author + "  \n")
+			sb.WriteString("**" + s.text.T("Author") + ":** " + s.authorLabel(doc.Author) + "  \n")
 		}
 		// Indicate auto-generated documentation
-		sb.WriteString("\n*⚠️ Auto-generated from XML metadata. Add `/** */` annotations for detailed documentation.*\n")
+		sb.WriteString("\n*⚠️ " + s.text.T("Auto-generated from XML metadata. Add `/** */` annotations for detailed documentation.") + "*\n")
 	}
 
-	sb.WriteString(fmt.Sprintf("\nGenerated by GXDocGen v%s\n", version))
+	sb.WriteString("\n" + s.procedureNav(proc))
+	sb.WriteString(s.footer())
 
-	// Write to file
-	_, err = file.WriteString(sb.String())
-	return err
+	return sb.String()
 }
 
 // generatePackageIndexes creates package-level index files
-func generatePackageIndexes(procedures []model.GXObject, outputDir string) error {
-	// Group procedures by package
-	packageMap := make(map[string][]model.GXObject)
-	
-	for _, proc := range procedures {
-		pkg := "root"
-		if proc.Documentation != nil && proc.Documentation.Package != "" {
-			pkg = sanitizePackageName(proc.Documentation.Package)
-		}
-		packageMap[pkg] = append(packageMap[pkg], proc)
-	}
-
+func generatePackageIndexes(s *site) error {
 	// Generate index file for each package
-	for pkg, procs := range packageMap {
-		filename := filepath.Join(outputDir, pkg+".md")
-		if err := generatePackageIndex(pkg, procs, filename); err != nil {
+	for _, pkg := range s.packages {
+		if err := generatePackageIndex(pkg, s.byPackage[pkg], s); err != nil {
 			return err
 		}
 	}
@@ -352,17 +791,11 @@ func generatePackageIndexes(procedures []model.GXObject, outputDir string) error
 }
 
 // generatePackageIndex creates an index file for a package
-func generatePackageIndex(packageName string, procedures []model.GXObject, outputPath string) error {
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
+func generatePackageIndex(packageName string, procedures []model.GXObject, s *site) error {
 	var sb strings.Builder
 
 	// Title
-	sb.WriteString("# Package: " + packageName + "\n\n")
+	sb.WriteString("# " + fmt.Sprintf(s.text.T("Package: %s"), packageName) + "\n\n")
 
 	// Group procedures by type
 	typeMap := make(map[string][]model.GXObject)
@@ -379,19 +812,19 @@ func generatePackageIndex(packageName string, procedures []model.GXObject, outpu
 	for t := range typeMap {
 		types = append(types, t)
 	}
-	sort.Strings(types)
+	s.order.sortStrings(types)
 
 	// Generate section for each type
 	for _, objType := range types {
 		procs := typeMap[objType]
-		
+
 		// Sort procedures alphabetically by name
-		sort.Slice(procs, func(i, j int) bool {
-			return procs[i].Path < procs[j].Path
+		sort.SliceStable(procs, func(i, j int) bool {
+			return s.order.less(procs[i].Path, procs[j].Path)
 		})
 
-		sb.WriteString("## " + objType + "s\n\n")
-		sb.WriteString("| Name | Summary |\n")
+		sb.WriteString("## " + s.text.T(objType+"s") + "\n\n")
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", s.text.T("Name"), s.text.T("Summary")))
 		sb.WriteString("|------|----------|\n")
 
 		for _, proc := range procs {
@@ -402,22 +835,16 @@ func generatePackageIndex(packageName string, procedures []model.GXObject, outpu
 			}
 
 			// Link to procedure file - in package folder for non-root, in current dir for root
-			var link string
-			if packageName != "root" {
-				link = fmt.Sprintf("[%s](./%s/%s.md)", name, packageName, proc.Path)
-			} else {
-				link = fmt.Sprintf("[%s](./%s.md)", name, proc.Path)
-			}
+			link := fmt.Sprintf("[%s](%s%s)", name, pathToRoot(s.packageFile(packageName)), s.procedureFile(proc))
 
-			sb.WriteString(fmt.Sprintf("| %s | %s |\n", link, summary))
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", link, escapeTableCell(summary)))
 		}
 		sb.WriteString("\n")
 	}
 
 	sb.WriteString("\n---\n")
-	sb.WriteString(fmt.Sprintf("Generated by GXDocGen v%s\n", version))
+	sb.WriteString(s.packageNav(packageName))
+	sb.WriteString(s.footer())
 
-	// Write to file
-	_, err = file.WriteString(sb.String())
-	return err
+	return s.out.WriteFile(s.packageFile(packageName), []byte(sb.String()))
 }
@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestGenerateDocsContextCancelled(t *testing.T) {
+	dir := t.TempDir()
+	objects := []model.GXObject{{Name: "GetUser", Path: "GetUser", Type: "Procedure"}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := GenerateDocsContext(ctx, objects, "KB", dir, Options{}); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "GetUser.md")); !os.IsNotExist(err) {
+		t.Errorf("Expected no pages after cancellation, got %v", err)
+	}
+}
+
+func TestGenerateDocsIsolatesRenderPanics(t *testing.T) {
+	renderPage = func(proc model.GXObject, s *site) string {
+		if proc.Path == "Broken" {
+			panic("boom")
+		}
+		return renderProcedurePage(proc, s)
+	}
+	defer func() { renderPage = renderProcedurePage }()
+
+	dir := t.TempDir()
+	objects := []model.GXObject{
+		{Name: "Broken", Path: "Broken", Type: "Procedure"},
+		{Name: "GetUser", Path: "GetUser", Type: "Procedure"},
+	}
+	if err := GenerateDocs(objects, "KB", dir, Options{}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	broken, err := os.ReadFile(filepath.Join(dir, "Broken.md"))
+	if err != nil || !strings.Contains(string(broken), "rendering failed") {
+		t.Errorf("Expected an error placeholder for Broken, got %q (%v)", broken, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "GetUser.md")); err != nil {
+		t.Errorf("Expected GetUser.md to be generated: %v", err)
+	}
+
+	summary, err := os.ReadFile(filepath.Join(dir, "summary.json"))
+	if err != nil || !strings.Contains(string(summary), `"object": "Broken"`) {
+		t.Errorf("Expected Broken in summary.json failures, got %s", summary)
+	}
+}
+
+func TestGenerateDocsDocAnnotations(t *testing.T) {
+	dir := t.TempDir()
+	objects := []model.GXObject{
+		{Name: "Hidden", Path: "Hidden", Type: "Procedure", Documentation: &model.DocComment{NoDoc: true}},
+		{Name: "Grouped", Path: "Grouped", Type: "Procedure", Documentation: &model.DocComment{Package: "misc", DocGroup: "Billing"}},
+	}
+	if err := GenerateDocs(objects, "KB", dir, Options{}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "Billing", "Grouped.md")); err != nil {
+		t.Errorf("Expected Grouped to be filed under its @docgroup: %v", err)
+	}
+	readme, _ := os.ReadFile(filepath.Join(dir, "KB.md"))
+	if strings.Contains(string(readme), "Hidden") {
+		t.Errorf("Expected @nodoc objects to be left out of the index:\n%s", readme)
+	}
+	if objects[1].Documentation.Package != "misc" {
+		t.Error("Expected the input objects to be left unchanged")
+	}
+}
+
+func TestGenerateDocsKBInfo(t *testing.T) {
+	objects := []model.GXObject{{Name: "GetUser", Path: "GetUser", Type: "Procedure"}}
+	info := model.KBInfo{
+		GeneXusVersion: "4.0.169212",
+		ExportDate:     time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC),
+		Environment:    "Java Web",
+		Modules:        []string{"Sales", "Users"},
+	}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out, KBInfo: info}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	readme, _ := fs.ReadFile(out, "KB.md")
+	content := string(readme)
+	for _, want := range []string{"## Knowledge Base Info", "| Knowledge Base | KB |", "| GeneXus Version | 4.0.169212 |",
+		"| Export Date | 2024-03-15 10:30:00 |", "| Environment | Java Web |", "| Modules | Sales, Users |"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in the README:\n%s", want, content)
+		}
+	}
+	if strings.Index(content, "Knowledge Base Info") > strings.Index(content, "Table of Contents") {
+		t.Error("Expected the KB info before the table of contents")
+	}
+
+	out = storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+	if readme, _ := fs.ReadFile(out, "KB.md"); strings.Contains(string(readme), "Knowledge Base Info") {
+		t.Error("Expected no KB info section without metadata")
+	}
+}
+
+func TestGenerateDocsUnknownTypes(t *testing.T) {
+	objects := []model.GXObject{{Name: "GetUser", Path: "GetUser", Type: "Procedure"}}
+	unknown := []model.UnknownType{{GUID: "0a1b2c3d-0000-4000-8000-000000000001", Count: 12, Samples: []string{"WpHome", "WpUsers"}}}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out, UnknownTypes: unknown}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	readme, _ := fs.ReadFile(out, "KB.md")
+	content := string(readme)
+	for _, want := range []string{"- [Unknown Types](#unknown-types)", "## Unknown Types",
+		"| `0a1b2c3d-0000-4000-8000-000000000001` | 12 | `WpHome`, `WpUsers` |"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in the README:\n%s", want, content)
+		}
+	}
+	if summary, _ := fs.ReadFile(out, "summary.json"); !strings.Contains(string(summary), `"guid": "0a1b2c3d-0000-4000-8000-000000000001"`) {
+		t.Errorf("Expected the unknown type in summary.json:\n%s", summary)
+	}
+}
+
+func TestGenerateDocsNestedLayout(t *testing.T) {
+	objects := []model.GXObject{
+		{Name: "CreateOrder", Path: "CreateOrder", Type: "Procedure", Documentation: &model.DocComment{Package: "Sales.Orders"}},
+		{Name: "Quote", Path: "Quote", Type: "Procedure", SourceCode: "CreateOrder.Call()", Documentation: &model.DocComment{Package: "Sales"}},
+	}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out, Layout: LayoutNested}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	for _, name := range []string{"Sales/Orders/CreateOrder.md", "Sales/Orders.md", "Sales/Quote.md", "Sales.md"} {
+		if _, err := fs.Stat(out, name); err != nil {
+			t.Errorf("Expected %s: %v", name, err)
+		}
+	}
+
+	page, _ := fs.ReadFile(out, "Sales/Orders/CreateOrder.md")
+	for _, want := range []string{"(../../Sales/Orders.md)", "(../../KB.md)", "[Quote](../../Sales/Quote.md)"} {
+		if !strings.Contains(string(page), want) {
+			t.Errorf("Expected link %s in CreateOrder.md:\n%s", want, page)
+		}
+	}
+	index, _ := fs.ReadFile(out, "Sales/Orders.md")
+	if !strings.Contains(string(index), "[CreateOrder](../Sales/Orders/CreateOrder.md)") {
+		t.Errorf("Expected the package index to link into its folder:\n%s", index)
+	}
+}
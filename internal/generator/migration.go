@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/parser"
+)
+
+// migration pairs a deprecated procedure with its replacement
+type migration struct {
+	Old         model.GXObject
+	Replacement *model.GXObject
+}
+
+// resolveReplacement finds the replacement of a deprecated procedure, first
+// from "@deprecated Use X", then from the first @see naming a known object
+func resolveReplacement(proc model.GXObject, kb *model.KnowledgeBase) *model.GXObject {
+	doc := proc.Documentation
+	if doc == nil || !doc.Deprecated {
+		return nil
+	}
+
+	candidates := []string{parser.ParseReplacement(doc.DeprecationNote)}
+	candidates = append(candidates, doc.See...)
+	for _, name := range candidates {
+		name = strings.TrimSpace(name)
+		if replacement, exists := kb.ByName(name); exists && name != proc.Path {
+			return &replacement
+		}
+	}
+	return nil
+}
+
+// collectMigrations lists deprecated procedures with their resolved replacement
+func collectMigrations(procedures []model.GXObject, kb *model.KnowledgeBase) []migration {
+	var migrations []migration
+	for _, proc := range procedures {
+		if proc.Documentation != nil && proc.Documentation.Deprecated {
+			migrations = append(migrations, migration{Old: proc, Replacement: resolveReplacement(proc, kb)})
+		}
+	}
+	return migrations
+}
+
+// parameterLabel renders "Name (DIRECTION Type)" for the mapping table
+func parameterLabel(p model.ParameterDoc) string {
+	details := strings.TrimSpace(p.Direction + " " + p.Type)
+	if details == "" {
+		return "`" + p.Name + "`"
+	}
+	return "`" + p.Name + "` (" + details + ")"
+}
+
+// parameterMapping renders the old → new parameter hints. Explicit @maps
+// entries on the replacement take precedence; other parameters are matched by name.
+func parameterMapping(oldParams, newParams []model.ParameterDoc, maps []model.ParamMapping) string {
+	newByName := make(map[string]model.ParameterDoc)
+	for _, p := range newParams {
+		newByName[p.Name] = p
+	}
+	explicit := make(map[string]model.ParamMapping)
+	for _, m := range maps {
+		explicit[m.Old] = m
+	}
+
+	var sb strings.Builder
+	sb.WriteString("| Old Parameter | New Parameter | Notes |\n")
+	sb.WriteString("|---------------|---------------|-------|\n")
+
+	matched := make(map[string]bool)
+	for _, oldParam := range oldParams {
+		var notes []string
+		targetName := oldParam.Name
+		m, mapped := explicit[oldParam.Name]
+		if mapped {
+			targetName = m.New
+			if m.New == "" {
+				notes = append(notes, "Dropped")
+			} else {
+				notes = append(notes, "Mapped via @maps")
+			}
+			if m.Note != "" {
+				notes = append(notes, m.Note)
+			}
+		}
+
+		newParam, exists := newByName[targetName]
+		if targetName == "" || !exists {
+			if len(notes) == 0 {
+				notes = append(notes, "No equivalent parameter")
+			}
+			newLabel := "-"
+			if mapped && targetName != "" {
+				newLabel = "`" + targetName + "`"
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", parameterLabel(oldParam), newLabel, escapeTableCell(strings.Join(notes, "; "))))
+			continue
+		}
+		matched[newParam.Name] = true
+
+		if oldParam.Direction != newParam.Direction {
+			notes = append(notes, "direction changed")
+		}
+		if oldParam.Type != newParam.Type {
+			notes = append(notes, "type changed")
+		}
+		if len(notes) == 0 {
+			notes = append(notes, "Same parameter")
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", parameterLabel(oldParam), parameterLabel(newParam), escapeTableCell(strings.Join(notes, "; "))))
+	}
+	for _, newParam := range newParams {
+		if !matched[newParam.Name] {
+			sb.WriteString(fmt.Sprintf("| - | %s | New parameter |\n", parameterLabel(newParam)))
+		}
+	}
+
+	return sb.String()
+}
+
+// generateMigrationGuide writes migration.md, aggregating a migration table
+// for every deprecated procedure
+func generateMigrationGuide(migrations []migration, s *site) error {
+	var sb strings.Builder
+	sb.WriteString("# " + s.text.T("Migration Guide") + "\n\n")
+	sb.WriteString("Deprecated procedures and what to use instead.\n\n")
+
+	sb.WriteString("| Deprecated | Replacement | Note |\n")
+	sb.WriteString("|------------|-------------|------|\n")
+	for _, m := range migrations {
+		replacement := "-"
+		if m.Replacement != nil {
+			replacement = fmt.Sprintf("[%s](./%s)", m.Replacement.Path, s.procedureFile(*m.Replacement))
+		}
+		note := m.Old.Documentation.DeprecationNote
+		if note == "" {
+			note = "-"
+		}
+		sb.WriteString(fmt.Sprintf("| [%s](./%s) | %s | %s |\n",
+			m.Old.Path, s.procedureFile(m.Old), replacement, escapeTableCell(note)))
+	}
+	sb.WriteString("\n")
+
+	for _, m := range migrations {
+		if m.Replacement == nil {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("## %s → %s\n\n", m.Old.Path, m.Replacement.Path))
+		if note := m.Old.Documentation.DeprecationNote; note != "" {
+			sb.WriteString("> " + oneLine(note) + "\n\n")
+		}
+		sb.WriteString("```genexus\n")
+		sb.WriteString("// before\n" + oneLine(m.Old.ParmSignature) + "\n")
+		sb.WriteString("// after\n" + oneLine(m.Replacement.ParmSignature) + "\n")
+		sb.WriteString("```\n\n")
+		var maps []model.ParamMapping
+		if m.Replacement.Documentation != nil {
+			maps = m.Replacement.Documentation.ParamMaps
+		}
+		sb.WriteString(parameterMapping(m.Old.Parameters, m.Replacement.Parameters, maps))
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(s.indexNav())
+	sb.WriteString(s.footer())
+
+	return s.out.WriteFile("migration.md", []byte(sb.String()))
+}
@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestResolveReplacement(t *testing.T) {
+	kb := model.NewKnowledgeBase("KB", []model.GXObject{
+		{Name: "NewProc", Path: "NewProc", Type: "Procedure"},
+	}, nil)
+
+	tests := []struct {
+		name string
+		doc  *model.DocComment
+		want string
+	}{
+		{"deprecation note", &model.DocComment{Deprecated: true, DeprecationNote: "Use NewProc instead."}, "NewProc"},
+		{"see tag", &model.DocComment{Deprecated: true, See: []string{"Missing", "NewProc"}}, "NewProc"},
+		{"unknown replacement", &model.DocComment{Deprecated: true, DeprecationNote: "Use Missing"}, ""},
+		{"not deprecated", &model.DocComment{See: []string{"NewProc"}}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resolveReplacement(model.GXObject{Path: "OldProc", Documentation: tt.doc}, kb)
+			if tt.want == "" {
+				if got != nil {
+					t.Errorf("expected no replacement, got %s", got.Path)
+				}
+				return
+			}
+			if got == nil || got.Path != tt.want {
+				t.Errorf("expected %s, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParameterMapping(t *testing.T) {
+	oldParams := []model.ParameterDoc{
+		{Name: "UserID", Direction: "IN", Type: "Numeric"},
+		{Name: "Total", Direction: "OUT", Type: "Numeric"},
+		{Name: "Legacy", Direction: "IN", Type: "Character"},
+	}
+	newParams := []model.ParameterDoc{
+		{Name: "UserID", Direction: "IN", Type: "Numeric"},
+		{Name: "Total", Direction: "INOUT", Type: "Numeric"},
+		{Name: "Messages", Direction: "OUT", Type: "Messages"},
+	}
+
+	table := parameterMapping(oldParams, newParams, nil)
+	for _, want := range []string{
+		"| `UserID` (IN Numeric) | `UserID` (IN Numeric) | Same parameter |",
+		"| `Total` (OUT Numeric) | `Total` (INOUT Numeric) | direction changed |",
+		"| `Legacy` (IN Character) | - | No equivalent parameter |",
+		"| - | `Messages` (OUT Messages) | New parameter |",
+	} {
+		if !strings.Contains(table, want) {
+			t.Errorf("mapping table missing %q:\n%s", want, table)
+		}
+	}
+}
+
+func TestParameterMapping_ExplicitMaps(t *testing.T) {
+	oldParams := []model.ParameterDoc{
+		{Name: "UserID", Direction: "IN", Type: "Numeric"},
+		{Name: "Legacy", Direction: "IN", Type: "Character"},
+	}
+	newParams := []model.ParameterDoc{
+		{Name: "CustomerID", Direction: "IN", Type: "Numeric"},
+	}
+	maps := []model.ParamMapping{
+		{Old: "UserID", New: "CustomerID"},
+		{Old: "Legacy", Note: "no longer needed"},
+	}
+
+	table := parameterMapping(oldParams, newParams, maps)
+	for _, want := range []string{
+		"| `UserID` (IN Numeric) | `CustomerID` (IN Numeric) | Mapped via @maps |",
+		"| `Legacy` (IN Character) | - | Dropped; no longer needed |",
+	} {
+		if !strings.Contains(table, want) {
+			t.Errorf("mapping table missing %q:\n%s", want, table)
+		}
+	}
+	if strings.Contains(table, "New parameter") {
+		t.Errorf("mapped parameter listed as new:\n%s", table)
+	}
+}
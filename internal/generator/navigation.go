@@ -0,0 +1,289 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+// site holds state shared by every page of a single generation run
+type site struct {
+	// out receives every generated file
+	out storage.Storage
+
+	// readme is the main index filename (README.md or <KB>.md)
+	readme string
+
+	// calls is the call graph across all objects
+	calls *graph.Graph
+
+	// packages lists sanitized package names in display order
+	packages []string
+
+	// byPackage holds the procedures of each package in display order
+	byPackage map[string][]model.GXObject
+
+	// slugs maps procedure names to their page file names (see assignSlugs)
+	slugs map[string]string
+
+	// packageFiles maps packages to their index page (see assignSlugs)
+	packageFiles map[string]string
+
+	// kb indexes all objects for lookups by name
+	kb *model.KnowledgeBase
+
+	// migrations lists deprecated procedures and their replacements
+	migrations []migration
+
+	// domains lists enumerated domain values referenced by procedures
+	domains []domainUsage
+
+	// transactions lists the Transactions with a structure, sorted by name
+	transactions []model.GXObject
+
+	// api groups main programs and exposed services by @tag
+	api []apiGroup
+
+	// order is the collation of alphabetical listings
+	order *collation
+
+	// format renders counts and dates for Options.Lang
+	format *formatter
+
+	// text translates headings and labels for Options.Lang
+	text translator
+
+	// diagrams pre-renders diagrams to SVG; nil keeps Mermaid source
+	diagrams *diagramRenderer
+
+	// screenshots holds the images of Options.Assets by lowercased folder
+	// name (see loadScreenshots)
+	screenshots map[string][]screenshot
+
+	opts Options
+}
+
+// newSite prepares shared navigation state for a generation run
+func newSite(objects, procedures []model.GXObject, kbName string, out storage.Storage, opts Options, order *collation) *site {
+	s := &site{
+		out:       out,
+		readme:    "README.md",
+		calls:     graph.Build(objects),
+		byPackage: make(map[string][]model.GXObject),
+		order:     order,
+		text:      newTranslator(opts.Lang, opts.Translations),
+		opts:      opts,
+	}
+	if kbName != "" {
+		s.readme = kbName + ".md"
+	}
+
+	for _, proc := range procedures {
+		pkg := s.procedurePackage(proc)
+		s.byPackage[pkg] = append(s.byPackage[pkg], proc)
+	}
+	for _, procs := range s.byPackage {
+		sortObjects(procs, opts.Sort, order)
+	}
+	s.packages = sortedKeys(s.byPackage, order)
+	s.assignSlugs(procedures)
+
+	s.kb = model.NewKnowledgeBase(kbName, objects, func(obj model.GXObject) []string {
+		return s.calls.Callees(obj.Path)
+	})
+	s.migrations = collectMigrations(procedures, s.kb)
+	s.domains = collectDomainUsage(procedures, order)
+	s.transactions = collectTransactions(objects, order)
+	s.api = collectPublicAPI(procedures, order)
+
+	return s
+}
+
+// procedurePackage returns the sanitized package a procedure is filed under.
+// With LayoutNested, "Sales.Orders" becomes the "Sales/Orders" directory.
+func (s *site) procedurePackage(proc model.GXObject) string {
+	if proc.Documentation == nil || proc.Documentation.Package == "" {
+		return "root"
+	}
+	if s.opts.Layout != LayoutNested {
+		return sanitizePackageName(proc.Documentation.Package)
+	}
+	var segments []string
+	for _, segment := range strings.FieldsFunc(proc.Documentation.Package, isPackageSeparator) {
+		if segment = sanitizePackageName(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) == 0 {
+		return "root"
+	}
+	return strings.Join(segments, "/")
+}
+
+// isPackageSeparator reports whether r separates nested package names
+func isPackageSeparator(r rune) bool {
+	return r == '/' || r == '\\' || r == '.'
+}
+
+// procedureFile returns a procedure page path relative to the output directory
+func (s *site) procedureFile(proc model.GXObject) string {
+	slug, ok := s.slugs[proc.QualifiedName()]
+	if !ok {
+		slug = slugify(proc.Path)
+	}
+	if pkg := s.procedurePackage(proc); pkg != "root" {
+		return pkg + "/" + slug + ".md"
+	}
+	return slug + ".md"
+}
+
+// packageFile returns the index page of a package relative to the output
+// directory, usually "<package>.md"
+func (s *site) packageFile(pkg string) string {
+	if file, ok := s.packageFiles[pkg]; ok {
+		return file
+	}
+	return pkg + ".md"
+}
+
+// rootPrefix returns the relative path from a procedure page to the output root
+func (s *site) rootPrefix(proc model.GXObject) string {
+	return pathToRoot(s.procedureFile(proc))
+}
+
+// pathToRoot returns the relative path from a page to the output root
+func pathToRoot(page string) string {
+	if depth := strings.Count(page, "/"); depth > 0 {
+		return strings.Repeat("../", depth)
+	}
+	return "./"
+}
+
+// navLine renders a "previous · index · next" navigation line. Empty
+// previous/next links are omitted.
+func navLine(indexLabel, indexLink, prevLabel, prevLink, nextLabel, nextLink string) string {
+	var parts []string
+	if prevLink != "" {
+		parts = append(parts, fmt.Sprintf("[← %s](%s)", prevLabel, prevLink))
+	}
+	parts = append(parts, fmt.Sprintf("[↑ %s](%s)", indexLabel, indexLink))
+	if nextLink != "" {
+		parts = append(parts, fmt.Sprintf("[%s →](%s)", nextLabel, nextLink))
+	}
+	return strings.Join(parts, " · ") + "\n"
+}
+
+// indexNav links a top-level page back to the main index
+func (s *site) indexNav() string {
+	return navLine(s.text.T("Back to index"), "./"+s.readme, "", "", "", "")
+}
+
+// procedureNav links a procedure page to its neighbors within the package
+func (s *site) procedureNav(proc model.GXObject) string {
+	pkg := s.procedurePackage(proc)
+	indexLink := s.rootPrefix(proc) + s.readme
+
+	var prevLabel, prevLink, nextLabel, nextLink string
+	procs := s.byPackage[pkg]
+	for i, p := range procs {
+		if p.QualifiedName() != proc.QualifiedName() {
+			continue
+		}
+		if i > 0 {
+			prevLabel, prevLink = procs[i-1].Path, "./"+path.Base(s.procedureFile(procs[i-1]))
+		}
+		if i < len(procs)-1 {
+			nextLabel, nextLink = procs[i+1].Path, "./"+path.Base(s.procedureFile(procs[i+1]))
+		}
+		break
+	}
+
+	return navLine(s.text.T("Back to index"), indexLink, prevLabel, prevLink, nextLabel, nextLink)
+}
+
+// packageNav links a package index to the neighboring packages
+func (s *site) packageNav(pkg string) string {
+	root := pathToRoot(s.packageFile(pkg))
+	var prevLabel, prevLink, nextLabel, nextLink string
+	for i, p := range s.packages {
+		if p != pkg {
+			continue
+		}
+		if i > 0 {
+			prevLabel, prevLink = s.packages[i-1], root+s.packageFile(s.packages[i-1])
+		}
+		if i < len(s.packages)-1 {
+			nextLabel, nextLink = s.packages[i+1], root+s.packageFile(s.packages[i+1])
+		}
+		break
+	}
+	return navLine(s.text.T("Back to index"), root+s.readme, prevLabel, prevLink, nextLabel, nextLink)
+}
+
+// tableOfContents renders the hierarchical README TOC (sections, packages → procedures, reports)
+func (s *site) tableOfContents() string {
+	var sb strings.Builder
+	sb.WriteString("## " + s.text.T("Table of Contents") + "\n\n")
+	if len(s.api) > 0 {
+		// External consumers start from the public API
+		sb.WriteString(s.tocPage("Public API", "api.md"))
+	}
+	sb.WriteString(s.tocSection("Object Statistics"))
+	if len(s.opts.UnknownTypes) > 0 {
+		sb.WriteString(s.tocSection("Unknown Types"))
+	}
+
+	if len(s.packages) > 0 {
+		sb.WriteString(s.tocSection("Packages"))
+		for _, pkg := range s.packages {
+			sb.WriteString(fmt.Sprintf("  - [%s](./%s)\n", pkg, s.packageFile(pkg)))
+			for _, proc := range s.byPackage[pkg] {
+				sb.WriteString(fmt.Sprintf("    - [%s](./%s)\n", proc.Path, s.procedureFile(proc)))
+			}
+		}
+	}
+
+	sb.WriteString(s.tocSection("Extracted Objects"))
+	sb.WriteString(s.tocPage("What's New", "CHANGELOG.md"))
+	sb.WriteString(s.tocPage("Documentation Coverage", "coverage.md"))
+	sb.WriteString(s.tocPage("Annotation Usage", "annotations.md"))
+	sb.WriteString(s.tocPage("KB Health", "health.md"))
+	sb.WriteString(s.tocPage("Object Dependencies", "dependencies.md"))
+	if s.opts.Layers != nil {
+		sb.WriteString(s.tocPage("Architecture Layers", "layers.md"))
+	}
+	if len(s.domains) > 0 {
+		sb.WriteString(s.tocPage("Domain Values", "domains.md"))
+	}
+	if len(s.transactions) > 0 {
+		sb.WriteString(s.tocPage("Data Model", "datamodel.md"))
+		sb.WriteString(s.tocPage("Attributes", "attributes.md"))
+	}
+	if len(s.migrations) > 0 {
+		sb.WriteString(s.tocPage("Migration Guide", "migration.md"))
+	}
+	if s.opts.Cheatsheet != "" {
+		sb.WriteString(s.tocPage("Cheat Sheet", "cheatsheet.md"))
+	}
+	if s.opts.BadgesEnabled {
+		sb.WriteString(s.tocPage("Badges", "badges.md"))
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
+
+// tocSection renders a TOC entry linking to a README section
+func (s *site) tocSection(heading string) string {
+	heading = s.text.T(heading)
+	return fmt.Sprintf("- [%s](#%s)\n", heading, headingAnchor(heading))
+}
+
+// tocPage renders a TOC entry linking to a report page
+func (s *site) tocPage(title, file string) string {
+	return fmt.Sprintf("- [%s](./%s)\n", s.text.T(title), file)
+}
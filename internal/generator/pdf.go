@@ -0,0 +1,142 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Standard PDF base fonts, available in every viewer without embedding
+const (
+	pdfFontRegular = "F1" // Helvetica
+	pdfFontBold    = "F2" // Helvetica-Bold
+	pdfFontMono    = "F3" // Courier
+)
+
+// A4 portrait page geometry in points
+const (
+	pdfPageWidth  = 595
+	pdfPageHeight = 842
+	pdfMargin     = 40
+)
+
+// pdfDocument is a minimal text-only PDF writer. It lays out lines top to
+// bottom and starts a new page when the current one is full.
+type pdfDocument struct {
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+	y       float64
+}
+
+// newPDFDocument creates an empty document with a first page
+func newPDFDocument() *pdfDocument {
+	doc := &pdfDocument{}
+	doc.newPage()
+	return doc
+}
+
+// newPage starts a new page
+func (d *pdfDocument) newPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+	d.y = pdfPageHeight - pdfMargin
+}
+
+// addLine writes a single line of text, truncating it to the page width
+func (d *pdfDocument) addLine(font string, size float64, text string) {
+	lineHeight := size * 1.35
+	if d.y-lineHeight < pdfMargin {
+		d.newPage()
+	}
+	d.y -= lineHeight
+
+	// Approximate glyph width: Courier is 0.6em, Helvetica averages ~0.5em
+	charWidth := size * 0.5
+	if font == pdfFontMono {
+		charWidth = size * 0.6
+	}
+	maxChars := int((pdfPageWidth - 2*pdfMargin) / charWidth)
+	if runes := []rune(text); len(runes) > maxChars {
+		text = string(runes[:maxChars-3]) + "..."
+	}
+
+	fmt.Fprintf(d.current, "BT /%s %.1f Tf %d %.1f Td (%s) Tj ET\n", font, size, pdfMargin, d.y, pdfEscape(text))
+}
+
+// addSpace adds vertical whitespace
+func (d *pdfDocument) addSpace(points float64) {
+	d.y -= points
+}
+
+// pdfEscape encodes text as a PDF literal string in WinAnsi (Latin-1) encoding
+func pdfEscape(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r >= 32 && r < 127:
+			sb.WriteRune(r)
+		case r >= 160 && r < 256:
+			sb.WriteString(fmt.Sprintf("\\%03o", r))
+		default:
+			sb.WriteByte('?')
+		}
+	}
+	return sb.String()
+}
+
+// bytes serializes the document
+func (d *pdfDocument) bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+
+	startObject := func() int {
+		offsets = append(offsets, out.Len())
+		id := len(offsets)
+		fmt.Fprintf(&out, "%d 0 obj\n", id)
+		return id
+	}
+
+	out.WriteString("%PDF-1.4\n")
+
+	// Objects 1-5: catalog, page tree and fonts. Pages start at object 6,
+	// each followed by its content stream.
+	startObject()
+	out.WriteString("<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+
+	startObject()
+	var kids []string
+	for i := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", 6+i*2))
+	}
+	fmt.Fprintf(&out, "<< /Type /Pages /Kids [%s] /Count %d >>\nendobj\n", strings.Join(kids, " "), len(d.pages))
+
+	for _, font := range []string{"Helvetica", "Helvetica-Bold", "Courier"} {
+		startObject()
+		fmt.Fprintf(&out, "<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>\nendobj\n", font)
+	}
+
+	for _, page := range d.pages {
+		pageID := startObject()
+		fmt.Fprintf(&out, "<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] ", pdfPageWidth, pdfPageHeight)
+		fmt.Fprintf(&out, "/Resources << /Font << /%s 3 0 R /%s 4 0 R /%s 5 0 R >> >> ", pdfFontRegular, pdfFontBold, pdfFontMono)
+		fmt.Fprintf(&out, "/Contents %d 0 R >>\nendobj\n", pageID+1)
+
+		startObject()
+		fmt.Fprintf(&out, "<< /Length %d >>\nstream\n", page.Len())
+		out.Write(page.Bytes())
+		out.WriteString("endstream\nendobj\n")
+	}
+
+	xrefOffset := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n", len(offsets)+1)
+	out.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xrefOffset)
+
+	return out.Bytes()
+}
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestPDFDocument_XrefOffsets(t *testing.T) {
+	doc := newPDFDocument()
+	for i := 0; i < 120; i++ {
+		doc.addLine(pdfFontRegular, 10, fmt.Sprintf("Line %d (with parentheses) and ação", i))
+	}
+	if len(doc.pages) < 2 {
+		t.Fatalf("Expected content to span several pages, got %d", len(doc.pages))
+	}
+
+	data := doc.bytes()
+
+	match := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(data)
+	if match == nil {
+		t.Fatal("Missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(match[1]))
+	if !bytes.HasPrefix(data[xref:], []byte("xref")) {
+		t.Fatalf("startxref does not point to the xref table")
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(data[xref:], -1)
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if !bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj", i+1))) {
+			t.Errorf("Xref entry %d does not point to its object", i+1)
+		}
+	}
+}
+
+func TestPDFEscape(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Parm(in:&X)", `Parm\(in:&X\)`},
+		{`a\b`, `a\\b`},
+		{"ação", `a\347\343o`},
+		{"→", "?"},
+	}
+
+	for _, tt := range tests {
+		if result := pdfEscape(tt.input); result != tt.expected {
+			t.Errorf("pdfEscape(%q) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/diff"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/parser"
+)
+
+// GenerateReleaseNotes writes a human-readable release notes document from
+// the differences between two exports and the @since/@deprecated annotations.
+// lang formats the generation date as in Options.Lang.
+func GenerateReleaseNotes(result diff.Result, kbName, releaseVersion, lang, outputPath string) error {
+	format, err := newFormatter(lang)
+	if err != nil {
+		return err
+	}
+
+	var sb strings.Builder
+
+	title := "Release Notes"
+	if kbName != "" {
+		title += " - " + kbName
+	}
+	if releaseVersion != "" {
+		title += " " + releaseVersion
+	}
+	sb.WriteString("# " + title + "\n\n")
+	sb.WriteString(fmt.Sprintf("Generated on: %s\n\n", format.DateTime(time.Now())))
+
+	if result.IsEmpty() {
+		sb.WriteString("*No changes between the two exports.*\n")
+	}
+
+	// New objects
+	if len(result.Added) > 0 {
+		sb.WriteString("## New Objects\n\n")
+		sb.WriteString("| Name | Type | Summary | Since | Signature |\n")
+		sb.WriteString("|------|------|---------|-------|-----------|\n")
+		for _, obj := range result.Added {
+			since := "-"
+			if obj.Documentation != nil && obj.Documentation.Since != "" {
+				since = obj.Documentation.Since
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | `%s` |\n",
+				escapeTableCell(obj.Path), obj.Type, escapeTableCell(cheatsheetSummary(obj)),
+				escapeTableCell(since), escapeTableCell(oneLine(obj.ParmSignature))))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Signature changes
+	var signatureChanges []diff.Change
+	for _, change := range result.Changed {
+		if change.SignatureChanged {
+			signatureChanges = append(signatureChanges, change)
+		}
+	}
+	if len(signatureChanges) > 0 {
+		sb.WriteString("## Changed Signatures\n\n")
+		for _, change := range signatureChanges {
+			sb.WriteString("### " + change.Name + "\n\n")
+			sb.WriteString("```genexus\n")
+			sb.WriteString("// before\n" + oneLine(change.Old.ParmSignature) + "\n")
+			sb.WriteString("// after\n" + oneLine(change.New.ParmSignature) + "\n")
+			sb.WriteString("```\n\n")
+			for _, pc := range change.ParamChanges {
+				sb.WriteString("- " + pc.String() + "\n")
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	// Implementation changes; documentation-only edits are left out
+	var codeChanges []diff.Change
+	for _, change := range result.Changed {
+		if change.CodeChanged() && !change.SignatureChanged {
+			codeChanges = append(codeChanges, change)
+		}
+	}
+	if len(codeChanges) > 0 {
+		sb.WriteString("## Code Changes\n\n")
+		sb.WriteString("| Name | Type | Changed |\n")
+		sb.WriteString("|------|------|---------|\n")
+		for _, change := range codeChanges {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+				escapeTableCell(change.Name), change.Type, strings.Join(change.CodeParts(), ", ")))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Deprecations introduced in this release
+	var deprecated []model.GXObject
+	for _, obj := range result.Added {
+		if obj.Documentation != nil && obj.Documentation.Deprecated {
+			deprecated = append(deprecated, obj)
+		}
+	}
+	for _, change := range result.Changed {
+		wasDeprecated := change.Old.Documentation != nil && change.Old.Documentation.Deprecated
+		if change.New.Documentation != nil && change.New.Documentation.Deprecated && !wasDeprecated {
+			deprecated = append(deprecated, change.New)
+		}
+	}
+	if len(deprecated) > 0 {
+		sb.WriteString("## Deprecations\n\n")
+		sb.WriteString("| Name | Migration Note | Replacement |\n")
+		sb.WriteString("|------|----------------|-------------|\n")
+		for _, obj := range deprecated {
+			note := obj.Documentation.DeprecationNote
+			replacement := parser.ParseReplacement(note)
+			if note == "" {
+				note = "-"
+			}
+			if replacement == "" {
+				replacement = "-"
+			}
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+				escapeTableCell(obj.Path), escapeTableCell(note), escapeTableCell(replacement)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Removed objects
+	if len(result.Removed) > 0 {
+		sb.WriteString("## Removed Objects\n\n")
+		sb.WriteString("| Name | Type | Summary |\n")
+		sb.WriteString("|------|------|---------|\n")
+		for _, obj := range result.Removed {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+				escapeTableCell(obj.Path), obj.Type, escapeTableCell(cheatsheetSummary(obj))))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\n---\n")
+	sb.WriteString(fmt.Sprintf("Generated by GXDocGen v%s\n", version))
+
+	return os.WriteFile(outputPath, []byte(sb.String()), 0644)
+}
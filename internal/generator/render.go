@@ -0,0 +1,92 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// ErrPageTimeout is returned for procedure pages that exceed Options.PageTimeout
+var ErrPageTimeout = errors.New("page generation timed out")
+
+// ErrRenderFailed is returned for procedure pages whose rendering panicked
+var ErrRenderFailed = errors.New("page rendering failed")
+
+// PageFailure is a procedure page that could not be generated and was
+// replaced by an error placeholder
+type PageFailure struct {
+	Object string `json:"object"`
+	Page   string `json:"page"`
+	Error  string `json:"error"`
+}
+
+// renderProcedure renders a procedure page, giving up after
+// opts.PageTimeout. A timed-out render keeps running in the background but
+// its result is discarded.
+func (s *site) renderProcedure(proc model.GXObject) (string, error) {
+	if s.opts.PageTimeout <= 0 {
+		return safeRender(proc, s)
+	}
+
+	type rendered struct {
+		page string
+		err  error
+	}
+	done := make(chan rendered, 1)
+	go func() {
+		page, err := safeRender(proc, s)
+		done <- rendered{page, err}
+	}()
+
+	timer := time.NewTimer(s.opts.PageTimeout)
+	defer timer.Stop()
+	select {
+	case r := <-done:
+		return r.page, r.err
+	case <-timer.C:
+		return "", fmt.Errorf("%w after %s", ErrPageTimeout, s.opts.PageTimeout)
+	}
+}
+
+// renderPage renders a procedure page; replaced in tests
+var renderPage = renderProcedurePage
+
+// safeRender renders a procedure page, turning a panic into ErrRenderFailed
+// so one malformed object cannot abort the whole run
+func safeRender(proc model.GXObject, s *site) (page string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			utils.Debug("%s: render panic: %v\n%s", proc.Path, r, debug.Stack())
+			page, err = "", fmt.Errorf("%w: %v", ErrRenderFailed, r)
+		}
+	}()
+	return renderPage(proc, s), nil
+}
+
+// placeholderPage is written instead of a page that timed out or failed, so
+// links from indexes and the call graph still resolve
+func placeholderPage(proc model.GXObject, renderErr error, s *site) string {
+	reason := fmt.Sprintf("rendering failed (%v)", renderErr)
+	if errors.Is(renderErr, ErrPageTimeout) {
+		reason = fmt.Sprintf("rendering took longer than %s", s.opts.PageTimeout)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# " + proc.Name + "\n\n")
+	sb.WriteString(fmt.Sprintf("*Documentation for this procedure was skipped: %s.*\n\n", reason))
+	if proc.ParmSignature != "" {
+		sb.WriteString("## " + s.text.T("Signature") + "\n\n")
+		sb.WriteString("```genexus\n")
+		sb.WriteString(proc.ParmSignature + "\n")
+		sb.WriteString("```\n\n")
+	}
+	sb.WriteString("---\n\n")
+	sb.WriteString(s.procedureNav(proc))
+	sb.WriteString(s.footer())
+	return sb.String()
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// DefaultReturnConventions are the parameter names that, as the only OUT
+// parameter of a procedure, carry its result
+var DefaultReturnConventions = []string{"Result", "Sucesso", "Messages"}
+
+// returnConventions returns the configured conventions, or the defaults
+// when none are configured. An empty, non-nil list disables detection.
+func (o Options) returnConventions() []string {
+	if o.ReturnConventions == nil {
+		return DefaultReturnConventions
+	}
+	return o.ReturnConventions
+}
+
+// conventionalReturn returns the parameter carrying the result of a
+// procedure without @return: its only OUT parameter, when that parameter is
+// named after a return convention
+func (s *site) conventionalReturn(proc model.GXObject) (model.ParameterDoc, bool) {
+	params := proc.Parameters
+	if proc.Documentation != nil && len(proc.Documentation.Parameters) > 0 {
+		params = proc.Documentation.Parameters
+	}
+
+	var out []model.ParameterDoc
+	for _, param := range params {
+		if strings.EqualFold(param.Direction, "OUT") {
+			out = append(out, param)
+		}
+	}
+	if len(out) != 1 {
+		return model.ParameterDoc{}, false
+	}
+	for _, convention := range s.opts.returnConventions() {
+		if strings.EqualFold(strings.TrimPrefix(convention, "&"), out[0].Name) {
+			return out[0], true
+		}
+	}
+	return model.ParameterDoc{}, false
+}
+
+// returnSection renders the "Return" section from @return or, without it,
+// from a return convention
+func returnSection(proc model.GXObject, s *site) string {
+	if doc := proc.Documentation; doc != nil && doc.Return != "" {
+		return "## " + s.text.T("Return") + "\n\n" + doc.Return + "\n\n"
+	}
+	param, ok := s.conventionalReturn(proc)
+	if !ok {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + s.text.T("Return") + "\n\n")
+	sb.WriteString("`&" + param.Name + "`")
+	if param.Type != "" {
+		sb.WriteString(" (" + s.dataType(param.Type) + ")")
+	}
+	if param.Description != "" {
+		sb.WriteString(": " + param.Description)
+	}
+	sb.WriteString("\n\n*" + fmt.Sprintf(s.text.T("Returned through the OUT parameter &%s."), param.Name) + "*\n\n")
+	return sb.String()
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestReturnSection(t *testing.T) {
+	result := model.ParameterDoc{Name: "Result", Direction: "OUT", Type: "Boolean", Description: "True when saved"}
+	id := model.ParameterDoc{Name: "CustomerId", Direction: "IN"}
+
+	tests := []struct {
+		name        string
+		proc        model.GXObject
+		conventions []string
+		want        string
+	}{
+		{"documented", model.GXObject{Parameters: []model.ParameterDoc{id, result}, Documentation: &model.DocComment{Return: "The saved flag"}}, nil, "The saved flag\n"},
+		{"convention", model.GXObject{Parameters: []model.ParameterDoc{id, result}}, nil, "`&Result` (Boolean): True when saved\n\n*Returned through the OUT parameter &Result.*"},
+		{"case-insensitive", model.GXObject{Parameters: []model.ParameterDoc{{Name: "sucesso", Direction: "out"}}}, nil, "`&sucesso`\n"},
+		{"configured", model.GXObject{Parameters: []model.ParameterDoc{{Name: "Retorno", Direction: "OUT"}}}, []string{"&Retorno"}, "`&Retorno`\n"},
+		{"disabled", model.GXObject{Parameters: []model.ParameterDoc{result}}, []string{}, ""},
+		{"other name", model.GXObject{Parameters: []model.ParameterDoc{{Name: "Total", Direction: "OUT"}}}, nil, ""},
+		{"several outputs", model.GXObject{Parameters: []model.ParameterDoc{result, {Name: "Messages", Direction: "OUT"}}}, nil, ""},
+		{"inout", model.GXObject{Parameters: []model.ParameterDoc{{Name: "Result", Direction: "INOUT"}}}, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSite(nil, nil, "KB", nil, Options{ReturnConventions: tt.conventions}, nil)
+			got := returnSection(tt.proc, s)
+			if tt.want == "" {
+				if got != "" {
+					t.Errorf("Expected no return section, got %q", got)
+				}
+				return
+			}
+			if !strings.HasPrefix(got, "## Return\n\n") || !strings.Contains(got, tt.want) {
+				t.Errorf("Expected a return section containing %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package generator
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	xhtml "golang.org/x/net/html"
+)
+
+// allowedTags are the HTML elements kept in descriptions, with the
+// attributes kept on each. Anything else is escaped and shows as text.
+var allowedTags = map[string][]string{
+	"a":          {"href", "title"},
+	"abbr":       {"title"},
+	"b":          nil,
+	"blockquote": nil,
+	"br":         nil,
+	"code":       nil,
+	"dd":         nil,
+	"del":        nil,
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "title", "width", "height"},
+	"kbd":        nil,
+	"li":         nil,
+	"ol":         nil,
+	"p":          nil,
+	"pre":        nil,
+	"s":          nil,
+	"strong":     nil,
+	"sub":        nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"colspan", "rowspan"},
+	"th":         {"colspan", "rowspan"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+}
+
+// urlAttrs are the attributes holding URLs, which must use a safe scheme
+var urlAttrs = map[string]bool{"href": true, "src": true}
+
+// safeSchemes are the URL schemes allowed in links and images; relative
+// URLs have none
+var safeSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// linkDefinitionRegex matches Markdown link reference definitions,
+// "[label]: destination", capturing the destination
+var linkDefinitionRegex = regexp.MustCompile(`(?m)^ {0,3}\[[^\]\n]+\]:[ \t]*(<[^>\n]*>|\S+)`)
+
+// sanitizeObjects strips unsafe HTML and links from the text of objects,
+// which comes from comments and exports written by many authors and ends up
+// on pages a portal renders as HTML. Descriptions keep allowedTags; names, types,
+// packages and tags never hold markup, so all of theirs is escaped.
+// Documentation, parameters, variables and levels are copied before
+// changing, since they are shared with the caller.
+func sanitizeObjects(objects []model.GXObject) {
+	for i := range objects {
+		obj := &objects[i]
+		obj.Name = escapeMarkup(obj.Name)
+		obj.Path = escapeMarkup(obj.Path)
+		obj.Parent = escapeMarkup(obj.Parent)
+		obj.XMLDescription = sanitizeHTML(obj.XMLDescription)
+		obj.Parameters = sanitizeParameters(obj.Parameters)
+		obj.Levels = sanitizeLevels(obj.Levels)
+
+		obj.Variables = slices.Clone(obj.Variables)
+		for j := range obj.Variables {
+			v := &obj.Variables[j]
+			v.Name, v.Type = escapeMarkup(v.Name), escapeMarkup(v.Type)
+			v.Description = sanitizeHTML(v.Description)
+		}
+
+		if obj.Documentation == nil {
+			continue
+		}
+		doc := *obj.Documentation
+		doc.Package = escapeMarkup(doc.Package)
+		doc.DocGroup = escapeMarkup(doc.DocGroup)
+		doc.Tags = escapeAll(doc.Tags)
+		doc.See = escapeAll(doc.See)
+		doc.Summary = sanitizeHTML(doc.Summary)
+		doc.Description = sanitizeHTML(doc.Description)
+		doc.Author = sanitizeHTML(doc.Author)
+		doc.Since = sanitizeHTML(doc.Since)
+		doc.Return = sanitizeHTML(doc.Return)
+		doc.DeprecationNote = sanitizeHTML(doc.DeprecationNote)
+		doc.Parameters = sanitizeParameters(doc.Parameters)
+		doc.ParamMaps = slices.Clone(doc.ParamMaps)
+		for j := range doc.ParamMaps {
+			doc.ParamMaps[j].Note = sanitizeHTML(doc.ParamMaps[j].Note)
+		}
+		doc.CustomFields = slices.Clone(doc.CustomFields)
+		for j := range doc.CustomFields {
+			doc.CustomFields[j].Value = sanitizeHTML(doc.CustomFields[j].Value)
+		}
+		obj.Documentation = &doc
+	}
+}
+
+// sanitizeParameters returns a sanitized copy of params
+func sanitizeParameters(params []model.ParameterDoc) []model.ParameterDoc {
+	params = slices.Clone(params)
+	for i := range params {
+		p := &params[i]
+		p.Name, p.Direction, p.Type = escapeMarkup(p.Name), escapeMarkup(p.Direction), escapeMarkup(p.Type)
+		p.Description = sanitizeHTML(p.Description)
+	}
+	return params
+}
+
+// sanitizeLevels returns a sanitized copy of levels
+func sanitizeLevels(levels []model.Level) []model.Level {
+	levels = slices.Clone(levels)
+	for i := range levels {
+		level := &levels[i]
+		level.Name = escapeMarkup(level.Name)
+		level.Description = sanitizeHTML(level.Description)
+		level.Attributes = slices.Clone(level.Attributes)
+		for j := range level.Attributes {
+			attr := &level.Attributes[j]
+			attr.Name, attr.Type, attr.References = escapeMarkup(attr.Name), escapeMarkup(attr.Type), escapeMarkup(attr.References)
+			attr.Description = sanitizeHTML(attr.Description)
+		}
+		level.Levels = sanitizeLevels(level.Levels)
+	}
+	return levels
+}
+
+// escapeMarkup escapes the angle brackets of plain text, such as names and
+// types, so no HTML tag can open in it, and defuses unsafe link destinations
+func escapeMarkup(text string) string {
+	if !strings.ContainsAny(text, "<>]") {
+		return text
+	}
+	return strings.NewReplacer("<", "&lt;", ">", "&gt;").Replace(sanitizeLinks(text))
+}
+
+// escapeAll returns a copy of values with escapeMarkup applied
+func escapeAll(values []string) []string {
+	values = slices.Clone(values)
+	for i := range values {
+		values[i] = escapeMarkup(values[i])
+	}
+	return values
+}
+
+// sanitizeHTML returns Markdown text with its raw HTML reduced to
+// allowedTags: other tags, comments and event handler or style attributes
+// are dropped or escaped, and HTML and Markdown links keep only safe
+// schemes. Markdown code spans and fenced blocks, which show their content
+// literally, are kept as written.
+func sanitizeHTML(text string) string {
+	if !strings.ContainsAny(text, "<]") {
+		return text
+	}
+
+	var sb strings.Builder
+	rest := text
+	for rest != "" {
+		start, end := nextCodeSpan(rest)
+		if start < 0 {
+			sanitizeFragment(&sb, rest)
+			break
+		}
+		sanitizeFragment(&sb, rest[:start])
+		sb.WriteString(rest[start:end])
+		rest = rest[end:]
+	}
+	return sb.String()
+}
+
+// nextCodeSpan returns the bounds of the first code span in text, opened
+// and closed by backtick runs of the same length, or -1 when there is none.
+// A backtick escaped with a backslash opens nothing, as in Markdown.
+func nextCodeSpan(text string) (int, int) {
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+		run := backtickRun(text[i:])
+		if escapedAt(text, i) {
+			i += run
+			continue
+		}
+		for j := i + run; j < len(text); {
+			if text[j] != '`' {
+				j++
+				continue
+			}
+			closing := backtickRun(text[j:])
+			if closing == run {
+				return i, j + closing
+			}
+			j += closing
+		}
+		i += run
+	}
+	return -1, -1
+}
+
+// backtickRun returns the number of backticks text starts with
+func backtickRun(text string) int {
+	n := 0
+	for n < len(text) && text[n] == '`' {
+		n++
+	}
+	return n
+}
+
+// escapedAt reports whether text[i] follows an odd number of backslashes
+func escapedAt(text string, i int) bool {
+	n := 0
+	for i-n > 0 && text[i-n-1] == '\\' {
+		n++
+	}
+	return n%2 == 1
+}
+
+// sanitizeFragment writes text outside code spans to sb, re-serializing
+// allowed tags and escaping everything else that is not plain text
+func sanitizeFragment(sb *strings.Builder, text string) {
+	text = sanitizeLinks(text)
+	if !strings.Contains(text, "<") {
+		sb.WriteString(text)
+		return
+	}
+	z := xhtml.NewTokenizer(strings.NewReader(text))
+	for {
+		tt := z.Next()
+		switch tt {
+		case xhtml.ErrorToken:
+			// An unterminated tag at the end is kept as text
+			sb.WriteString(html.EscapeString(string(z.Raw())))
+			return
+		case xhtml.TextToken:
+			sb.Write(z.Raw())
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken, xhtml.EndTagToken:
+			// Tokenize the content of script, style and the like as markup,
+			// so tags inside them are sanitized too
+			if tt == xhtml.StartTagToken {
+				z.NextIsNotRawText()
+			}
+			token := z.Token()
+			if attrs, ok := allowedTags[token.Data]; ok {
+				sb.WriteString(allowedTag(token, attrs))
+			} else {
+				sb.WriteString(html.EscapeString(string(z.Raw())))
+			}
+		default:
+			sb.WriteString(html.EscapeString(string(z.Raw())))
+		}
+	}
+}
+
+// allowedTag serializes an allowed tag with only its allowed attributes
+func allowedTag(token xhtml.Token, allowed []string) string {
+	if token.Type == xhtml.EndTagToken {
+		return "</" + token.Data + ">"
+	}
+	var sb strings.Builder
+	sb.WriteString("<" + token.Data)
+	for _, attr := range token.Attr {
+		if attr.Namespace != "" || !slices.Contains(allowed, attr.Key) {
+			continue
+		}
+		if urlAttrs[attr.Key] && !safeURL(attr.Val) {
+			continue
+		}
+		sb.WriteString(" " + attr.Key + `="` + html.EscapeString(attr.Val) + `"`)
+	}
+	if token.Type == xhtml.SelfClosingTagToken {
+		sb.WriteString(" /")
+	}
+	sb.WriteString(">")
+	return sb.String()
+}
+
+// sanitizeLinks replaces the destination of Markdown links, images and link
+// reference definitions that use no safe scheme with "#", so
+// "[x](javascript:alert(1))" becomes "[x](#)"
+func sanitizeLinks(text string) string {
+	var sb strings.Builder
+	last := 0
+	for _, span := range linkDestinations(text) {
+		if span[0] < last || safeLinkDestination(text[span[0]:span[1]]) {
+			continue
+		}
+		sb.WriteString(text[last:span[0]])
+		sb.WriteString("#")
+		last = span[1]
+	}
+	if last == 0 {
+		return text
+	}
+	sb.WriteString(text[last:])
+	return sb.String()
+}
+
+// linkDestinations returns the bounds of the destinations of inline links
+// and images ("[text](dest)") and of link reference definitions, in order.
+// Bare destinations end at whitespace or at an unbalanced ")".
+func linkDestinations(text string) [][2]int {
+	var spans [][2]int
+	for i := 0; i < len(text); {
+		idx := strings.Index(text[i:], "](")
+		if idx < 0 {
+			break
+		}
+		start := i + idx + 2
+		for start < len(text) && (text[start] == ' ' || text[start] == '\t' || text[start] == '\n') {
+			start++
+		}
+		end := start
+		if end < len(text) && text[end] == '<' {
+			for end < len(text) && text[end] != '>' && text[end] != '\n' {
+				end++
+			}
+			if end < len(text) && text[end] == '>' {
+				end++
+			}
+		} else {
+			for depth := 0; end < len(text); end++ {
+				c := text[end]
+				if c == '\\' && end+1 < len(text) {
+					end++
+					continue
+				}
+				if c <= ' ' || (c == ')' && depth == 0) {
+					break
+				}
+				if c == '(' {
+					depth++
+				} else if c == ')' {
+					depth--
+				}
+			}
+		}
+		if end > start {
+			spans = append(spans, [2]int{start, end})
+		}
+		i = max(end, start)
+	}
+	for _, m := range linkDefinitionRegex.FindAllStringSubmatchIndex(text, -1) {
+		spans = append(spans, [2]int{m[2], m[3]})
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i][0] < spans[j][0]
+	})
+	return spans
+}
+
+// safeLinkDestination reports whether a Markdown link destination is a safe
+// URL once its angle brackets, character references and backslash escapes,
+// which renderers resolve, are removed
+func safeLinkDestination(dest string) bool {
+	dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+	dest = strings.ReplaceAll(html.UnescapeString(dest), "\\", "")
+	return safeURL(dest)
+}
+
+// safeURL reports whether a URL is relative or uses one of safeSchemes.
+// Browsers ignore whitespace and control characters inside the scheme, so
+// "java\tscript:" is treated as javascript.
+func safeURL(url string) bool {
+	var scheme strings.Builder
+	for _, r := range url {
+		switch {
+		case r == ':':
+			return safeSchemes[strings.ToLower(scheme.String())]
+		case r == '/' || r == '?' || r == '#':
+			return true
+		case r <= ' ':
+			continue
+		default:
+			scheme.WriteRune(r)
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name, text, want string
+	}{
+		{"plain text", "Returns a < b && c", "Returns a < b && c"},
+		{"allowed tags", "Use <b>bold</b> and <br/>breaks", "Use <b>bold</b> and <br />breaks"},
+		{"script", "<script>alert(1)</script>", "&lt;script&gt;alert(1)&lt;/script&gt;"},
+		{"markup inside script", "<script><img src=x onerror=alert(1)></script>", `&lt;script&gt;<img src="x">&lt;/script&gt;`},
+		{"event handler", `<b onclick="alert(1)">x</b>`, "<b>x</b>"},
+		{"javascript link", `<a href="java&#x09;script:alert(1)" title="t">x</a>`, `<a title="t">x</a>`},
+		{"safe link", `<a href="https://example.com/?a=1&amp;b=2">x</a>`, `<a href="https://example.com/?a=1&amp;b=2">x</a>`},
+		{"relative link", `<a href="../Sales/GetUser.md">x</a>`, `<a href="../Sales/GetUser.md">x</a>`},
+		{"comment", "a <!-- hidden --> b", "a &lt;!-- hidden --&gt; b"},
+		{"unterminated tag", `x <img src="`, "x &lt;img src=&#34;"},
+		{"code span", "Call `<script>` here", "Call `<script>` here"},
+		{"fenced block", "```\n<iframe>\n```", "```\n<iframe>\n```"},
+		{"escaped backtick", "\\`<iframe>`", "\\`&lt;iframe&gt;`"},
+		{"javascript markdown link", "[x](javascript:alert(1))", "[x](#)"},
+		{"data markdown image", "![](data:text/html,<b>x</b>)", "![](#)"},
+		{"encoded markdown link", `[x](JaVa&#115;cript:alert(1) "t")`, `[x](# "t")`},
+		{"escaped markdown link", `[x](<javascript\:alert(1)>)`, "[x](#)"},
+		{"link definition", "[x]: javascript:alert(1)\n\nSee [x].", "[x]: #\n\nSee [x]."},
+		{"safe markdown link", "[a](https://example.com/a_(b)) and ![b](img/b.png)", "[a](https://example.com/a_(b)) and ![b](img/b.png)"},
+		{"link in code span", "`[x](javascript:alert(1))`", "`[x](javascript:alert(1))`"},
+	}
+
+	for _, tt := range tests {
+		if got := sanitizeHTML(tt.text); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestSanitizeObjects(t *testing.T) {
+	doc := &model.DocComment{
+		Description: "<iframe src=x></iframe>",
+		Parameters:  []model.ParameterDoc{{Name: "Id", Description: "<img src=x onerror=alert(1)>"}},
+	}
+	objects := []model.GXObject{{Name: "GetUser", Documentation: doc}}
+
+	sanitizeObjects(objects)
+
+	got := objects[0].Documentation
+	if got.Description != "&lt;iframe src=x&gt;&lt;/iframe&gt;" {
+		t.Errorf("Expected the iframe escaped, got %q", got.Description)
+	}
+	if got.Parameters[0].Description != `<img src="x">` {
+		t.Errorf("Expected the event handler dropped, got %q", got.Parameters[0].Description)
+	}
+	if doc.Description != "<iframe src=x></iframe>" || doc.Parameters[0].Description != "<img src=x onerror=alert(1)>" {
+		t.Error("Expected the caller's documentation left untouched")
+	}
+}
+
+func TestGenerateDocsEscapesPlainText(t *testing.T) {
+	doc := &model.DocComment{
+		Package:    "<script>alert(6)</script>",
+		Tags:       []string{"<script>alert(7)</script>"},
+		Parameters: []model.ParameterDoc{{Name: "X", Direction: "IN", Type: "<iframe"}},
+	}
+	doc.Description = "See [docs](javascript:alert(8))"
+	objects := []model.GXObject{{Name: "Evil [x](javascript:alert(9)) <script>alert(1)</script>", Path: "Evil", Type: "Procedure", Documentation: doc}}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	checked := 0
+	err := fs.WalkDir(out, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(name, ".md") {
+			return err
+		}
+		data, _ := fs.ReadFile(out, name)
+		for _, tag := range []string{"<script", "<iframe", "javascript:"} {
+			if strings.Contains(string(data), tag) {
+				t.Errorf("Expected no raw %s in %s:\n%s", tag, name, data)
+			}
+		}
+		checked++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]string{
+		"KB.md":          "Evil [x](#) &lt;script&gt;alert(1)&lt;/script&gt;",
+		"coverage.md":    "&lt;script&gt;alert(6)&lt;/script&gt;",
+		"annotations.md": "&lt;script&gt;alert(6)&lt;/script&gt;",
+		"health.md":      "&lt;script&gt;alert(6)&lt;/script&gt;",
+	} {
+		if data, _ := fs.ReadFile(out, name); !strings.Contains(string(data), want) {
+			t.Errorf("Expected %q in %s:\n%s", want, name, data)
+		}
+	}
+	if checked == 0 {
+		t.Fatal("Expected generated pages")
+	}
+}
@@ -0,0 +1,130 @@
+package generator
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// screenshot is a PNG image of an object, copied next to the pages
+type screenshot struct {
+	// source is the image on disk
+	source string
+
+	// file is the image in the output, relative to the root (e.g.
+	// "assets/WpUsers/list.png")
+	file string
+}
+
+// loadScreenshots lists the PNG screenshots under dir, which holds one
+// folder per object: <ObjectName>/*.png or <Module.ObjectName>/*.png.
+// Folders are keyed by their lowercased name, images sorted by file name.
+func loadScreenshots(dir string) (map[string][]screenshot, error) {
+	folders, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byObject := make(map[string][]screenshot)
+	for _, folder := range folders {
+		if !folder.IsDir() {
+			continue
+		}
+		files, err := os.ReadDir(filepath.Join(dir, folder.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if file.IsDir() || !strings.EqualFold(filepath.Ext(file.Name()), ".png") {
+				continue
+			}
+			key := strings.ToLower(folder.Name())
+			byObject[key] = append(byObject[key], screenshot{
+				source: filepath.Join(dir, folder.Name(), file.Name()),
+				file:   path.Join("assets", folder.Name(), file.Name()),
+			})
+		}
+	}
+	return byObject, nil
+}
+
+// screenshotsOf returns the screenshots of obj, from the folder named after
+// its qualified name or, failing that, its name
+func (s *site) screenshotsOf(obj model.GXObject) []screenshot {
+	if shots, ok := s.screenshots[strings.ToLower(obj.QualifiedName())]; ok {
+		return shots
+	}
+	return s.screenshots[strings.ToLower(obj.Path)]
+}
+
+// screenshotsSection embeds the screenshots of obj in page, under heading
+// ("##" on object pages, "###" within datamodel.md)
+func (s *site) screenshotsSection(obj model.GXObject, page, heading string) string {
+	shots := s.screenshotsOf(obj)
+	if len(shots) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(heading + " " + s.text.T("Screenshots") + "\n\n")
+	for _, shot := range shots {
+		var link []string
+		for _, segment := range strings.Split(shot.file, "/") {
+			link = append(link, url.PathEscape(segment))
+		}
+		sb.WriteString(fmt.Sprintf("![%s](%s%s)\n\n", screenshotCaption(shot.file), pathToRoot(page), strings.Join(link, "/")))
+	}
+	return sb.String()
+}
+
+// screenshotCaption turns an image file name into alt text, e.g.
+// "order-list_filtered.png" into "order list filtered"
+func screenshotCaption(file string) string {
+	name := strings.TrimSuffix(path.Base(file), path.Ext(file))
+	name = strings.NewReplacer("-", " ", "_", " ", "[", "", "]", "").Replace(name)
+	return strings.TrimSpace(name)
+}
+
+// copyScreenshots copies the screenshots of the objects with a page to the
+// output, warning about folders that match no such object. Images are
+// copied on every run, so pages skipped in incremental mode keep them.
+func copyScreenshots(objects []model.GXObject, s *site) error {
+	used := make(map[string]bool)
+	for _, obj := range objects {
+		if obj.Type != "Procedure" && !(obj.Type == "Transaction" && len(obj.Levels) > 0) {
+			continue
+		}
+		for _, shot := range s.screenshotsOf(obj) {
+			if used[shot.file] {
+				continue
+			}
+			used[shot.file] = true
+			data, err := os.ReadFile(shot.source)
+			if err != nil {
+				return err
+			}
+			if err := s.out.WriteFile(shot.file, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	var unused []string
+	for _, shots := range s.screenshots {
+		if !used[shots[0].file] {
+			unused = append(unused, filepath.Dir(shots[0].source))
+		}
+	}
+	sort.Strings(unused)
+	for _, dir := range unused {
+		utils.Warning("Screenshots in %s match no documented object", dir)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestGenerateDocsScreenshots(t *testing.T) {
+	assets := t.TempDir()
+	for _, file := range []string{"GetUser/user list.png", "GetUser/notes.txt", "Customer/form.PNG", "WpMissing/home.png"} {
+		path := filepath.Join(assets, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("png"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects := []model.GXObject{
+		{Name: "GetUser", Path: "getuser", Type: "Procedure", Documentation: &model.DocComment{Package: "Users"}},
+		{Name: "Customer", Path: "Customer", Type: "Transaction", Levels: []model.Level{{Name: "Customer"}}},
+	}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out, Assets: assets}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	page, _ := fs.ReadFile(out, "Users/getuser.md")
+	if !strings.Contains(string(page), "## Screenshots\n\n![user list](../assets/GetUser/user%20list.png)") {
+		t.Errorf("Expected the screenshot embedded in the procedure page:\n%s", page)
+	}
+	if strings.Contains(string(page), "notes.txt") {
+		t.Error("Expected only PNG files to be embedded")
+	}
+	datamodel, _ := fs.ReadFile(out, "datamodel.md")
+	if !strings.Contains(string(datamodel), "### Screenshots\n\n![form](./assets/Customer/form.PNG)") {
+		t.Errorf("Expected the screenshot embedded in the data model:\n%s", datamodel)
+	}
+
+	for _, file := range []string{"assets/GetUser/user list.png", "assets/Customer/form.PNG"} {
+		if data, err := fs.ReadFile(out, file); err != nil || string(data) != "png" {
+			t.Errorf("Expected %s copied to the output, got %q, %v", file, data, err)
+		}
+	}
+	if _, err := fs.Stat(out, "assets/WpMissing/home.png"); err == nil {
+		t.Error("Expected screenshots of unknown objects not to be copied")
+	}
+}
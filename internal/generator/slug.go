@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// slugify turns an object name into a URL-friendly file name: accents are
+// removed and anything but letters, digits, '_', '-' and '.' becomes a
+// dash. Names that are already safe are returned unchanged.
+func slugify(name string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'):
+			sb.WriteRune(r)
+			dash = false
+		case !dash && sb.Len() > 0:
+			sb.WriteRune('-')
+			dash = true
+		}
+	}
+	slug := strings.Trim(sb.String(), "-.")
+	if slug == "" {
+		return "object"
+	}
+	return slug
+}
+
+// reportPages are the fixed pages written to the output root, whose names
+// package indexes and root-level procedure pages must not take
+var reportPages = []string{
+	"CHANGELOG.md", "annotations.md", "api.md", "attributes.md", "badges.md",
+	"cheatsheet.md", "coverage.md", "datamodel.md", "dependencies.md",
+	"domains.md", "health.md", "layers.md", "migration.md",
+}
+
+// assignSlugs maps each package to its index page and each procedure to a
+// file name (without extension), unique within their folder even on
+// case-insensitive file systems. The README and reportPages are reserved, so
+// a package such as "api" gets "api-2.md" as its index. Procedures sharing a
+// name in different modules or folders are warned about and named after
+// their qualified name. Procedures are visited by name so suffixes don't
+// depend on the listing order.
+func (s *site) assignSlugs(procedures []model.GXObject) {
+	taken := map[string]bool{strings.ToLower(s.readme): true}
+	for _, page := range reportPages {
+		taken[strings.ToLower(page)] = true
+	}
+	s.packageFiles = make(map[string]string, len(s.packages))
+	for _, pkg := range s.packages {
+		file := pkg + ".md"
+		for n := 2; taken[strings.ToLower(file)]; n++ {
+			file = fmt.Sprintf("%s-%d.md", pkg, n)
+		}
+		if file != pkg+".md" {
+			utils.Warning("Package %s would overwrite the %s page; its index is named %s", pkg, pkg+".md", file)
+		}
+		taken[strings.ToLower(file)] = true
+		s.packageFiles[pkg] = file
+	}
+
+	sorted := append([]model.GXObject(nil), procedures...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].QualifiedName() < sorted[j].QualifiedName()
+	})
+
+	sameName := make(map[string][]string)
+	for _, proc := range sorted {
+		key := strings.ToLower(proc.Path)
+		sameName[key] = append(sameName[key], sourcePath(proc))
+	}
+
+	s.slugs = make(map[string]string, len(sorted))
+	for _, proc := range sorted {
+		if _, done := s.slugs[proc.QualifiedName()]; done {
+			continue
+		}
+		dir := ""
+		if pkg := s.procedurePackage(proc); pkg != "root" {
+			dir = pkg
+		}
+		base := slugify(proc.Path)
+		if duplicates := sameName[strings.ToLower(proc.Path)]; len(duplicates) > 1 {
+			if duplicates[0] == sourcePath(proc) {
+				utils.Warning("%d objects are named %s: %s; their pages are named after their module or folder",
+					len(duplicates), proc.Path, strings.Join(duplicates, ", "))
+			}
+			base = slugify(proc.QualifiedName())
+		}
+		slug := base
+		for n := 2; taken[strings.ToLower(path.Join(dir, slug+".md"))]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		taken[strings.ToLower(path.Join(dir, slug+".md"))] = true
+		s.slugs[proc.QualifiedName()] = slug
+	}
+}
+
+// sourcePath returns where an object lives in the KB, e.g. "Sales/GetUser"
+func sourcePath(obj model.GXObject) string {
+	if obj.Parent == "" {
+		return obj.Path
+	}
+	return obj.Parent + "/" + obj.Path
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"GetUser":          "GetUser",
+		"Get_User.v2":      "Get_User.v2",
+		"Órden de Compra":  "Orden-de-Compra",
+		"Sales/Report (1)": "Sales-Report-1",
+		"  ??  ":           "object",
+	}
+	for name, expected := range tests {
+		if slug := slugify(name); slug != expected {
+			t.Errorf("slugify(%q) = %q, expected %q", name, slug, expected)
+		}
+	}
+}
+
+func TestGenerateDocsUniqueFileNames(t *testing.T) {
+	billing := &model.DocComment{Package: "Billing"}
+	objects := []model.GXObject{
+		{Name: "GetUser", Path: "GetUser", Type: "Procedure", Documentation: billing},
+		{Name: "getuser", Path: "getuser", Type: "Procedure", Documentation: billing},
+		{Name: "Billing", Path: "Billing", Type: "Procedure"},
+		{Name: "Cálculo Total", Path: "Cálculo Total", Type: "Procedure", Documentation: billing},
+	}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"Billing/GetUser.md", "Billing/getuser-2.md", "Billing-2.md", "Billing.md", "Billing/Calculo-Total.md"} {
+		if _, err := fs.Stat(out, name); err != nil {
+			t.Errorf("Expected %s: %v", name, err)
+		}
+	}
+	index, _ := fs.ReadFile(out, "Billing.md")
+	if !strings.Contains(string(index), "[Cálculo Total](./Billing/Calculo-Total.md)") {
+		t.Errorf("Expected the package index to link to the slugged page:\n%s", index)
+	}
+}
+
+func TestGenerateDocsSameNameInOtherModules(t *testing.T) {
+	doc := &model.DocComment{Package: "Users"}
+	objects := []model.GXObject{
+		{Name: "GetUser", Path: "GetUser", Parent: "Users", Type: "Procedure", Documentation: doc},
+		{Name: "GetUser", Path: "GetUser", Parent: "Admin", Type: "Procedure", Documentation: doc},
+	}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"Users/Users.GetUser.md", "Users/Admin.GetUser.md"} {
+		if _, err := fs.Stat(out, name); err != nil {
+			t.Errorf("Expected %s: %v", name, err)
+		}
+	}
+}
+
+func TestGenerateDocsReservedReportPages(t *testing.T) {
+	objects := []model.GXObject{
+		{Name: "ListOrders", Path: "ListOrders", Type: "Procedure", IsMain: true, Documentation: &model.DocComment{Package: "api"}},
+		{Name: "health", Path: "health", Type: "Procedure"},
+	}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out}); err != nil {
+		t.Fatal(err)
+	}
+
+	index, _ := fs.ReadFile(out, "api-2.md")
+	if !strings.Contains(string(index), "[ListOrders](./api/ListOrders.md)") {
+		t.Errorf("Expected the api package index in api-2.md:\n%s", index)
+	}
+	if api, _ := fs.ReadFile(out, "api.md"); !strings.HasPrefix(string(api), "# Public API") {
+		t.Errorf("Expected api.md to stay the Public API page:\n%s", api)
+	}
+	readme, _ := fs.ReadFile(out, "KB.md")
+	if !strings.Contains(string(readme), "[api](./api-2.md)") {
+		t.Errorf("Expected the README to link to the renamed package index:\n%s", readme)
+	}
+	page, _ := fs.ReadFile(out, "api/ListOrders.md")
+	if !strings.Contains(string(page), "(../api-2.md)") {
+		t.Errorf("Expected the procedure page to link to the renamed package index:\n%s", page)
+	}
+	if _, err := fs.Stat(out, "health-2.md"); err != nil {
+		t.Errorf("Expected the health procedure page renamed clear of health.md: %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// Sort modes for object listings
+const (
+	SortByName    = "name"
+	SortByType    = "type"
+	SortByPackage = "package"
+)
+
+// LocaleBytes is the Options.Locale value that keeps plain byte order, where
+// accented and lowercase names sort after 'Z'
+const LocaleBytes = "C"
+
+// collation orders names in alphabetical listings. A nil collation compares
+// bytes.
+type collation struct {
+	// mu guards collator, whose buffers are not safe for concurrent use
+	mu       sync.Mutex
+	collator *collate.Collator
+}
+
+// newCollation returns the collation for a BCP 47 locale such as "pt-BR" or
+// "es". An empty locale uses the language-neutral root order, in which
+// accented letters sort next to their base letter.
+func newCollation(locale string) (*collation, error) {
+	if locale == LocaleBytes {
+		return nil, nil
+	}
+	tag := language.Und
+	if locale != "" {
+		var err error
+		if tag, err = language.Parse(locale); err != nil {
+			return nil, fmt.Errorf("invalid locale %q: %w", locale, err)
+		}
+	}
+	return &collation{collator: collate.New(tag)}, nil
+}
+
+// less reports whether a sorts before b. Names the collation considers equal
+// fall back to byte order so the output is identical between runs.
+func (c *collation) less(a, b string) bool {
+	if c != nil && a != b {
+		c.mu.Lock()
+		cmp := c.collator.CompareString(a, b)
+		c.mu.Unlock()
+		if cmp != 0 {
+			return cmp < 0
+		}
+	}
+	return a < b
+}
+
+// sortStrings orders names in place
+func (c *collation) sortStrings(names []string) {
+	sort.SliceStable(names, func(i, j int) bool {
+		return c.less(names[i], names[j])
+	})
+}
+
+// sortObjects orders objects in place. All modes fall back to the object name
+// so the output is identical between runs.
+func sortObjects(objects []model.GXObject, mode string, order *collation) {
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		switch mode {
+		case SortByType:
+			if a.Type != b.Type {
+				return order.less(a.Type, b.Type)
+			}
+		case SortByPackage:
+			if pa, pb := objectPackage(a), objectPackage(b); pa != pb {
+				return order.less(pa, pb)
+			}
+		}
+		return order.less(a.Path, b.Path)
+	})
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys[V any](m map[string]V, order *collation) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	order.sortStrings(keys)
+	return keys
+}
+
+// objectPackage returns the documented package of an object, or "" if none
+func objectPackage(obj model.GXObject) string {
+	if obj.Documentation != nil {
+		return obj.Documentation.Package
+	}
+	return ""
+}
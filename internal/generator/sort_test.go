@@ -0,0 +1,69 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestSortObjects(t *testing.T) {
+	newObj := func(name, objType, pkg string) model.GXObject {
+		return model.GXObject{Path: name, Type: objType, Documentation: &model.DocComment{Package: pkg}}
+	}
+	objects := []model.GXObject{
+		newObj("Zeta", "Procedure", "billing"),
+		newObj("Alpha", "Transaction", "users"),
+		newObj("Beta", "Procedure", "users"),
+	}
+
+	tests := []struct {
+		mode     string
+		expected []string
+	}{
+		{SortByName, []string{"Alpha", "Beta", "Zeta"}},
+		{SortByType, []string{"Beta", "Zeta", "Alpha"}},
+		{SortByPackage, []string{"Zeta", "Alpha", "Beta"}},
+	}
+
+	for _, tt := range tests {
+		sorted := append([]model.GXObject(nil), objects...)
+		sortObjects(sorted, tt.mode, nil)
+		for i, name := range tt.expected {
+			if sorted[i].Path != name {
+				t.Errorf("Mode %s: expected %v at position %d, got %s", tt.mode, name, i, sorted[i].Path)
+			}
+		}
+	}
+}
+
+func TestSortObjectsLocale(t *testing.T) {
+	objects := []model.GXObject{{Path: "Zeta"}, {Path: "Órdenes"}, {Path: "Beta"}, {Path: "Ágil"}, {Path: "Ñandú"}, {Path: "Nube"}, {Path: "Oca"}}
+
+	tests := []struct {
+		locale   string
+		expected []string
+	}{
+		{"pt-BR", []string{"Ágil", "Beta", "Ñandú", "Nube", "Oca", "Órdenes", "Zeta"}},
+		// Spanish sorts Ñ as a letter of its own, after N
+		{"es", []string{"Ágil", "Beta", "Nube", "Ñandú", "Oca", "Órdenes", "Zeta"}},
+		{LocaleBytes, []string{"Beta", "Nube", "Oca", "Zeta", "Ágil", "Ñandú", "Órdenes"}},
+	}
+
+	for _, tt := range tests {
+		order, err := newCollation(tt.locale)
+		if err != nil {
+			t.Fatalf("Locale %s: %v", tt.locale, err)
+		}
+		sorted := append([]model.GXObject(nil), objects...)
+		sortObjects(sorted, SortByName, order)
+		for i, name := range tt.expected {
+			if sorted[i].Path != name {
+				t.Errorf("Locale %s: expected %s at position %d, got %s", tt.locale, name, i, sorted[i].Path)
+			}
+		}
+	}
+
+	if _, err := newCollation("not a locale!"); err == nil {
+		t.Error("Expected an error for an invalid locale")
+	}
+}
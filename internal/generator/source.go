@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// sourceSection renders the procedure source in a collapsible block.
+// Returns "" when the procedure has no source.
+func sourceSection(proc model.GXObject, s *site) string {
+	if strings.TrimSpace(proc.SourceCode) == "" {
+		return ""
+	}
+	source := strings.ReplaceAll(proc.SourceCode, "\r\n", "\n")
+	fence := codeFence(source)
+
+	var sb strings.Builder
+	sb.WriteString("## " + s.text.T("Source Code") + "\n\n")
+	sb.WriteString("<details>\n<summary>" + s.text.T("Show source") + "</summary>\n\n")
+	sb.WriteString(fence + "genexus\n" + source + "\n" + fence + "\n\n")
+	sb.WriteString("</details>\n\n")
+	return sb.String()
+}
+
+// codeFence returns a backtick fence longer than any backtick run in text,
+// so the code block cannot be closed early by the source itself
+func codeFence(text string) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+	if longest < 3 {
+		return "```"
+	}
+	return strings.Repeat("`", longest+1)
+}
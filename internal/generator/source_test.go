@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestSourceSection(t *testing.T) {
+	s := &site{}
+	if got := sourceSection(model.GXObject{}, s); got != "" {
+		t.Errorf("Expected no section without source, got %q", got)
+	}
+
+	proc := model.GXObject{SourceCode: "&Msg = '```'\r\nmsg(&Msg)"}
+	got := sourceSection(proc, s)
+	if !strings.Contains(got, "<details>") || !strings.Contains(got, "````genexus\n&Msg = '```'\nmsg(&Msg)\n````\n") {
+		t.Errorf("Unexpected source section:\n%s", got)
+	}
+}
+
+func TestGenerateDocsExampleWithFence(t *testing.T) {
+	doc := &model.DocComment{Examples: []string{"&Msg = '\n```\n<script>alert(1)</script>'\nmsg(&Msg)"}}
+	objects := []model.GXObject{{Name: "Notify", Path: "Notify", Type: "Procedure", Documentation: doc}}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	page, _ := fs.ReadFile(out, "Notify.md")
+	want := "````genexus\n&Msg = '\n```\n<script>alert(1)</script>'\nmsg(&Msg)\n````\n"
+	if !strings.Contains(string(page), want) {
+		t.Errorf("Expected the example in a fence longer than its own:\n%s", page)
+	}
+}
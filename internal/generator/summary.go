@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"encoding/json"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// Summary is the machine-readable result of a generation run, written to
+// summary.json for CI and trend dashboards
+type Summary struct {
+	KB         string  `json:"kb"`
+	Version    string  `json:"version"`
+	Objects    int     `json:"objects"`
+	Procedures int     `json:"procedures"`
+	Documented int     `json:"documented"`
+	Coverage   float64 `json:"coverage"`
+	Warnings   int     `json:"warnings"`
+	Errors     int     `json:"errors"`
+
+	// Skipped lists objects left out by size, count or time limits
+	Skipped []model.SkippedObject `json:"skipped"`
+
+	// Failures lists pages replaced by an error placeholder
+	Failures []PageFailure `json:"failures"`
+
+	// UnknownTypes lists object types left out because their GUID is not
+	// mapped to a type
+	UnknownTypes []model.UnknownType `json:"unknownTypes"`
+
+	Health Health `json:"health"`
+}
+
+// writeSummary writes summary.json to the output directory
+func writeSummary(summary Summary, s *site) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+	return s.out.WriteFile("summary.json", append(data, '\n'))
+}
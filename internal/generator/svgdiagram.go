@@ -0,0 +1,185 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// diagramDir holds the pre-rendered diagrams, relative to the output root
+const diagramDir = "diagrams"
+
+// diagramRenderer turns Mermaid sources into SVG files with an external
+// command (Options.DiagramCommand). Identical diagrams are rendered once;
+// different ones render concurrently.
+type diagramRenderer struct {
+	command []string
+
+	mu sync.Mutex
+	// diagrams maps source hashes to their rendering, started by the first
+	// page that needs it and waited on by the others
+	diagrams map[string]*renderedDiagram
+}
+
+// renderedDiagram is the rendering of one diagram; done is closed once
+// file is set
+type renderedDiagram struct {
+	done chan struct{}
+	// file is the written SVG path, or "" when the diagram failed to render
+	file string
+}
+
+// newDiagramRenderer returns nil when no command is configured
+func newDiagramRenderer(command string) (*diagramRenderer, error) {
+	args, err := splitCommand(command)
+	if err != nil {
+		return nil, fmt.Errorf("invalid diagram command: %w", err)
+	}
+	if len(args) == 0 {
+		return nil, nil
+	}
+	return &diagramRenderer{command: args, diagrams: make(map[string]*renderedDiagram)}, nil
+}
+
+// splitCommand splits a command line into arguments at unquoted whitespace.
+// Single or double quotes group an argument holding spaces, such as a path
+// under "C:\Program Files"; backslashes are kept as written.
+func splitCommand(command string) ([]string, error) {
+	var args []string
+	var arg strings.Builder
+	inArg := false
+	var quote rune
+	for _, r := range command {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+// diagram returns a fenced Mermaid block for a page or, when diagrams are
+// pre-rendered, an image of its SVG. A diagram that fails to render is kept
+// as Mermaid source.
+func (s *site) diagram(block, alt, page string) string {
+	if s.diagrams == nil || block == "" {
+		return block
+	}
+	source := strings.TrimSuffix(strings.TrimPrefix(block, "```mermaid\n"), "```\n")
+	file := s.diagrams.render(source, s)
+	if file == "" {
+		return block
+	}
+	return fmt.Sprintf("![%s](%s%s)\n", alt, pathToRoot(page), file)
+}
+
+// render returns the path of the SVG rendered from source, or "" on failure
+func (r *diagramRenderer) render(source string, s *site) string {
+	sum := sha256.Sum256([]byte(source))
+	hash := hex.EncodeToString(sum[:])[:16]
+
+	// The lock only guards the map, so the command runs unlocked
+	r.mu.Lock()
+	d, started := r.diagrams[hash]
+	if !started {
+		d = &renderedDiagram{done: make(chan struct{})}
+		r.diagrams[hash] = d
+	}
+	r.mu.Unlock()
+	if started {
+		<-d.done
+		return d.file
+	}
+	defer close(d.done)
+
+	file := ""
+	svg, err := r.run(source)
+	if err == nil {
+		file = diagramDir + "/" + hash + ".svg"
+		err = s.out.WriteFile(file, svg)
+	}
+	if err != nil {
+		utils.Warning("Failed to pre-render diagram %s, keeping Mermaid source: %v", hash, err)
+		file = ""
+	}
+	d.file = file
+	return file
+}
+
+// run executes the command on source. {input} and {output} in its arguments
+// are replaced by temporary file paths; without them the source is written
+// to stdin and the SVG read from stdout.
+func (r *diagramRenderer) run(source string) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "gxdocgen-diagram")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	input := filepath.Join(dir, "diagram.mmd")
+	output := filepath.Join(dir, "diagram.svg")
+	if err := os.WriteFile(input, []byte(source), 0644); err != nil {
+		return nil, err
+	}
+
+	usesInput, usesOutput := false, false
+	args := make([]string, len(r.command))
+	for i, arg := range r.command {
+		usesInput = usesInput || strings.Contains(arg, "{input}")
+		usesOutput = usesOutput || strings.Contains(arg, "{output}")
+		args[i] = strings.NewReplacer("{input}", input, "{output}", output).Replace(arg)
+	}
+
+	cmd := exec.Command(args[0], args[1:]...)
+	if !usesInput {
+		cmd.Stdin = strings.NewReader(source)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%w: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	svg := stdout.Bytes()
+	if usesOutput {
+		if svg, err = os.ReadFile(output); err != nil {
+			return nil, err
+		}
+	}
+	if !bytes.Contains(svg, []byte("<svg")) {
+		return nil, fmt.Errorf("%s did not produce an SVG", args[0])
+	}
+	return svg, nil
+}
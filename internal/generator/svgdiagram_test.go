@@ -0,0 +1,124 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+)
+
+func TestGenerateDocsPreRenderedDiagrams(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	// The fake renderer counts its runs and writes a fixed SVG
+	dir := t.TempDir()
+	script := filepath.Join(dir, "render.sh")
+	os.WriteFile(script, []byte("echo run >> "+filepath.Join(dir, "runs")+"\necho '<svg xmlns=\"http://www.w3.org/2000/svg\"/>' > \"$2\"\n"), 0755)
+
+	objects := []model.GXObject{
+		{Path: "Main", Type: "Procedure", SourceCode: "Helper.Call()"},
+		{Path: "Helper", Type: "Procedure"},
+	}
+	out := storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out, DiagramCommand: "sh " + script + " {input} {output}"}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+
+	page, _ := fs.ReadFile(out, "Main.md")
+	if strings.Contains(string(page), "```mermaid") || !strings.Contains(string(page), "![Call Graph](./diagrams/") {
+		t.Errorf("Expected the call graph as an SVG image:\n%s", page)
+	}
+	svgs, _ := fs.Glob(out, "diagrams/*.svg")
+	runs, _ := os.ReadFile(filepath.Join(dir, "runs"))
+	// The call graphs of Main and Helper and the dependency graph
+	if len(svgs) != 3 || strings.Count(string(runs), "run") != 3 {
+		t.Errorf("Expected 3 diagrams rendered once each, got %v after %d run(s)", svgs, strings.Count(string(runs), "run"))
+	}
+
+	// A failing renderer keeps the Mermaid source
+	out = storage.NewMemory()
+	if err := GenerateDocs(objects, "KB", "", Options{Storage: out, DiagramCommand: "sh -c false"}); err != nil {
+		t.Fatalf("GenerateDocs failed: %v", err)
+	}
+	if page, _ := fs.ReadFile(out, "Main.md"); !strings.Contains(string(page), "```mermaid") {
+		t.Errorf("Expected Mermaid source when rendering fails:\n%s", page)
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []string
+	}{
+		{"mmdc -i {input} -o {output}", []string{"mmdc", "-i", "{input}", "-o", "{output}"}},
+		{`"C:\Program Files\mmdc.cmd" -i {input}`, []string{`C:\Program Files\mmdc.cmd`, "-i", "{input}"}},
+		{`sh -c 'mmdc -i "$0" -o out.svg' {input}`, []string{"sh", "-c", `mmdc -i "$0" -o out.svg`, "{input}"}},
+		{`tool --title="" x`, []string{"tool", "--title=", "x"}},
+		{"  ", nil},
+	}
+	for _, tt := range tests {
+		got, err := splitCommand(tt.command)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("splitCommand(%q) = %q, %v; want %q", tt.command, got, err, tt.want)
+		}
+	}
+
+	if _, err := splitCommand(`mmdc -i "{input}`); err == nil {
+		t.Error("Expected an error for an unterminated quote")
+	}
+	if err := GenerateDocs(nil, "KB", "", Options{Storage: storage.NewMemory(), DiagramCommand: `mmdc "-i`}); err == nil {
+		t.Error("Expected GenerateDocs to reject an invalid diagram command")
+	}
+}
+
+func TestDiagramRendererConcurrent(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh is not available")
+	}
+	// The fake renderer, under a path with a space, only succeeds when the
+	// other diagram is rendering at the same time
+	dir := filepath.Join(t.TempDir(), "my tools")
+	os.MkdirAll(filepath.Join(dir, "running"), 0755)
+	script := filepath.Join(dir, "render.sh")
+	os.WriteFile(script, []byte(`cd "$(dirname "$0")"
+echo run >> runs
+touch running/$$
+n=0
+while [ "$(ls running | wc -l)" -lt 2 ] && [ $n -lt 100 ]; do sleep 0.05; n=$((n+1)); done
+[ "$(ls running | wc -l)" -ge 2 ] && echo '<svg/>'
+`), 0755)
+
+	r, err := newDiagramRenderer(`sh "` + script + `"`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &site{out: storage.NewMemory()}
+	sources := []string{"graph TD\n  A-->B\n", "graph TD\n  C-->D\n"}
+	files := make([]string, 6)
+	var wg sync.WaitGroup
+	for i := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			files[i] = r.render(sources[i%2], s)
+		}()
+	}
+	wg.Wait()
+
+	for i, file := range files {
+		if file == "" || file != files[i%2] {
+			t.Errorf("Expected diagram %d rendered, got %q", i, file)
+		}
+	}
+	if runs, _ := os.ReadFile(filepath.Join(dir, "runs")); strings.Count(string(runs), "run") != 2 {
+		t.Errorf("Expected each diagram rendered once, got %d run(s)", strings.Count(string(runs), "run"))
+	}
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// standardVariables are declared by GeneXus in every object and add nothing
+// to a procedure page
+var standardVariables = map[string]bool{
+	"pgmname": true,
+	"pgmdesc": true,
+	"today":   true,
+	"time":    true,
+}
+
+// localVariables returns the variables a procedure declares besides its
+// parameters and the GeneXus standard variables
+func localVariables(proc model.GXObject) []model.Variable {
+	params := make(map[string]bool)
+	for _, param := range proc.Parameters {
+		params[strings.ToLower(param.Name)] = true
+	}
+	if proc.Documentation != nil {
+		for _, param := range proc.Documentation.Parameters {
+			params[strings.ToLower(param.Name)] = true
+		}
+	}
+
+	var locals []model.Variable
+	for _, v := range proc.Variables {
+		name := strings.ToLower(v.Name)
+		if params[name] || standardVariables[name] {
+			continue
+		}
+		locals = append(locals, v)
+	}
+	return locals
+}
+
+// localVariablesSection renders the "Local Variables" table of a procedure
+// page. Returns "" when the procedure declares no local variables.
+func localVariablesSection(proc model.GXObject, s *site) string {
+	locals := localVariables(proc)
+	if len(locals) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## " + s.text.T("Local Variables") + "\n\n")
+	sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+		s.text.T("Name"), s.text.T("Type"), s.text.T("Collection"), s.text.T("Description")))
+	sb.WriteString("|------|------|------------|-------------|\n")
+	for _, v := range locals {
+		varType := v.Type
+		if varType == "" {
+			varType = "-"
+		}
+		collection := "-"
+		if v.IsCollection {
+			collection = s.text.T("Yes")
+		}
+		desc := v.Description
+		if desc == "" {
+			desc = "-"
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			escapeTableCell(v.Name), escapeTableCell(s.dataType(varType)), collection, escapeTableCell(desc)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
@@ -0,0 +1,32 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestLocalVariablesSection(t *testing.T) {
+	proc := model.GXObject{
+		Parameters: []model.ParameterDoc{{Name: "OrderId", Direction: "IN"}},
+		Variables: []model.Variable{
+			{Name: "OrderId", Type: "Numeric"},
+			{Name: "Pgmname", Type: "Character"},
+			{Name: "Lines", Type: "sdtOrderLine", Description: "Order | lines", IsCollection: true},
+			{Name: "Total", Type: "Numeric"},
+		},
+	}
+
+	got := localVariablesSection(proc, &site{})
+	if strings.Contains(got, "OrderId") || strings.Contains(got, "Pgmname") {
+		t.Errorf("Expected parameters and standard variables to be left out:\n%s", got)
+	}
+	if !strings.Contains(got, "| Lines | sdtOrderLine | Yes | Order \\| lines |") || !strings.Contains(got, "| Total | Numeric | - | - |") {
+		t.Errorf("Unexpected section:\n%s", got)
+	}
+
+	if got := localVariablesSection(model.GXObject{Parameters: proc.Parameters, Variables: proc.Variables[:2]}, &site{}); got != "" {
+		t.Errorf("Expected no section without local variables, got %q", got)
+	}
+}
@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Invocation is a single call of an object found in source
+type Invocation struct {
+	// Args is the number of arguments passed, excluding the object name of
+	// Call(Name, ...) and Udp(Name, ...)
+	Args int
+
+	// Returns is true for function-style calls (Udp, or &Var = Name(...)),
+	// which receive the last Parm() parameter as their result
+	Returns bool
+}
+
+// Invocations returns every call of the named object in source, in order of
+// appearance. Calls with unbalanced parentheses are skipped.
+func Invocations(source, name string) []Invocation {
+	source = StripComments(source)
+	quoted := regexp.QuoteMeta(name)
+	methodRegex := regexp.MustCompile(`(?i)(?:^|[^&\w.])(?:\w+\.)*` + quoted + `\.(call|udp)\s*\(`)
+	funcRegex := regexp.MustCompile(`(?i)\b(call|udp)\s*\(\s*'?(?:\w+\.)*` + quoted + `'?\s*[,)]`)
+	directRegex := regexp.MustCompile(`(?i)(?:^|[^&\w.])(?:\w+\.)*` + quoted + `\s*\(`)
+
+	type found struct {
+		pos int
+		Invocation
+	}
+	var calls []found
+	for _, m := range methodRegex.FindAllStringSubmatchIndex(source, -1) {
+		if args, ok := countArguments(source[m[1]:]); ok {
+			calls = append(calls, found{m[0], Invocation{Args: args, Returns: strings.EqualFold(source[m[2]:m[3]], "udp")}})
+		}
+	}
+	for _, m := range funcRegex.FindAllStringSubmatchIndex(source, -1) {
+		open := m[3] + strings.Index(source[m[3]:], "(") + 1
+		if args, ok := countArguments(source[open:]); ok {
+			calls = append(calls, found{m[0], Invocation{Args: args - 1, Returns: strings.EqualFold(source[m[2]:m[3]], "udp")}})
+		}
+	}
+	for _, m := range directRegex.FindAllStringIndex(source, -1) {
+		if args, ok := countArguments(source[m[1]:]); ok {
+			lineStart := strings.LastIndex(source[:m[0]+1], "\n") + 1
+			returns := strings.Contains(source[lineStart:m[0]+1], "=")
+			calls = append(calls, found{m[0], Invocation{Args: args, Returns: returns}})
+		}
+	}
+
+	sort.SliceStable(calls, func(i, j int) bool { return calls[i].pos < calls[j].pos })
+	invocations := make([]Invocation, len(calls))
+	for i, call := range calls {
+		invocations[i] = call.Invocation
+	}
+	return invocations
+}
+
+// countArguments counts the top-level arguments of an argument list, given
+// the text following its opening parenthesis. String literals and nested
+// parentheses are skipped; ok is false when the list is never closed.
+func countArguments(text string) (count int, ok bool) {
+	depth, empty := 0, true
+	var quote rune
+	for _, r := range text {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+			continue
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			if depth == 0 {
+				if empty {
+					return 0, true
+				}
+				return count + 1, true
+			}
+			depth--
+		case r == ',' && depth == 0:
+			count++
+		}
+		if !strings.ContainsRune(" \t\r\n", r) {
+			empty = false
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,190 @@
+package graph
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// Pre-compiled regular expressions for call detection
+var (
+	// Call(ProcName, ...) / Call('ProcName', ...)
+	callFuncRegex = regexp.MustCompile(`(?i)\bcall\s*\(\s*'?([A-Za-z_][\w.]*)'?`)
+	// Udp(ProcName, ...) / Udp('ProcName', ...)
+	udpFuncRegex = regexp.MustCompile(`(?i)\budp\s*\(\s*'?([A-Za-z_][\w.]*)'?`)
+	// ProcName.Call(...) / Module.ProcName.Udp(...), excluding &Variable.Call
+	methodCallRegex = regexp.MustCompile(`(?i)(?:^|[^&\w.])([A-Za-z_][\w.]*)\.(?:call|udp)\s*\(`)
+)
+
+// Graph holds call relationships between GeneXus objects
+type Graph struct {
+	callees map[string][]string
+	callers map[string][]string
+}
+
+// Build extracts call references from every object's source code and links
+// them to objects present in the export. References to unknown objects are dropped.
+func Build(objects []model.GXObject) *Graph {
+	g := &Graph{
+		callees: make(map[string][]string),
+		callers: make(map[string][]string),
+	}
+
+	known := make(map[string]bool)
+	for _, obj := range objects {
+		known[obj.Path] = true
+	}
+
+	for _, obj := range objects {
+		for _, callee := range ExtractCalls(obj.SourceCode) {
+			if !known[callee] || callee == obj.Path {
+				continue
+			}
+			g.callees[obj.Path] = append(g.callees[obj.Path], callee)
+			g.callers[callee] = append(g.callers[callee], obj.Path)
+		}
+	}
+
+	for name := range g.callers {
+		sort.Strings(g.callers[name])
+	}
+
+	return g
+}
+
+// Callees returns the objects called by the named object, in source order
+func (g *Graph) Callees(name string) []string {
+	if g == nil {
+		return nil
+	}
+	return g.callees[name]
+}
+
+// Callers returns the objects that call the named object, sorted by name
+func (g *Graph) Callers(name string) []string {
+	if g == nil {
+		return nil
+	}
+	return g.callers[name]
+}
+
+// ExtractCalls returns the unique names of objects invoked through
+// Call()/Udp()/.Call()/.Udp() in the given source, in order of appearance.
+// Module-qualified names (Module.ProcName) are reduced to the object name.
+func ExtractCalls(source string) []string {
+	source = StripComments(source)
+
+	type match struct {
+		pos  int
+		name string
+	}
+	var matches []match
+	for _, re := range []*regexp.Regexp{callFuncRegex, udpFuncRegex, methodCallRegex} {
+		for _, m := range re.FindAllStringSubmatchIndex(source, -1) {
+			matches = append(matches, match{pos: m[2], name: source[m[2]:m[3]]})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].pos < matches[j].pos
+	})
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range matches {
+		name := m.name
+		if idx := strings.LastIndex(name, "."); idx != -1 {
+			name = name[idx+1:]
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// StripComments removes /* */ blocks and // line comments from GeneXus
+// source. Markers inside "..." and '...' literals, such as the slashes of a
+// URL, are not comments; literals end at the end of the line.
+func StripComments(source string) string {
+	var sb strings.Builder
+	sb.Grow(len(source))
+	var quote byte
+	for i := 0; i < len(source); i++ {
+		c := source[i]
+		switch {
+		case quote != 0:
+			if c == quote || c == '\n' {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(source[i:], "/*"):
+			if end := strings.Index(source[i+2:], "*/"); end != -1 {
+				i += end + 3
+				continue
+			}
+		case strings.HasPrefix(source[i:], "//"):
+			end := strings.IndexByte(source[i:], '\n')
+			if end == -1 {
+				return sb.String()
+			}
+			i += end - 1
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// Call site snippets are cut to this many characters
+const maxSnippetLength = 120
+
+// stringLiteralRegex matches "..." and '...' literals in GeneXus source
+var stringLiteralRegex = regexp.MustCompile(`"[^"\n]*"|'[^'\n]*'`)
+
+// CallSites returns the statements in source that invoke the named object,
+// sanitized (comments removed, string literals masked, whitespace collapsed)
+// and truncated for display
+func CallSites(source, name string) []string {
+	quoted := regexp.QuoteMeta(name)
+	callRegex := regexp.MustCompile(`(?i)(?:\b(?:call|udp)\s*\(\s*'?(?:[\w]+\.)*` + quoted + `'?\s*[,)]|(?:^|[^&\w.])(?:[\w]+\.)*` + quoted + `\.(?:call|udp)\s*\()`)
+
+	var sites []string
+	lines := strings.Split(StripComments(source), "\n")
+	for i := 0; i < len(lines); i++ {
+		if !callRegex.MatchString(lines[i]) {
+			continue
+		}
+
+		// Join continuation lines until the parentheses balance
+		statement := lines[i]
+		for strings.Count(statement, "(") > strings.Count(statement, ")") && i+1 < len(lines) {
+			i++
+			statement += " " + lines[i]
+		}
+		sites = append(sites, sanitizeSnippet(statement, name))
+	}
+	return sites
+}
+
+// sanitizeSnippet masks string literals (except a quoted object name),
+// collapses whitespace and truncates the statement
+func sanitizeSnippet(statement, name string) string {
+	statement = stringLiteralRegex.ReplaceAllStringFunc(statement, func(literal string) string {
+		if strings.EqualFold(literal[1:len(literal)-1], name) {
+			return literal
+		}
+		return literal[:1] + "..." + literal[:1]
+	})
+	statement = strings.Join(strings.Fields(statement), " ")
+
+	if runes := []rune(statement); len(runes) > maxSnippetLength {
+		statement = string(runes[:maxSnippetLength-3]) + "..."
+	}
+	return statement
+}
@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestExtractCalls(t *testing.T) {
+	source := `/**
+ * @summary Calls OldProc.Call() in a comment
+ */
+Call(LoadUser, &UserID)
+call('SendMail', &Email)
+&Total = CalcTotal.Udp(&UserID)
+&Ok = Udp(ValidateUser, &UserID)
+Billing.CreateInvoice.Call(&UserID)
+&Http.Call(&Url)        // IgnoredProc.Call()
+LoadUser.Call(&UserID)`
+
+	expected := []string{"LoadUser", "SendMail", "CalcTotal", "ValidateUser", "CreateInvoice"}
+	result := ExtractCalls(source)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ExtractCalls() = %v, expected %v", result, expected)
+	}
+}
+
+func TestStripComments(t *testing.T) {
+	source := `&Url = "https://example.com/api" // endpoint
+&Mask = '/*.txt' /* files */ + '*/'
+/* LoadUser.Call()
+ */&Msg = "don't" // it's fine
+// Removed.Call()
+&Path = 'C:/temp//x'`
+
+	expected := "&Url = \"https://example.com/api\" \n" +
+		"&Mask = '/*.txt'  + '*/'\n" +
+		"&Msg = \"don't\" \n" +
+		"\n" +
+		"&Path = 'C:/temp//x'"
+	if got := StripComments(source); got != expected {
+		t.Errorf("StripComments() = %q, expected %q", got, expected)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	objects := []model.GXObject{
+		{Path: "GetUser", SourceCode: "LoadUser.Call(&Id)\nAudit.Call()\nUnknown.Call()"},
+		{Path: "LoadUser", SourceCode: "Audit.Call()"},
+		{Path: "Audit", SourceCode: "Audit.Call()"},
+	}
+
+	g := Build(objects)
+
+	if callees := g.Callees("GetUser"); !reflect.DeepEqual(callees, []string{"LoadUser", "Audit"}) {
+		t.Errorf("Unexpected callees for GetUser: %v", callees)
+	}
+	if callers := g.Callers("Audit"); !reflect.DeepEqual(callers, []string{"GetUser", "LoadUser"}) {
+		t.Errorf("Unexpected callers for Audit: %v", callers)
+	}
+	if callees := g.Callees("Audit"); len(callees) != 0 {
+		t.Errorf("Expected self-calls to be ignored, got %v", callees)
+	}
+}
+
+func TestCallSites(t *testing.T) {
+	source := `// LoadUser.Call(&Ignored)
+LoadUser.Call(&UserID, "secret@example.com")
+Call(LoadUserDetails, &UserID)
+call('LoadUser',
+     &UserID,
+     &Name)
+Users.LoadUser.Udp(&UserID)
+&Http.LoadUser.Call()`
+
+	expected := []string{
+		`LoadUser.Call(&UserID, "...")`,
+		`call('LoadUser', &UserID, &Name)`,
+		`Users.LoadUser.Udp(&UserID)`,
+	}
+	result := CallSites(source, "LoadUser")
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("CallSites() = %q, expected %q", result, expected)
+	}
+}
+
+func TestCallSites_Truncates(t *testing.T) {
+	source := "LoadUser.Call(&" + strings.Repeat("A", 200) + ")"
+
+	sites := CallSites(source, "LoadUser")
+	if len(sites) != 1 || len([]rune(sites[0])) != maxSnippetLength || !strings.HasSuffix(sites[0], "...") {
+		t.Errorf("Expected one truncated snippet, got %q", sites)
+	}
+}
+
+func TestInvocations(t *testing.T) {
+	source := `GetUser.Call(&UserID, &User)
+&User = GetUser.Udp(&UserID)
+Call('GetUser', &UserID, Format("%1, %2", &A, &B))
+&User = GetUser(&UserID)
+GetUser()
+Users.GetUser.Call(
+	&UserID,
+	&User)
+&Other.GetUser(&UserID)  // GetUser.Call(&Ignored)
+GetUserName.Call(&UserID)`
+
+	expected := []Invocation{
+		{Args: 2},
+		{Args: 1, Returns: true},
+		{Args: 2},
+		{Args: 1, Returns: true},
+		{Args: 0},
+		{Args: 2},
+	}
+	if result := Invocations(source, "GetUser"); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Invocations() = %+v, expected %+v", result, expected)
+	}
+}
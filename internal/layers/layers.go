@@ -0,0 +1,161 @@
+// Package layers classifies objects into architecture layers (UI, Service,
+// Data, ...) by name and module, and finds calls that go against the
+// layering.
+package layers
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// Rule files the objects matching its patterns under Layer. Name and
+// Module are regular expressions on the object name and on its module or
+// folder; an empty pattern matches anything, and a rule with both set
+// needs both to match.
+type Rule struct {
+	Layer  string
+	Name   string
+	Module string
+}
+
+// Config lists the layers from top to bottom and the rules classifying
+// objects into them
+type Config struct {
+	// Order lists the layers from top (e.g. UI) to bottom (e.g. Data).
+	// Empty uses the order in which rules name them.
+	Order []string
+
+	// Rules are tried in order; the first match wins
+	Rules []Rule
+}
+
+// compiledRule is a Rule with its patterns compiled
+type compiledRule struct {
+	layer        string
+	name, module *regexp.Regexp
+}
+
+// Classifier assigns objects to layers
+type Classifier struct {
+	order []string
+	rules []compiledRule
+}
+
+// Compile validates cfg and returns its classifier, or nil when cfg has no
+// rules
+func Compile(cfg Config) (*Classifier, error) {
+	if len(cfg.Rules) == 0 {
+		return nil, nil
+	}
+	c := &Classifier{order: slices.Clone(cfg.Order)}
+	for i, rule := range cfg.Rules {
+		if rule.Layer == "" {
+			return nil, fmt.Errorf("layer rule %d has no layer", i+1)
+		}
+		if rule.Name == "" && rule.Module == "" {
+			return nil, fmt.Errorf("layer rule %d (%s) has neither a name nor a module pattern", i+1, rule.Layer)
+		}
+		compiled := compiledRule{layer: rule.Layer}
+		var err error
+		if compiled.name, err = compilePattern(rule.Name); err != nil {
+			return nil, fmt.Errorf("layer rule %d (%s): %w", i+1, rule.Layer, err)
+		}
+		if compiled.module, err = compilePattern(rule.Module); err != nil {
+			return nil, fmt.Errorf("layer rule %d (%s): %w", i+1, rule.Layer, err)
+		}
+		c.rules = append(c.rules, compiled)
+
+		if len(cfg.Order) == 0 && !slices.Contains(c.order, rule.Layer) {
+			c.order = append(c.order, rule.Layer)
+		} else if len(cfg.Order) > 0 && !slices.Contains(cfg.Order, rule.Layer) {
+			return nil, fmt.Errorf("layer rule %d names layer %s, which is not in the order", i+1, rule.Layer)
+		}
+	}
+	return c, nil
+}
+
+// compilePattern compiles a case-insensitive pattern; "" returns nil
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// Layers returns the layers from top to bottom
+func (c *Classifier) Layers() []string {
+	return c.order
+}
+
+// Layer returns the layer of obj, or "" when no rule matches
+func (c *Classifier) Layer(obj model.GXObject) string {
+	for _, rule := range c.rules {
+		if rule.name != nil && !rule.name.MatchString(obj.Path) {
+			continue
+		}
+		if rule.module != nil && !rule.module.MatchString(obj.Parent) {
+			continue
+		}
+		return rule.layer
+	}
+	return ""
+}
+
+// rank returns the position of a layer from the top
+func (c *Classifier) rank(layer string) int {
+	return slices.Index(c.order, layer)
+}
+
+// Dependency is a call between objects of two layers
+type Dependency struct {
+	From, FromLayer string
+	To, ToLayer     string
+}
+
+// Upward reports whether the call goes from a layer to one above it, e.g.
+// from Data to UI
+func (c *Classifier) Upward(d Dependency) bool {
+	return c.rank(d.FromLayer) > c.rank(d.ToLayer)
+}
+
+// Skipped returns the layers a downward call passes over, e.g. Service for
+// a call from UI to Data
+func (c *Classifier) Skipped(d Dependency) []string {
+	from, to := c.rank(d.FromLayer), c.rank(d.ToLayer)
+	if to-from < 2 {
+		return nil
+	}
+	return c.order[from+1 : to]
+}
+
+// Dependencies returns the calls between objects of different layers, in
+// object and source order. Called objects missing from objects, such as
+// panels, which are not extracted, are classified by name alone.
+func (c *Classifier) Dependencies(objects []model.GXObject) []Dependency {
+	byName := make(map[string]model.GXObject, len(objects))
+	for _, obj := range objects {
+		byName[obj.Path] = obj
+	}
+
+	var deps []Dependency
+	for _, obj := range objects {
+		from := c.Layer(obj)
+		if from == "" {
+			continue
+		}
+		for _, callee := range graph.ExtractCalls(obj.SourceCode) {
+			target, known := byName[callee]
+			if !known {
+				target = model.GXObject{Name: callee, Path: callee}
+			}
+			if to := c.Layer(target); to != "" && to != from && callee != obj.Path {
+				deps = append(deps, Dependency{From: obj.Path, FromLayer: from, To: callee, ToLayer: to})
+			}
+		}
+	}
+	return deps
+}
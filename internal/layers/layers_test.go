@@ -0,0 +1,111 @@
+package layers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func testConfig() Config {
+	return Config{
+		Order: []string{"UI", "Service", "Data"},
+		Rules: []Rule{
+			{Layer: "UI", Name: "^(Wp|Panel)"},
+			{Layer: "Data", Module: "^Persistence$"},
+			{Layer: "Data", Name: "^(Dp|Load|Save)"},
+			{Layer: "Service", Name: ".*"},
+		},
+	}
+}
+
+func TestClassifierLayer(t *testing.T) {
+	c, err := Compile(testConfig())
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	tests := []struct {
+		obj      model.GXObject
+		expected string
+	}{
+		{model.GXObject{Path: "WpCustomers"}, "UI"},
+		{model.GXObject{Path: "panelHome"}, "UI"},
+		{model.GXObject{Path: "Customer", Parent: "Persistence"}, "Data"},
+		{model.GXObject{Path: "LoadCustomer", Parent: "Sales"}, "Data"},
+		{model.GXObject{Path: "ComputeTotal", Parent: "Sales"}, "Service"},
+	}
+	for _, tt := range tests {
+		if layer := c.Layer(tt.obj); layer != tt.expected {
+			t.Errorf("Layer(%s) = %q, expected %q", tt.obj.Path, layer, tt.expected)
+		}
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	if c, err := Compile(Config{}); c != nil || err != nil {
+		t.Errorf("Expected no classifier without rules, got %v, %v", c, err)
+	}
+
+	tests := []struct {
+		rule     Rule
+		expected string
+	}{
+		{Rule{Name: "^Wp"}, "has no layer"},
+		{Rule{Layer: "UI"}, "neither a name nor a module pattern"},
+		{Rule{Layer: "UI", Name: "("}, "layer rule 1 (UI)"},
+		{Rule{Layer: "Batch", Name: "^Job"}, "not in the order"},
+	}
+	for _, tt := range tests {
+		_, err := Compile(Config{Order: []string{"UI", "Data"}, Rules: []Rule{tt.rule}})
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("Compile(%+v) error = %v, expected %q", tt.rule, err, tt.expected)
+		}
+	}
+
+	c, err := Compile(Config{Rules: []Rule{{Layer: "Service", Name: "^Svc"}, {Layer: "UI", Name: "^Wp"}, {Layer: "Service", Name: "^Api"}}})
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	if !reflect.DeepEqual(c.Layers(), []string{"Service", "UI"}) {
+		t.Errorf("Expected the layers in rule order without an explicit order, got %v", c.Layers())
+	}
+}
+
+func TestDependencies(t *testing.T) {
+	c, err := Compile(testConfig())
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	objects := []model.GXObject{
+		{Path: "WpCustomers", SourceCode: "ComputeTotal.Call()\nLoadCustomer.Call()"},
+		{Path: "ComputeTotal", SourceCode: "LoadCustomer.Call()\nHelper.Call()"},
+		{Path: "Helper"},
+		{Path: "LoadCustomer", SourceCode: "WpCustomers.Call()\nCall(PanelHome)"},
+	}
+
+	deps := c.Dependencies(objects)
+	expected := []Dependency{
+		{From: "WpCustomers", FromLayer: "UI", To: "ComputeTotal", ToLayer: "Service"},
+		{From: "WpCustomers", FromLayer: "UI", To: "LoadCustomer", ToLayer: "Data"},
+		{From: "ComputeTotal", FromLayer: "Service", To: "LoadCustomer", ToLayer: "Data"},
+		{From: "LoadCustomer", FromLayer: "Data", To: "WpCustomers", ToLayer: "UI"},
+		{From: "LoadCustomer", FromLayer: "Data", To: "PanelHome", ToLayer: "UI"},
+	}
+	if !reflect.DeepEqual(deps, expected) {
+		t.Fatalf("Unexpected dependencies:\n%+v", deps)
+	}
+
+	for i, upward := range []bool{false, false, false, true, true} {
+		if c.Upward(deps[i]) != upward {
+			t.Errorf("Upward(%+v) = %v, expected %v", deps[i], !upward, upward)
+		}
+	}
+	if skipped := c.Skipped(deps[1]); !reflect.DeepEqual(skipped, []string{"Service"}) {
+		t.Errorf("Expected UI -> Data to skip Service, got %v", skipped)
+	}
+	if skipped := c.Skipped(deps[0]); skipped != nil {
+		t.Errorf("Expected UI -> Service to skip nothing, got %v", skipped)
+	}
+}
@@ -0,0 +1,294 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/layers"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/parser"
+)
+
+// Severity controls how a rule finding is reported
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// Finding is a single problem reported by a rule
+type Finding struct {
+	Object   string
+	Rule     string
+	Severity Severity
+	Message  string
+}
+
+// Context gives rules access to the whole knowledge base being linted
+type Context struct {
+	// Objects indexes every extracted object by name
+	Objects map[string]model.GXObject
+
+	// Layers classifies objects into architecture layers; nil disables the
+	// layer rules
+	Layers *layers.Classifier
+
+	// dependencies lists the cross-layer calls of each object by name
+	dependencies map[string][]layers.Dependency
+}
+
+// Rule checks a single object against a documentation or architecture convention
+type Rule struct {
+	// Name identifies the rule in output and configuration (e.g., "missing-summary")
+	Name string
+
+	// Description explains what the rule checks
+	Description string
+
+	// Severity is the default severity when not overridden by configuration
+	Severity Severity
+
+	// check returns one message per problem found. raw holds the annotations
+	// exactly as written, before any fallback was applied during extraction.
+	check func(obj model.GXObject, raw *model.DocComment, ctx *Context) []string
+
+	// checkObject, set instead of check by architecture rules, runs on every
+	// object, documented or not, when layers are configured
+	checkObject func(obj model.GXObject, ctx *Context) []string
+}
+
+// Rules lists all available lint rules
+var Rules = []Rule{
+	{
+		Name:        "missing-summary",
+		Description: "Documented procedure has no @summary",
+		Severity:    SeverityWarning,
+		check:       checkMissingSummary,
+	},
+	{
+		Name:        "param-count-mismatch",
+		Description: "Number of @param tags differs from the Parm() declaration",
+		Severity:    SeverityError,
+		check:       checkParamCount,
+	},
+	{
+		Name:        "example-arg-mismatch",
+		Description: "@example calls the procedure with a different number of arguments than Parm() declares",
+		Severity:    SeverityWarning,
+		check:       checkExampleArguments,
+	},
+	{
+		Name:        "missing-return",
+		Description: "Procedure has OUT parameters but no @return",
+		Severity:    SeverityWarning,
+		check:       checkMissingReturn,
+	},
+	{
+		Name:        "stale-deprecated",
+		Description: "@deprecated has no note or points to an object missing from the export",
+		Severity:    SeverityWarning,
+		check:       checkStaleDeprecated,
+	},
+	{
+		Name:        "layer-upward-call",
+		Description: "Object calls an object in a higher architecture layer (e.g. Data calling UI)",
+		Severity:    SeverityError,
+		checkObject: checkUpwardCalls,
+	},
+	{
+		Name:        "layer-skip-call",
+		Description: "Object calls an object more than one architecture layer below it",
+		Severity:    SeverityOff,
+		checkObject: checkSkippedLayers,
+	},
+}
+
+// Options configures a lint run
+type Options struct {
+	// Overrides replaces the default severity of rules by name
+	Overrides map[string]Severity
+
+	// Layers, when set, enables the architecture layer rules
+	Layers *layers.Classifier
+}
+
+// ParseSeverity converts a configuration value into a Severity
+func ParseSeverity(value string) (Severity, error) {
+	switch Severity(strings.ToLower(strings.TrimSpace(value))) {
+	case SeverityOff:
+		return SeverityOff, nil
+	case SeverityWarning:
+		return SeverityWarning, nil
+	case SeverityError:
+		return SeverityError, nil
+	}
+	return "", fmt.Errorf("invalid severity %q (expected off, warning or error)", value)
+}
+
+// Run lints all procedures and returns findings sorted by object and rule.
+// overrides replaces the default severity of rules by name.
+func Run(objects []model.GXObject, overrides map[string]Severity) []Finding {
+	return RunWithOptions(objects, Options{Overrides: overrides})
+}
+
+// RunWithOptions lints all procedures and, with opts.Layers, the calls of
+// every object across architecture layers. Findings are sorted by object
+// and rule.
+func RunWithOptions(objects []model.GXObject, opts Options) []Finding {
+	ctx := &Context{Objects: make(map[string]model.GXObject), Layers: opts.Layers}
+	for _, obj := range objects {
+		ctx.Objects[obj.Path] = obj
+	}
+	if ctx.Layers != nil {
+		ctx.dependencies = make(map[string][]layers.Dependency)
+		for _, dep := range ctx.Layers.Dependencies(objects) {
+			ctx.dependencies[dep.From] = append(ctx.dependencies[dep.From], dep)
+		}
+	}
+
+	var findings []Finding
+	for _, obj := range objects {
+		var raw *model.DocComment
+		if obj.Type == "Procedure" && obj.IsDocumented() {
+			if parsed, err := parser.Parse(obj.SourceCode); err == nil {
+				raw = parsed
+			}
+		}
+
+		for _, rule := range Rules {
+			severity := rule.Severity
+			if override, ok := opts.Overrides[rule.Name]; ok {
+				severity = override
+			}
+			if severity == SeverityOff {
+				continue
+			}
+
+			var messages []string
+			switch {
+			case rule.checkObject != nil && ctx.Layers != nil:
+				messages = rule.checkObject(obj, ctx)
+			case rule.check != nil && raw != nil:
+				messages = rule.check(obj, raw, ctx)
+			}
+			for _, message := range messages {
+				findings = append(findings, Finding{
+					Object:   obj.Path,
+					Rule:     rule.Name,
+					Severity: severity,
+					Message:  message,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Object != findings[j].Object {
+			return findings[i].Object < findings[j].Object
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+
+	return findings
+}
+
+// checkMissingSummary flags annotation blocks without @summary
+func checkMissingSummary(obj model.GXObject, raw *model.DocComment, ctx *Context) []string {
+	if raw.Summary == "" {
+		return []string{"missing @summary"}
+	}
+	return nil
+}
+
+// checkParamCount compares the number of @param tags with the Parm() declaration
+func checkParamCount(obj model.GXObject, raw *model.DocComment, ctx *Context) []string {
+	if len(raw.Parameters) == 0 || len(raw.Parameters) == len(obj.Parameters) {
+		return nil
+	}
+	return []string{fmt.Sprintf("%d @param tag(s) but Parm() declares %d parameter(s)",
+		len(raw.Parameters), len(obj.Parameters))}
+}
+
+// checkExampleArguments flags calls of the documented procedure in @example
+// blocks whose argument count no longer matches the Parm() declaration.
+// Function-style calls receive the last parameter as their result.
+func checkExampleArguments(obj model.GXObject, raw *model.DocComment, ctx *Context) []string {
+	var messages []string
+	for _, example := range raw.Examples {
+		for _, call := range graph.Invocations(example, obj.Path) {
+			args := call.Args
+			if call.Returns {
+				args++
+			}
+			if args == len(obj.Parameters) {
+				continue
+			}
+			if call.Returns {
+				messages = append(messages, fmt.Sprintf("@example calls %s with %d argument(s) and a return value but Parm() declares %d parameter(s)",
+					obj.Path, call.Args, len(obj.Parameters)))
+			} else {
+				messages = append(messages, fmt.Sprintf("@example calls %s with %d argument(s) but Parm() declares %d parameter(s)",
+					obj.Path, call.Args, len(obj.Parameters)))
+			}
+		}
+	}
+	return messages
+}
+
+// checkMissingReturn flags procedures returning values through OUT parameters without @return
+func checkMissingReturn(obj model.GXObject, raw *model.DocComment, ctx *Context) []string {
+	if raw.Return != "" {
+		return nil
+	}
+	for _, param := range obj.Parameters {
+		if param.Direction == "OUT" || param.Direction == "INOUT" {
+			return []string{"has " + param.Direction + " parameter '" + param.Name + "' but no @return"}
+		}
+	}
+	return nil
+}
+
+// checkStaleDeprecated flags deprecations without guidance or with a missing replacement
+func checkStaleDeprecated(obj model.GXObject, raw *model.DocComment, ctx *Context) []string {
+	if !raw.Deprecated {
+		return nil
+	}
+	if raw.DeprecationNote == "" {
+		return []string{"@deprecated without a note explaining what to use instead"}
+	}
+	replacement := parser.ParseReplacement(raw.DeprecationNote)
+	if replacement == "" {
+		return nil
+	}
+	if _, exists := ctx.Objects[replacement]; !exists {
+		return []string{"@deprecated points to '" + replacement + "', which is not in the export"}
+	}
+	return nil
+}
+
+// checkUpwardCalls flags calls to objects in a higher layer
+func checkUpwardCalls(obj model.GXObject, ctx *Context) []string {
+	var messages []string
+	for _, dep := range ctx.dependencies[obj.Path] {
+		if ctx.Layers.Upward(dep) {
+			messages = append(messages, fmt.Sprintf("%s object calls %s in the higher %s layer", dep.FromLayer, dep.To, dep.ToLayer))
+		}
+	}
+	return messages
+}
+
+// checkSkippedLayers flags calls that bypass the layers in between
+func checkSkippedLayers(obj model.GXObject, ctx *Context) []string {
+	var messages []string
+	for _, dep := range ctx.dependencies[obj.Path] {
+		if skipped := ctx.Layers.Skipped(dep); len(skipped) > 0 {
+			messages = append(messages, fmt.Sprintf("%s object calls %s in the %s layer, bypassing %s",
+				dep.FromLayer, dep.To, dep.ToLayer, strings.Join(skipped, ", ")))
+		}
+	}
+	return messages
+}
@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/layers"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func newProcedure(name, source string, params []model.ParameterDoc) model.GXObject {
+	return model.GXObject{
+		Name:          name,
+		Type:          "Procedure",
+		Path:          name,
+		SourceCode:    source,
+		Parameters:    params,
+		Documentation: &model.DocComment{},
+	}
+}
+
+func TestRun_ReportsFindings(t *testing.T) {
+	objects := []model.GXObject{
+		newProcedure("GetUser", `/**
+ * @param UserID IN Numeric - The id
+ */
+&User.Load(&UserID)`, []model.ParameterDoc{
+			{Name: "UserID", Direction: "IN"},
+			{Name: "User", Direction: "OUT"},
+		}),
+		newProcedure("OldLogin", `/**
+ * @summary Old login
+ * @deprecated Use NewLogin instead
+ */`, nil),
+	}
+
+	findings := Run(objects, nil)
+
+	expected := map[string]bool{
+		"GetUser|missing-summary":      true,
+		"GetUser|param-count-mismatch": true,
+		"GetUser|missing-return":       true,
+		"OldLogin|stale-deprecated":    true,
+	}
+
+	if len(findings) != len(expected) {
+		t.Fatalf("Expected %d findings, got %d: %+v", len(expected), len(findings), findings)
+	}
+	for _, f := range findings {
+		if !expected[f.Object+"|"+f.Rule] {
+			t.Errorf("Unexpected finding: %+v", f)
+		}
+	}
+}
+
+func TestRun_SeverityOverrides(t *testing.T) {
+	objects := []model.GXObject{
+		newProcedure("DoSomething", `/**
+ * @description No summary here
+ */`, nil),
+	}
+
+	findings := Run(objects, map[string]Severity{"missing-summary": SeverityError})
+	if len(findings) != 1 || findings[0].Severity != SeverityError {
+		t.Fatalf("Expected one error finding, got %+v", findings)
+	}
+
+	findings = Run(objects, map[string]Severity{"missing-summary": SeverityOff})
+	if len(findings) != 0 {
+		t.Errorf("Expected no findings with rule disabled, got %+v", findings)
+	}
+}
+
+func TestRun_SkipsUndocumented(t *testing.T) {
+	obj := newProcedure("NoDocs", "&X = 1", nil)
+	obj.Documentation.IsAutoGenerated = true
+
+	if findings := Run([]model.GXObject{obj}, nil); len(findings) != 0 {
+		t.Errorf("Expected no findings for undocumented procedure, got %+v", findings)
+	}
+}
+
+func TestRun_ExampleArguments(t *testing.T) {
+	params := []model.ParameterDoc{{Name: "UserID", Direction: "IN"}, {Name: "User", Direction: "OUT"}}
+	objects := []model.GXObject{
+		newProcedure("GetUser", `/**
+ * @summary Get a user
+ * @return The user
+ * @example GetUser.Call(&UserID, &User)
+ * @example &User = GetUser(&UserID)
+ * @example GetUser.Call(&UserID)
+ */`, params),
+	}
+
+	var messages []string
+	for _, f := range Run(objects, nil) {
+		if f.Rule == "example-arg-mismatch" {
+			messages = append(messages, f.Message)
+		}
+	}
+	expected := "@example calls GetUser with 1 argument(s) but Parm() declares 2 parameter(s)"
+	if len(messages) != 1 || messages[0] != expected {
+		t.Errorf("Expected one finding %q, got %q", expected, messages)
+	}
+}
+
+func TestRunWithOptions_LayerRules(t *testing.T) {
+	classifier, err := layers.Compile(layers.Config{
+		Order: []string{"UI", "Service", "Data"},
+		Rules: []layers.Rule{
+			{Layer: "UI", Name: "^Wp"},
+			{Layer: "Data", Name: "^Load"},
+			{Layer: "Service", Name: "^Svc"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Undocumented objects and panels missing from the export are checked too
+	objects := []model.GXObject{
+		{Name: "LoadUser", Path: "LoadUser", Type: "Procedure", SourceCode: "WpUsers.Call()"},
+		{Name: "SvcUser", Path: "SvcUser", Type: "Procedure", SourceCode: "LoadUser.Call()"},
+		{Name: "WpHome", Path: "WpHome", Type: "Procedure", SourceCode: "LoadUser.Call()"},
+	}
+
+	if findings := Run(objects, nil); len(findings) != 0 {
+		t.Errorf("Expected no layer findings without layers, got %+v", findings)
+	}
+
+	findings := RunWithOptions(objects, Options{Layers: classifier})
+	if len(findings) != 1 || findings[0].Object != "LoadUser" || findings[0].Rule != "layer-upward-call" ||
+		findings[0].Severity != SeverityError || findings[0].Message != "Data object calls WpUsers in the higher UI layer" {
+		t.Errorf("Expected the Data -> UI call to be flagged, got %+v", findings)
+	}
+
+	findings = RunWithOptions(objects, Options{Layers: classifier, Overrides: map[string]Severity{"layer-skip-call": SeverityWarning}})
+	var skips []string
+	for _, f := range findings {
+		if f.Rule == "layer-skip-call" {
+			skips = append(skips, f.Object+": "+f.Message)
+		}
+	}
+	expected := "WpHome: UI object calls LoadUser in the Data layer, bypassing Service"
+	if len(skips) != 1 || skips[0] != expected {
+		t.Errorf("Expected %q, got %q", expected, skips)
+	}
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// ModelVersion is the version of the model file format. It is increased
+// whenever a change to the objects would make older files render differently.
+const ModelVersion = 1
+
+// ModelFile is the intermediate model written by "gxdocgen extract" and read
+// by "gxdocgen render", so extraction and rendering can run separately
+type ModelFile struct {
+	// Version is the ModelVersion the file was written with
+	Version int `json:"version"`
+
+	// Generator is the gxdocgen version that wrote the file
+	Generator string `json:"generator"`
+
+	// KBName is the name of the exported Knowledge Base
+	KBName string `json:"kb"`
+
+	// Info is the metadata of the export
+	Info KBInfo `json:"info,omitzero"`
+
+	// Objects are the extracted objects
+	Objects []GXObject `json:"objects"`
+
+	// Skipped lists objects left out during extraction
+	Skipped []SkippedObject `json:"skipped,omitempty"`
+
+	// UnknownTypes lists the object type GUIDs no type mapping recognized
+	UnknownTypes []UnknownType `json:"unknownTypes,omitempty"`
+}
+
+// WriteModelFile writes f to path as indented JSON, stamped with ModelVersion
+func WriteModelFile(path string, f *ModelFile) error {
+	f.Version = ModelVersion
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode model: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// ReadModelFile reads a model file, rejecting files written with another
+// ModelVersion
+func ReadModelFile(path string) (*ModelFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f ModelFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid model file %s: %w", path, err)
+	}
+	switch {
+	case f.Version == 0:
+		return nil, fmt.Errorf("%s is not a gxdocgen model file", path)
+	case f.Version > ModelVersion:
+		return nil, fmt.Errorf("%s uses model version %d, written by %s; this gxdocgen reads version %d", path, f.Version, f.Generator, ModelVersion)
+	case f.Version < ModelVersion:
+		return nil, fmt.Errorf("%s uses the outdated model version %d; run extract again", path, f.Version)
+	}
+	return &f, nil
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestModelFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "model.json")
+	written := &ModelFile{
+		Generator: "gxdocgen test",
+		KBName:    "KB",
+		Info:      KBInfo{GeneXusVersion: "4.0.169212", ExportDate: time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC), Modules: []string{"Users"}},
+		Objects: []GXObject{{
+			Name: "GetUser", Type: "Procedure", Path: "GetUser",
+			Parameters:    []ParameterDoc{{Name: "UserID", Direction: "IN"}},
+			Documentation: &DocComment{Summary: "Get a user", Tags: []string{"users"}},
+		}},
+		Skipped: []SkippedObject{{Name: "Huge", Reason: "source too large"}},
+	}
+	if err := WriteModelFile(path, written); err != nil {
+		t.Fatal(err)
+	}
+
+	read, err := ReadModelFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Version != ModelVersion || !reflect.DeepEqual(read, written) {
+		t.Errorf("Expected %+v, got %+v", written, read)
+	}
+}
+
+func TestReadModelFileRejectsOtherVersions(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"not a gxdocgen model": `{"objects": []}`,
+		"this gxdocgen reads":  `{"version": 99, "generator": "gxdocgen 9.0"}`,
+	} {
+		path := filepath.Join(dir, "model.json")
+		os.WriteFile(path, []byte(content), 0644)
+		if _, err := ReadModelFile(path); err == nil || !strings.Contains(err.Error(), name) {
+			t.Errorf("Expected an error containing %q for %s, got %v", name, content, err)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package model
+
+import "sort"
+
+// KnowledgeBase indexes the objects of an export so they can be looked up
+// instead of iterating raw slices. Objects are keyed by their GeneXus name
+// (GXObject.Path).
+type KnowledgeBase struct {
+	// Name is the knowledge base name from the export
+	Name string
+
+	// Objects are all indexed objects, in the order they were given
+	Objects []GXObject
+
+	byName       map[string]int
+	byType       map[string][]int
+	byPackage    map[string][]int
+	referencedBy map[string][]string
+}
+
+// NewKnowledgeBase indexes objects. calls returns the names of the objects
+// an object invokes from its source; when nil, ReferencedBy only follows
+// Dependencies.
+func NewKnowledgeBase(name string, objects []GXObject, calls func(GXObject) []string) *KnowledgeBase {
+	kb := &KnowledgeBase{
+		Name:         name,
+		Objects:      objects,
+		byName:       make(map[string]int),
+		byType:       make(map[string][]int),
+		byPackage:    make(map[string][]int),
+		referencedBy: make(map[string][]string),
+	}
+
+	references := make(map[string]map[string]bool)
+	addReference := func(from, to string) {
+		if to == "" || to == from {
+			return
+		}
+		if references[to] == nil {
+			references[to] = make(map[string]bool)
+		}
+		references[to][from] = true
+	}
+
+	for i, obj := range objects {
+		if _, exists := kb.byName[obj.Path]; !exists {
+			kb.byName[obj.Path] = i
+		}
+		kb.byType[obj.Type] = append(kb.byType[obj.Type], i)
+		if obj.Documentation != nil && obj.Documentation.Package != "" {
+			kb.byPackage[obj.Documentation.Package] = append(kb.byPackage[obj.Documentation.Package], i)
+		}
+
+		for _, dep := range obj.Dependencies {
+			addReference(obj.Path, dep.Name)
+		}
+		if calls != nil {
+			for _, callee := range calls(obj) {
+				addReference(obj.Path, callee)
+			}
+		}
+	}
+
+	for name, from := range references {
+		names := make([]string, 0, len(from))
+		for caller := range from {
+			names = append(names, caller)
+		}
+		sort.Strings(names)
+		kb.referencedBy[name] = names
+	}
+
+	return kb
+}
+
+// ByName returns the object with the given GeneXus name
+func (kb *KnowledgeBase) ByName(name string) (GXObject, bool) {
+	if kb == nil {
+		return GXObject{}, false
+	}
+	i, exists := kb.byName[name]
+	if !exists {
+		return GXObject{}, false
+	}
+	return kb.Objects[i], true
+}
+
+// ByType returns the objects of the given type (e.g. "Procedure")
+func (kb *KnowledgeBase) ByType(objType string) []GXObject {
+	if kb == nil {
+		return nil
+	}
+	return kb.pick(kb.byType[objType])
+}
+
+// ByPackage returns the objects documented with the given @package
+func (kb *KnowledgeBase) ByPackage(pkg string) []GXObject {
+	if kb == nil {
+		return nil
+	}
+	return kb.pick(kb.byPackage[pkg])
+}
+
+// ReferencedBy returns the names of the objects that call the named object
+// or use it as a variable type, sorted by name
+func (kb *KnowledgeBase) ReferencedBy(name string) []string {
+	if kb == nil {
+		return nil
+	}
+	return kb.referencedBy[name]
+}
+
+// pick returns the objects at the given indexes
+func (kb *KnowledgeBase) pick(indexes []int) []GXObject {
+	if len(indexes) == 0 {
+		return nil
+	}
+	objects := make([]GXObject, len(indexes))
+	for i, idx := range indexes {
+		objects[i] = kb.Objects[idx]
+	}
+	return objects
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKnowledgeBase(t *testing.T) {
+	objects := []GXObject{
+		{Path: "GetUser", Type: "Procedure", Documentation: &DocComment{Package: "users"}},
+		{Path: "SaveUser", Type: "Procedure", Documentation: &DocComment{Package: "users"}, Dependencies: []Dependency{{Name: "User", Type: "Transaction"}}},
+		{Path: "User", Type: "Transaction"},
+		{Path: "Billing", Type: "Procedure", Dependencies: []Dependency{{Name: "User", Type: "Transaction"}}},
+	}
+	calls := map[string][]string{"SaveUser": {"GetUser"}, "Billing": {"GetUser", "Billing"}}
+	kb := NewKnowledgeBase("Demo", objects, func(obj GXObject) []string { return calls[obj.Path] })
+
+	if obj, ok := kb.ByName("User"); !ok || obj.Type != "Transaction" {
+		t.Errorf("ByName(User) = %+v, %v", obj, ok)
+	}
+	if _, ok := kb.ByName("Missing"); ok {
+		t.Error("ByName should not find a missing object")
+	}
+	if procs := kb.ByType("Procedure"); len(procs) != 3 || procs[0].Path != "GetUser" {
+		t.Errorf("Unexpected procedures: %+v", procs)
+	}
+	if users := kb.ByPackage("users"); len(users) != 2 {
+		t.Errorf("Expected 2 objects in package users, got %d", len(users))
+	}
+
+	tests := map[string][]string{
+		"GetUser": {"Billing", "SaveUser"},
+		"User":    {"Billing", "SaveUser"},
+		"Billing": nil,
+	}
+	for name, expected := range tests {
+		if got := kb.ReferencedBy(name); !reflect.DeepEqual(got, expected) {
+			t.Errorf("ReferencedBy(%s) = %v, expected %v", name, got, expected)
+		}
+	}
+
+	var empty *KnowledgeBase
+	if _, ok := empty.ByName("User"); ok || empty.ByType("Procedure") != nil || empty.ReferencedBy("User") != nil {
+		t.Error("A nil KnowledgeBase should have no objects")
+	}
+}
@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // GXObject represents a GeneXus object extracted from an XPZ file
 type GXObject struct {
 	// Name is the object's identifier (e.g., "CustomerTransaction")
@@ -11,17 +13,183 @@ type GXObject struct {
 	// Path is the relative file path within the XPZ archive
 	Path string
 
+	// Parent is the module or folder containing the object in the KB
+	Parent string
+
+	// GUID is the object's identifier in the Knowledge Base, stable across
+	// renames; empty when the export does not include it
+	GUID string
+
 	// SourceCode contains the extracted source code (for Procedures, DataProviders, etc.)
 	SourceCode string
 
 	// ParmSignature contains the Parm() declaration for Procedures
 	ParmSignature string
 
+	// Parameters are the parameters extracted from the Parm() rule or IsParm variables
+	Parameters []ParameterDoc
+
+	// Rules is the source of the Rules part (parm() and other rules)
+	Rules string
+
+	// Variables are all variables declared in the Variables part, parameters included
+	Variables []Variable
+
 	// XMLDescription is the description attribute from the XML Object node
 	XMLDescription string
 
 	// Documentation contains parsed annotation comments
 	Documentation *DocComment
+
+	// Dependencies are SDTs and Transactions referenced through variable types
+	Dependencies []Dependency
+
+	// DomainValues are enumerated domain values compared against in the source
+	DomainValues []DomainValue
+
+	// Levels is the structure of a Transaction, starting with its first level
+	Levels []Level
+
+	// IsMain is true for main programs
+	IsMain bool
+
+	// Exposure is how the object is exposed to external consumers ("REST",
+	// "SOAP", "HTTP", ...); empty for internal objects
+	Exposure string
+
+	// Hash is a content hash of the object's export XML, used for incremental generation
+	Hash string
+}
+
+// Variable is a variable declared in an object's Variables part
+type Variable struct {
+	// Name is the variable name without the leading & (e.g., "CustomerId")
+	Name string
+
+	// Type is the GeneXus type without its prefix (e.g., "Numeric", "sdtOrder")
+	Type string
+
+	// Description is the variable description from its properties
+	Description string
+
+	// IsCollection is true for collection variables
+	IsCollection bool
+}
+
+// Level is a level of a Transaction structure
+type Level struct {
+	// Name is the level name (e.g., "Invoice", "InvoiceLine")
+	Name string
+
+	// Description is the level description from the structure
+	Description string
+
+	// Attributes are the level's attributes in structure order
+	Attributes []Attribute
+
+	// Levels are the nested levels
+	Levels []Level
+}
+
+// Keys returns the names of the level's key attributes
+func (l Level) Keys() []string {
+	var keys []string
+	for _, attr := range l.Attributes {
+		if attr.Key {
+			keys = append(keys, attr.Name)
+		}
+	}
+	return keys
+}
+
+// Attribute is an attribute of a Transaction level
+type Attribute struct {
+	// Name is the attribute name (e.g., "CustomerId")
+	Name string
+
+	// Type is the attribute type or domain (e.g., "Numeric", "Name")
+	Type string
+
+	// Description is the attribute description
+	Description string
+
+	// Key is true for attributes in the level's primary key
+	Key bool
+
+	// References is the Transaction whose primary key this attribute is
+	// part of when it is a foreign key
+	References string
+}
+
+// SkippedObject is an object left out of the documentation because it hit
+// a configured limit (source size, object count, generation time)
+type SkippedObject struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// UnknownType is an object type GUID found in an export that no type
+// mapping recognizes; its objects are left out of the documentation
+type UnknownType struct {
+	GUID  string `json:"guid"`
+	Count int    `json:"count"`
+
+	// Samples are the names of the first objects of the type
+	Samples []string `json:"samples"`
+}
+
+// KBInfo describes the export the documentation was generated from
+type KBInfo struct {
+	// GeneXusVersion is the version of the Knowledge Manager that wrote the
+	// export (KMW major.minor.build), e.g. "4.0.169212"
+	GeneXusVersion string `json:"genexusVersion,omitempty"`
+
+	// ExportDate is when the export was written; zero when unknown
+	ExportDate time.Time `json:"exportDate,omitzero"`
+
+	// Environment is the generator environment recorded in the export
+	Environment string `json:"environment,omitempty"`
+
+	// Modules are the modules and folders holding the exported objects
+	Modules []string `json:"modules,omitempty"`
+}
+
+// IsZero reports whether the export recorded no metadata
+func (i KBInfo) IsZero() bool {
+	return i.GeneXusVersion == "" && i.ExportDate.IsZero() && i.Environment == "" && len(i.Modules) == 0
+}
+
+// DomainValue is a reference to an enumerated domain value (e.g., StatusDomain.Active)
+type DomainValue struct {
+	// Domain is the domain name (e.g., "StatusDomain")
+	Domain string
+
+	// Value is the enumerated value name (e.g., "Active")
+	Value string
+}
+
+// Dependency is a reference from an object to another KB object
+type Dependency struct {
+	// Name is the referenced object's name (e.g., "Customer")
+	Name string
+
+	// Type is the referenced object's type (e.g., "SDT", "Transaction")
+	Type string
+}
+
+// QualifiedName returns the object name prefixed with its module or folder
+// (e.g., "Sales.GetUser"), which tells apart objects sharing a name
+func (o GXObject) QualifiedName() string {
+	if o.Parent == "" {
+		return o.Path
+	}
+	return o.Parent + "." + o.Path
+}
+
+// IsDocumented reports whether the object has a /** */ annotation block,
+// as opposed to documentation auto-generated from XML metadata
+func (o GXObject) IsDocumented() bool {
+	return o.Documentation != nil && !o.Documentation.IsAutoGenerated
 }
 
 // DocComment represents parsed documentation from structured comments
@@ -44,26 +212,72 @@ type DocComment struct {
 	// Created is the creation date in ISO format (@created)
 	Created string
 
+	// Since is the KB version that introduced the object (@since)
+	Since string
+
 	// Parameters describes procedure parameters (@param)
 	Parameters []ParameterDoc
 
 	// Return describes the return type or SDT (@return)
 	Return string
 
+	// Examples are GeneXus snippets showing how to call the object (@example)
+	Examples []string
+
 	// ExampleRequest is a JSON example for request body (@example-request)
 	ExampleRequest string
 
 	// ExampleResponse is a JSON example for response body (@example-response)
 	ExampleResponse string
 
+	// See lists related objects (@see)
+	See []string
+
+	// ParamMaps maps parameters of the replaced object to this one (@maps)
+	ParamMaps []ParamMapping
+
 	// Tags are OpenAPI tags for grouping endpoints (@tag)
 	Tags []string
 
 	// Deprecated indicates if the object is deprecated (@deprecated)
 	Deprecated bool
 
+	// NoDoc excludes the object from generated documentation (@nodoc)
+	NoDoc bool
+
+	// DocGroup overrides the package the object is grouped under (@docgroup)
+	DocGroup string
+
 	// DeprecationNote contains the deprecation message
 	DeprecationNote string
+
+	// CustomFields holds values captured by registered custom tags (e.g. @ticket)
+	CustomFields []CustomField
+}
+
+// CustomField represents the value of an organization-specific annotation
+type CustomField struct {
+	// Tag is the annotation that produced the field (e.g., "@ticket")
+	Tag string
+
+	// Label is the display name used in generated documentation (e.g., "Ticket")
+	Label string
+
+	// Value is the raw text following the tag
+	Value string
+}
+
+// ParamMapping links a parameter of a deprecated object to its
+// counterpart in the replacement (@maps Old -> New)
+type ParamMapping struct {
+	// Old is the parameter name in the deprecated object
+	Old string
+
+	// New is the parameter name in the replacement; empty when dropped
+	New string
+
+	// Note is an optional explanation of the mapping
+	Note string
 }
 
 // ParameterDoc represents a procedure parameter
@@ -0,0 +1,155 @@
+// Package overlay patches extracted objects with documentation kept outside
+// the Knowledge Base, so a docs team can fill gaps without editing the KB.
+package overlay
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// Overlay is a JSON overlay file
+type Overlay struct {
+	Objects []Patch `json:"objects"`
+}
+
+// Patch amends a single object, identified by GUID or, when the export has
+// no GUIDs, by name. Empty fields leave the extracted value unchanged.
+type Patch struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+
+	Summary     string `json:"summary"`
+	Description string `json:"description"`
+	Package     string `json:"package"`
+	Return      string `json:"return"`
+
+	// Tags are added to the object's @tag values
+	Tags []string `json:"tags"`
+
+	// Parameters amends parameters by name
+	Parameters map[string]ParameterPatch `json:"parameters"`
+}
+
+// ParameterPatch amends the type or description of a parameter
+type ParameterPatch struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// Load reads and decodes an overlay file
+func Load(path string) (*Overlay, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var o Overlay
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("invalid overlay file %s: %w", path, err)
+	}
+	for i, patch := range o.Objects {
+		if patch.GUID == "" && patch.Name == "" {
+			return nil, fmt.Errorf("invalid overlay file %s: object %d has neither guid nor name", path, i+1)
+		}
+	}
+	return &o, nil
+}
+
+// Apply patches objects in place and returns the patches matching no
+// object, described by their GUID or name. Documentation is copied before it
+// is changed, so other holders of the same DocComment are not affected.
+func (o *Overlay) Apply(objects []model.GXObject) []string {
+	var unmatched []string
+	for _, patch := range o.Objects {
+		matched := false
+		for i := range objects {
+			if patch.matches(objects[i]) {
+				patch.apply(&objects[i])
+				matched = true
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, patch.String())
+		}
+	}
+	return unmatched
+}
+
+// String identifies the patch in messages
+func (p Patch) String() string {
+	if p.GUID != "" {
+		return "guid " + p.GUID
+	}
+	return p.Name
+}
+
+// matches reports whether the patch targets obj. A GUID takes precedence
+// over the name; names are compared case-insensitively.
+func (p Patch) matches(obj model.GXObject) bool {
+	if p.GUID != "" {
+		return strings.EqualFold(p.GUID, obj.GUID)
+	}
+	return strings.EqualFold(p.Name, obj.Path) || strings.EqualFold(p.Name, obj.Name)
+}
+
+// apply copies the non-empty fields of the patch into obj
+func (p Patch) apply(obj *model.GXObject) {
+	var doc model.DocComment
+	if obj.Documentation != nil {
+		doc = *obj.Documentation
+	} else {
+		doc.IsAutoGenerated = true
+		doc.Parameters = obj.Parameters
+	}
+
+	if p.Summary != "" {
+		doc.Summary = p.Summary
+	}
+	if p.Description != "" {
+		doc.Description = p.Description
+	}
+	if p.Package != "" {
+		doc.Package = p.Package
+	}
+	if p.Return != "" {
+		doc.Return = p.Return
+	}
+	if len(p.Tags) > 0 {
+		tags := slices.Clone(doc.Tags)
+		for _, tag := range p.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		doc.Tags = tags
+	}
+	if len(p.Parameters) > 0 {
+		doc.Parameters = patchParameters(doc.Parameters, p.Parameters)
+		obj.Parameters = patchParameters(obj.Parameters, p.Parameters)
+	}
+	obj.Documentation = &doc
+}
+
+// patchParameters returns a copy of params with the patches applied by
+// parameter name
+func patchParameters(params []model.ParameterDoc, patches map[string]ParameterPatch) []model.ParameterDoc {
+	result := slices.Clone(params)
+	for i := range result {
+		for name, patch := range patches {
+			if !strings.EqualFold(strings.TrimPrefix(name, "&"), result[i].Name) {
+				continue
+			}
+			if patch.Type != "" {
+				result[i].Type = patch.Type
+			}
+			if patch.Description != "" {
+				result[i].Description = patch.Description
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,60 @@
+package overlay
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestLoadAndApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overlay.json")
+	os.WriteFile(path, []byte(`{
+  "objects": [
+    {"guid": "A1B2", "summary": "Get a user", "tags": ["users", "read"]},
+    {"name": "deleteuser", "package": "Admin",
+     "parameters": {"&UserID": {"type": "Numeric(10)", "description": "The user to delete"}}},
+    {"name": "Missing", "summary": "Nobody"}
+  ]
+}`), 0644)
+
+	o, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shared := &model.DocComment{Summary: "Old", Tags: []string{"users"}}
+	objects := []model.GXObject{
+		{Name: "GetUser", Path: "GetUser", GUID: "a1b2", Documentation: shared},
+		{Name: "DeleteUser", Path: "DeleteUser", Parameters: []model.ParameterDoc{{Name: "UserID", Direction: "IN"}}},
+	}
+	unmatched := o.Apply(objects)
+
+	if !reflect.DeepEqual(unmatched, []string{"Missing"}) {
+		t.Errorf("Expected only Missing to be unmatched, got %v", unmatched)
+	}
+	if doc := objects[0].Documentation; doc.Summary != "Get a user" || !reflect.DeepEqual(doc.Tags, []string{"users", "read"}) {
+		t.Errorf("Expected the summary replaced and the tag added, got %+v", doc)
+	}
+	if shared.Summary != "Old" || len(shared.Tags) != 1 {
+		t.Error("Expected the original DocComment to be left unchanged")
+	}
+
+	doc := objects[1].Documentation
+	if doc == nil || !doc.IsAutoGenerated || doc.Package != "Admin" {
+		t.Fatalf("Expected auto-generated documentation filed under Admin, got %+v", doc)
+	}
+	if p := objects[1].Parameters[0]; p.Type != "Numeric(10)" || p.Description != "The user to delete" || p.Direction != "IN" {
+		t.Errorf("Expected the parameter patched, got %+v", p)
+	}
+}
+
+func TestLoadRejectsUnidentifiedPatches(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "overlay.json")
+	os.WriteFile(path, []byte(`{"objects": [{"summary": "Whose?"}]}`), 0644)
+	if _, err := Load(path); err == nil {
+		t.Error("Expected an error for a patch without guid or name")
+	}
+}
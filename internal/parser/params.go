@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// CompareParams compares the @param tags of a doc comment against the
+// parameters declared by Parm() and returns one message per parameter that
+// is missing from the tags, documented but not declared, or documented with
+// another direction. Names are compared case-insensitively, with or without
+// the leading &. No tags at all is not a mismatch, nor is a tag without a
+// direction.
+func CompareParams(documented, declared []model.ParameterDoc) []string {
+	if len(documented) == 0 {
+		return nil
+	}
+
+	tags := make(map[string]model.ParameterDoc, len(documented))
+	for _, param := range documented {
+		tags[paramKey(param.Name)] = param
+	}
+	parms := make(map[string]bool, len(declared))
+
+	var messages []string
+	for _, param := range declared {
+		key := paramKey(param.Name)
+		parms[key] = true
+		tag, ok := tags[key]
+		switch {
+		case !ok:
+			messages = append(messages, fmt.Sprintf("Parm() parameter &%s has no @param tag", strings.TrimPrefix(param.Name, "&")))
+		case param.Direction != "" && tag.Direction != "" && !strings.EqualFold(tag.Direction, param.Direction):
+			messages = append(messages, fmt.Sprintf("@param %s is %s but Parm() declares it %s", tag.Name, tag.Direction, strings.ToUpper(param.Direction)))
+		}
+	}
+	for _, param := range documented {
+		if !parms[paramKey(param.Name)] {
+			messages = append(messages, fmt.Sprintf("@param %s is not declared in Parm()", param.Name))
+		}
+	}
+	return messages
+}
+
+// ApplyDirections sets the direction of the @param tags that give none to
+// the one Parm() declares for the parameter, or IN when it is not declared
+func ApplyDirections(documented, declared []model.ParameterDoc) {
+	directions := make(map[string]string, len(declared))
+	for _, param := range declared {
+		directions[paramKey(param.Name)] = strings.ToUpper(param.Direction)
+	}
+	for i := range documented {
+		if documented[i].Direction != "" {
+			continue
+		}
+		documented[i].Direction = "IN"
+		if direction := directions[paramKey(documented[i].Name)]; direction != "" {
+			documented[i].Direction = direction
+		}
+	}
+}
+
+// paramKey normalizes a parameter name for comparison
+func paramKey(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "&"))
+}
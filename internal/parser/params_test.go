@@ -0,0 +1,59 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestCompareParams(t *testing.T) {
+	declared := []model.ParameterDoc{
+		{Name: "UserID", Direction: "IN"},
+		{Name: "User", Direction: "OUT"},
+		{Name: "Messages", Direction: "INOUT"},
+	}
+
+	tests := []struct {
+		name       string
+		documented []model.ParameterDoc
+		want       []string
+	}{
+		{"no tags", nil, nil},
+		{"matching", []model.ParameterDoc{
+			{Name: "&userid", Direction: "IN"}, {Name: "User", Direction: "OUT"}, {Name: "Messages", Direction: "INOUT"},
+		}, nil},
+		{"missing, extra and wrong direction", []model.ParameterDoc{
+			{Name: "UserID", Direction: "IN"}, {Name: "User", Direction: "IN"}, {Name: "OldFlag", Direction: "IN"},
+		}, []string{
+			"@param User is IN but Parm() declares it OUT",
+			"Parm() parameter &Messages has no @param tag",
+			"@param OldFlag is not declared in Parm()",
+		}},
+	}
+
+	for _, tt := range tests {
+		if got := CompareParams(tt.documented, declared); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestCompareParams_NoDirection(t *testing.T) {
+	doc, err := Parse("/**\n * @param UserID Numeric - The id\n */")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	// parm(&UserID); declares an INOUT parameter
+	declared := []model.ParameterDoc{{Name: "UserID", Direction: "INOUT"}}
+
+	if got := CompareParams(doc.Parameters, declared); len(got) != 0 {
+		t.Errorf("Expected no mismatch for a tag without a direction, got %q", got)
+	}
+
+	documented := append(doc.Parameters, model.ParameterDoc{Name: "Extra"})
+	ApplyDirections(documented, declared)
+	if documented[0].Direction != "INOUT" || documented[1].Direction != "IN" {
+		t.Errorf("Expected the Parm() direction, or IN when undeclared, got %+v", documented)
+	}
+}
@@ -1,51 +1,104 @@
 package parser
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
 )
 
-// Parse extracts and parses documentation comments from GeneXus source code
-func Parse(sourceCode string) (*model.DocComment, error) {
-	commentBlock := extractCommentBlock(sourceCode)
-	if commentBlock == "" {
-		return nil, nil
+// Limits that keep pathological sources (huge blocks, endless lines,
+// binary garbage) from blowing up parsing time or generated pages
+const (
+	// MaxCommentLength is the number of bytes of a /** */ block that are parsed
+	MaxCommentLength = 64 * 1024
+	// MaxLineLength is the length at which comment lines are truncated
+	MaxLineLength = 4096
+	// MaxParameters is the number of @param tags kept per block
+	MaxParameters = 256
+)
+
+// ErrUnterminatedComment is returned for a /** block without a closing */
+var ErrUnterminatedComment = errors.New("unterminated /** comment block")
+
+// commentBlockRegex matches the first /** ... */ block
+var commentBlockRegex = regexp.MustCompile(`(?s)/\*\*\s*(.*?)\s*\*/`)
+
+// Parse extracts and parses documentation comments from GeneXus source code.
+// It never panics: unexpected failures are returned as errors.
+func Parse(sourceCode string) (doc *model.DocComment, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			doc, err = nil, fmt.Errorf("malformed comment block: %v", r)
+		}
+	}()
+
+	commentBlock, err := extractCommentBlock(sourceCode)
+	if err != nil || commentBlock == "" {
+		return nil, err
 	}
 
-	doc := &model.DocComment{
+	doc = &model.DocComment{
 		Parameters: make([]model.ParameterDoc, 0),
 		Tags:       make([]string, 0),
 	}
 
 	lines := strings.Split(commentBlock, "\n")
 
+	// currentTag tracks the last tag seen, after resolving aliases, so that
+	// free-form lines following a @description or @example can be kept as
+	// part of its body
+	currentTag := ""
+
 	for _, line := range lines {
 		line = strings.TrimSpace(line)
 
-		if line == "" {
+		if strings.HasPrefix(line, "@") {
+			parseTag(line, doc)
+			currentTag = resolveAlias(strings.SplitN(line, " ", 2)[0])
 			continue
 		}
 
-		if strings.HasPrefix(line, "@") {
-			parseTag(line, doc)
+		switch currentTag {
+		case "@description":
+			doc.Description += "\n" + line
+		case "@example":
+			if len(doc.Examples) > 0 {
+				doc.Examples[len(doc.Examples)-1] += "\n" + line
+			}
 		}
 	}
 
+	doc.Description = strings.TrimSpace(doc.Description)
+	for i, example := range doc.Examples {
+		doc.Examples[i] = strings.TrimSpace(example)
+	}
+
 	return doc, nil
 }
 
-// extractCommentBlock finds and extracts the /** ... */ comment block
-func extractCommentBlock(source string) string {
-	re := regexp.MustCompile(`(?s)/\*\*\s*(.*?)\s*\*/`)
-	matches := re.FindStringSubmatch(source)
+// extractCommentBlock finds and extracts the /** ... */ comment block.
+// Blocks are capped at MaxCommentLength and lines at MaxLineLength;
+// invalid UTF-8 and control characters are dropped.
+func extractCommentBlock(source string) (string, error) {
+	matches := commentBlockRegex.FindStringSubmatch(source)
 
 	if len(matches) < 2 {
-		return ""
+		if opensCommentBlock(source) {
+			return "", ErrUnterminatedComment
+		}
+		return "", nil
 	}
 
 	block := matches[1]
+	if len(block) > MaxCommentLength {
+		block = block[:MaxCommentLength]
+	}
+	block = sanitizeText(normalizeText(block))
 
 	// Remove leading * from each line
 	lines := strings.Split(block, "\n")
@@ -54,10 +107,65 @@ func extractCommentBlock(source string) string {
 		line = strings.TrimSpace(line)
 		line = strings.TrimPrefix(line, "*")
 		line = strings.TrimSpace(line)
+		if len(line) > MaxLineLength {
+			line = strings.ToValidUTF8(line[:MaxLineLength], "")
+		}
 		cleaned = append(cleaned, line)
 	}
 
-	return strings.Join(cleaned, "\n")
+	return strings.Join(cleaned, "\n"), nil
+}
+
+// opensCommentBlock reports whether a line starts a /** block that is never
+// closed. A "/**" in the middle of a line (e.g. inside a string) is ignored.
+// Source is scanned once, tracking whether the current line is still blank.
+func opensCommentBlock(source string) bool {
+	blank := true
+	for i, r := range source {
+		switch {
+		case r == '\n':
+			blank = true
+		case blank && strings.HasPrefix(source[i:], "/**"):
+			return !strings.Contains(source[i+2:], "*/")
+		case !unicode.IsSpace(r):
+			blank = false
+		}
+	}
+	return false
+}
+
+// textNormalizer maps characters that Word and some GeneXus editors
+// substitute while typing back to the plain ASCII the tag syntax expects
+var textNormalizer = strings.NewReplacer(
+	"\r\n", "\n",
+	"\r", "\n",
+	"\u2018", "'", "\u2019", "'", // ‘ ’
+	"\u201C", "\"", "\u201D", "\"", // “ ”
+	"\u00A0", " ", "\u2007", " ", "\u202F", " ", // non-breaking spaces
+	"\u200B", "", "\uFEFF", "", // zero-width space, byte order mark
+	" \u2013 ", " - ", " \u2014 ", " - ", // autocorrected " - " separators
+)
+
+// normalizeText converts Windows and old Mac line endings, smart quotes,
+// non-breaking spaces and autocorrected dashes so annotations pasted from
+// Word still match the tag syntax
+func normalizeText(text string) string {
+	return textNormalizer.Replace(text)
+}
+
+// sanitizeText replaces invalid UTF-8 and drops control characters other
+// than tabs and line breaks
+func sanitizeText(text string) string {
+	text = strings.ToValidUTF8(text, "\uFFFD")
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' {
+			return r
+		}
+		if r == '\r' || unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, text)
 }
 
 // parseTag processes a single @tag line
@@ -67,7 +175,7 @@ func parseTag(line string, doc *model.DocComment) {
 		return
 	}
 
-	tag := parts[0]
+	tag := resolveAlias(parts[0])
 	value := ""
 	if len(parts) > 1 {
 		value = strings.TrimSpace(parts[1])
@@ -84,23 +192,163 @@ func parseTag(line string, doc *model.DocComment) {
 		doc.Author = value
 	case "@created":
 		doc.Created = value
+	case "@since":
+		doc.Since = value
 	case "@param":
 		param := parseParameter(value)
-		if param != nil {
+		if param != nil && len(doc.Parameters) < MaxParameters {
 			doc.Parameters = append(doc.Parameters, *param)
 		}
+	case "@maps":
+		if mapping := parseMapping(value); mapping != nil {
+			doc.ParamMaps = append(doc.ParamMaps, *mapping)
+		}
 	case "@return":
 		doc.Return = value
 	case "@tag":
 		doc.Tags = append(doc.Tags, value)
+	case "@example":
+		doc.Examples = append(doc.Examples, value)
+	case "@see":
+		if value != "" {
+			doc.See = append(doc.See, value)
+		}
 	case "@deprecated":
 		doc.Deprecated = true
 		doc.DeprecationNote = value
+	case "@nodoc":
+		doc.NoDoc = true
+	case "@docgroup":
+		doc.DocGroup = value
+	default:
+		if handler := lookupTag(tag); handler != nil {
+			handler(value, doc)
+		}
+	}
+}
+
+// replacementRegex matches "use X" in deprecation notes, with the "instead"
+// that may follow it
+var replacementRegex = regexp.MustCompile(`(?i)\buse\s+([A-Za-z_][\w.]*)(\s+instead\b)?`)
+
+// ParseReplacement extracts the suggested replacement object from a
+// deprecation note (e.g., "Use NewAuthenticateUser instead" → "NewAuthenticateUser").
+// Only the documented forms count: "use X instead" anywhere, or a note
+// starting with "Use X" that ends the sentence there, so prose such as
+// "Use the new API instead" names no replacement.
+func ParseReplacement(note string) string {
+	note = strings.TrimSpace(note)
+	for _, m := range replacementRegex.FindAllStringSubmatchIndex(note, -1) {
+		name, rest := note[m[2]:m[3]], note[m[1]:]
+		leading := m[0] == 0 && (strings.HasSuffix(name, ".") || rest == "" || strings.ContainsAny(rest[:1], ",;:!"))
+		if m[4] >= 0 || leading {
+			return strings.TrimSuffix(name, ".")
+		}
+	}
+	return ""
+}
+
+// TagHandler processes the value of a custom tag and stores it in the DocComment
+type TagHandler func(value string, doc *model.DocComment)
+
+var (
+	customTagsMu sync.RWMutex
+	customTags   = make(map[string]TagHandler)
+	tagAliases   = make(map[string]string)
+)
+
+// RegisterTag registers a handler for a custom annotation such as "@ticket".
+// The leading @ is optional. Built-in tags always take precedence.
+func RegisterTag(tag string, handler TagHandler) {
+	customTagsMu.Lock()
+	defer customTagsMu.Unlock()
+	customTags[normalizeTagName(tag)] = handler
+}
+
+// UnregisterTag removes a previously registered custom tag handler
+func UnregisterTag(tag string) {
+	customTagsMu.Lock()
+	defer customTagsMu.Unlock()
+	delete(customTags, normalizeTagName(tag))
+}
+
+// RegisterAlias makes alias (e.g. "@resumo") parse as tag (e.g. "@summary"),
+// so comments can be written with localized tag names. The leading @ is
+// optional on both.
+func RegisterAlias(alias, tag string) {
+	customTagsMu.Lock()
+	defer customTagsMu.Unlock()
+	tagAliases[normalizeTagName(alias)] = normalizeTagName(tag)
+}
+
+// UnregisterAlias removes a previously registered tag alias
+func UnregisterAlias(alias string) {
+	customTagsMu.Lock()
+	defer customTagsMu.Unlock()
+	delete(tagAliases, normalizeTagName(alias))
+}
+
+// CustomFieldHandler returns a TagHandler that records the tag value as a
+// CustomField rendered under the given label
+func CustomFieldHandler(tag, label string) TagHandler {
+	tag = normalizeTagName(tag)
+	return func(value string, doc *model.DocComment) {
+		doc.CustomFields = append(doc.CustomFields, model.CustomField{
+			Tag:   tag,
+			Label: label,
+			Value: value,
+		})
 	}
 }
 
+// lookupTag returns the handler registered for a tag, or nil
+func lookupTag(tag string) TagHandler {
+	customTagsMu.RLock()
+	defer customTagsMu.RUnlock()
+	return customTags[tag]
+}
+
+// resolveAlias returns the tag an alias stands for, or tag itself
+func resolveAlias(tag string) string {
+	customTagsMu.RLock()
+	defer customTagsMu.RUnlock()
+	if target, ok := tagAliases[tag]; ok {
+		return target
+	}
+	return tag
+}
+
+// normalizeTagName ensures tag names are stored with a single leading @
+func normalizeTagName(tag string) string {
+	return "@" + strings.TrimPrefix(strings.TrimSpace(tag), "@")
+}
+
+// parseMapping parses a @maps line
+// Format: @maps OldParam -> NewParam - Note. A new name of "-" marks the
+// old parameter as dropped.
+func parseMapping(value string) *model.ParamMapping {
+	names := strings.SplitN(value, "->", 2)
+	if len(names) < 2 {
+		return nil
+	}
+	oldName := strings.TrimPrefix(strings.TrimSpace(names[0]), "&")
+	tokens := strings.Fields(names[1])
+	if oldName == "" || len(tokens) == 0 {
+		return nil
+	}
+
+	newName := strings.TrimPrefix(tokens[0], "&")
+	if newName == "-" {
+		newName = ""
+	}
+	note := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.Join(tokens[1:], " ")), "-"))
+
+	return &model.ParamMapping{Old: oldName, New: newName, Note: note}
+}
+
 // parseParameter parses a @param line
 // Format: @param name [IN|OUT|INOUT] Type:TypeName - Description
+// Direction is left empty when the tag gives none (see ApplyDirections).
 func parseParameter(value string) *model.ParameterDoc {
 	// Split by " - " to separate description
 	parts := strings.SplitN(value, " - ", 2)
@@ -129,7 +377,6 @@ func parseParameter(value string) *model.ParameterDoc {
 			param.Type = tokens[2]
 		}
 	} else {
-		param.Direction = "IN"
 		param.Type = tokens[1]
 	}
 
@@ -1,7 +1,13 @@
 package parser
 
 import (
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
 )
 
 func TestParse_ValidComment(t *testing.T) {
@@ -171,6 +177,201 @@ Parm();`
 	}
 }
 
+func TestParse_MultilineDescription(t *testing.T) {
+	sourceCode := `/**
+ * @summary Close Order
+ * @description Closes an order and notifies the customer.
+ *
+ * Steps:
+ * - validates **status**
+ * - calls ` + "`" + `NotifyCustomer` + "`" + `
+ * @author Jane Smith
+ */
+Parm();`
+
+	doc, err := Parse(sourceCode)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	expected := "Closes an order and notifies the customer.\n\nSteps:\n- validates **status**\n- calls `NotifyCustomer`"
+	if doc.Description != expected {
+		t.Errorf("Expected description %q, got %q", expected, doc.Description)
+	}
+
+	if doc.Author != "Jane Smith" {
+		t.Errorf("Expected author 'Jane Smith', got '%s'", doc.Author)
+	}
+}
+
+func TestParse_NormalizesPastedText(t *testing.T) {
+	sourceCode := "/**\r\n" +
+		" * @summary\u00A0Get the customer\u2019s \u201Cactive\u201D orders\r\n" +
+		" * @param\u00A0CustomerId IN Numeric \u2013 Customer identifier\r\n" +
+		" * @return\u202FOrders\r\n" +
+		" */\r\nParm(in:&CustomerId);"
+
+	doc, err := Parse(sourceCode)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	if doc.Summary != "Get the customer's \"active\" orders" {
+		t.Errorf("Unexpected summary %q", doc.Summary)
+	}
+	if len(doc.Parameters) != 1 {
+		t.Fatalf("Expected 1 parameter, got %d", len(doc.Parameters))
+	}
+	param := doc.Parameters[0]
+	if param.Name != "CustomerId" || param.Type != "Numeric" || param.Description != "Customer identifier" {
+		t.Errorf("Unexpected parameter %+v", param)
+	}
+	if doc.Return != "Orders" {
+		t.Errorf("Expected return 'Orders', got %q", doc.Return)
+	}
+}
+
+func TestParse_CustomTag(t *testing.T) {
+	RegisterTag("ticket", CustomFieldHandler("@ticket", "Ticket"))
+	defer UnregisterTag("@ticket")
+
+	sourceCode := `/**
+ * @summary Close Order
+ * @ticket JIRA-1234
+ * @unknown ignored
+ */
+Parm();`
+
+	doc, err := Parse(sourceCode)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	if len(doc.CustomFields) != 1 {
+		t.Fatalf("Expected 1 custom field, got %d", len(doc.CustomFields))
+	}
+
+	field := doc.CustomFields[0]
+	if field.Tag != "@ticket" || field.Label != "Ticket" || field.Value != "JIRA-1234" {
+		t.Errorf("Unexpected custom field: %+v", field)
+	}
+}
+
+func TestParse_TagAlias(t *testing.T) {
+	RegisterAlias("@resumo", "summary")
+	RegisterAlias("@parametro", "@param")
+	defer UnregisterAlias("@resumo")
+	defer UnregisterAlias("@parametro")
+
+	sourceCode := `/**
+ * @resumo Fechar pedido
+ * @parametro PedidoId IN Numeric - Identificador do pedido
+ */
+Parm(in:&PedidoId);`
+
+	doc, err := Parse(sourceCode)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+
+	if doc.Summary != "Fechar pedido" {
+		t.Errorf("Expected summary from @resumo, got %q", doc.Summary)
+	}
+	if len(doc.Parameters) != 1 || doc.Parameters[0].Name != "PedidoId" {
+		t.Errorf("Expected 1 parameter from @parametro, got %+v", doc.Parameters)
+	}
+}
+
+func TestParse_TagAliasContinuationLines(t *testing.T) {
+	RegisterAlias("@descricao", "@description")
+	RegisterAlias("@exemplo", "@example")
+	defer UnregisterAlias("@descricao")
+	defer UnregisterAlias("@exemplo")
+
+	doc, err := Parse(`/**
+ * @descricao Fecha o pedido
+ * e avisa o cliente
+ * @exemplo
+ * &PedidoId = 42
+ * FecharPedido.Call(&PedidoId)
+ */`)
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if doc.Description != "Fecha o pedido\ne avisa o cliente" {
+		t.Errorf("Expected the @descricao continuation kept, got %q", doc.Description)
+	}
+	if len(doc.Examples) != 1 || doc.Examples[0] != "&PedidoId = 42\nFecharPedido.Call(&PedidoId)" {
+		t.Errorf("Expected the @exemplo body kept, got %q", doc.Examples)
+	}
+}
+
+func TestParse_ExampleAliasedAway(t *testing.T) {
+	RegisterAlias("@example", "@description")
+	defer UnregisterAlias("@example")
+
+	doc, err := Parse("/**\n * @summary Close\n * @example\n * Close.Call()\n */")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if len(doc.Examples) != 0 || doc.Description != "Close.Call()" {
+		t.Errorf("Expected @example read as @description, got examples %q and description %q", doc.Examples, doc.Description)
+	}
+}
+
+func TestParse_NoDocAndDocGroup(t *testing.T) {
+	doc, err := Parse("/**\n * @nodoc\n * @docgroup Billing\n */\n&X = 1")
+	if err != nil {
+		t.Fatalf("Parse() failed: %v", err)
+	}
+	if !doc.NoDoc || doc.DocGroup != "Billing" {
+		t.Errorf("Expected @nodoc and @docgroup to be parsed, got %+v", doc)
+	}
+}
+
+func TestParseReplacement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Use NewAuthenticateUser instead", "NewAuthenticateUser"},
+		{"use RemoveUser.", "RemoveUser"},
+		{"Use RemoveUser. It also logs the removal", "RemoveUser"},
+		{"Deprecated in 2.0, use NewLogin instead.", "NewLogin"},
+		{"Replaced by the new API", ""},
+		{"Use the new API instead", ""},
+		{"Callers should use caution here", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		result := ParseReplacement(tt.input)
+		if result != tt.expected {
+			t.Errorf("ParseReplacement(%q) = %q, expected %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *model.ParamMapping
+	}{
+		{"UserID -> CustomerID", &model.ParamMapping{Old: "UserID", New: "CustomerID"}},
+		{"&Total -> &Amount - now includes taxes", &model.ParamMapping{Old: "Total", New: "Amount", Note: "now includes taxes"}},
+		{"Legacy -> - - no longer needed", &model.ParamMapping{Old: "Legacy", Note: "no longer needed"}},
+		{"UserID CustomerID", nil},
+		{"-> CustomerID", nil},
+	}
+
+	for _, tt := range tests {
+		result := parseMapping(tt.input)
+		if !reflect.DeepEqual(result, tt.expected) {
+			t.Errorf("parseMapping(%q) = %+v, expected %+v", tt.input, result, tt.expected)
+		}
+	}
+}
+
 func TestParseParameter_INOUT(t *testing.T) {
 	param := parseParameter("OrderData INOUT sdtOrder - Order information to be processed")
 
@@ -202,9 +403,9 @@ func TestParseParameter_NoDirection(t *testing.T) {
 		t.Fatal("Expected param to be non-nil")
 	}
 
-	// Should default to IN
-	if param.Direction != "IN" {
-		t.Errorf("Expected default direction 'IN', got '%s'", param.Direction)
+	// Left for ApplyDirections to fill from Parm()
+	if param.Direction != "" {
+		t.Errorf("Expected no direction, got '%s'", param.Direction)
 	}
 
 	if param.Type != "Numeric" {
@@ -231,7 +432,10 @@ func TestExtractCommentBlock(t *testing.T) {
  */
 Some code here`
 
-	block := extractCommentBlock(source)
+	block, err := extractCommentBlock(source)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 	
 	if block == "" {
 		t.Fatal("Expected non-empty comment block")
@@ -245,7 +449,10 @@ Some code here`
 func TestExtractCommentBlock_NoComment(t *testing.T) {
 	source := `Just some code without comments`
 
-	block := extractCommentBlock(source)
+	block, err := extractCommentBlock(source)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 	
 	if block != "" {
 		t.Errorf("Expected empty comment block, got: %s", block)
@@ -268,3 +475,103 @@ func findInString(s, substr string) bool {
 	}
 	return false
 }
+
+func TestParse_UnterminatedComment(t *testing.T) {
+	doc, err := Parse("/**\n * @summary Never closed\n&x = 1\n")
+	if err != ErrUnterminatedComment || doc != nil {
+		t.Errorf("Expected ErrUnterminatedComment, got doc=%+v err=%v", doc, err)
+	}
+
+	// "/**" inside a line (e.g. a string literal) is not a comment opener
+	doc, err = Parse("&path = \"/**\"\n")
+	if err != nil || doc != nil {
+		t.Errorf("Expected no documentation and no error, got doc=%+v err=%v", doc, err)
+	}
+}
+
+func TestParse_LongLineOfOpeners(t *testing.T) {
+	// A single line with many "/**" used to rescan the line for each one
+	source := strings.Repeat(`&x = "/**" `, 200000) + "\n/**\n * @summary Never closed\n"
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := Parse(source)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != ErrUnterminatedComment {
+			t.Errorf("Expected ErrUnterminatedComment, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Parse took too long on a long line of comment openers")
+	}
+}
+
+func TestParse_Limits(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("/**\n * @summary " + strings.Repeat("x", 2*MaxLineLength) + "\n")
+	for i := 0; i < MaxParameters+10; i++ {
+		sb.WriteString(" * @param P IN Numeric - p\n")
+	}
+	sb.WriteString(" * @description bin\x00ary\xff\n */")
+
+	doc, err := Parse(sb.String())
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(doc.Summary) != MaxLineLength-len("@summary ") {
+		t.Errorf("Expected summary truncated to the line limit, got %d bytes", len(doc.Summary))
+	}
+	if len(doc.Parameters) != MaxParameters {
+		t.Errorf("Expected %d parameters, got %d", MaxParameters, len(doc.Parameters))
+	}
+	if doc.Description != "binary\uFFFD" {
+		t.Errorf("Expected control characters and invalid UTF-8 cleaned, got %q", doc.Description)
+	}
+}
+
+func TestParse_Examples(t *testing.T) {
+	source := `/**
+ * @summary Get a user
+ * @example &User = GetUser(&UserID)
+ * @example
+ * &UserID = 42
+ * GetUser.Call(&UserID, &User)
+ * @return The user
+ */`
+
+	doc, err := Parse(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"&User = GetUser(&UserID)", "&UserID = 42\nGetUser.Call(&UserID, &User)"}
+	if !reflect.DeepEqual(doc.Examples, expected) {
+		t.Errorf("Expected examples %q, got %q", expected, doc.Examples)
+	}
+	if doc.Return != "The user" {
+		t.Errorf("Expected the example to end at the next tag, got return %q", doc.Return)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	f.Add("/**\n * @summary Get user\n * @param UserID IN Numeric - id\n */\n&x = 1")
+	f.Add("/**\n * @maps Old -> New - note\n * @deprecated Use X instead\n")
+	f.Add("/***/")
+	f.Add("\x00\xff/** @param */")
+
+	f.Fuzz(func(t *testing.T, source string) {
+		doc, err := Parse(source)
+		if err != nil || doc == nil {
+			return
+		}
+		if len(doc.Parameters) > MaxParameters {
+			t.Errorf("%d parameters exceed the limit", len(doc.Parameters))
+		}
+		for _, text := range []string{doc.Summary, doc.Description, doc.Return, doc.Package} {
+			if !utf8.ValidString(text) {
+				t.Errorf("invalid UTF-8 in %q", text)
+			}
+		}
+	})
+}
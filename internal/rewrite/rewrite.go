@@ -0,0 +1,181 @@
+// Package rewrite applies mechanical fixes to /** */ doc comments and
+// produces patches developers can apply to the GeneXus source.
+package rewrite
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// isoDate is the @created format the generator understands
+const isoDate = "2006-01-02"
+
+// contextLines is the number of unchanged lines shown around a change
+const contextLines = 3
+
+// Options selects the transformations to apply
+type Options struct {
+	// RenameTags maps old tag names to new ones (e.g. "@brief" -> "@summary")
+	RenameTags map[string]string
+
+	// NormalizeDirections upper-cases @param directions (in -> IN)
+	NormalizeDirections bool
+
+	// DateLayout is the Go layout of @created dates to convert to
+	// YYYY-MM-DD (e.g. "02/01/2006"); empty leaves dates alone
+	DateLayout string
+}
+
+// Patch is the suggested change to one object's source
+type Patch struct {
+	Object string
+	// Diff is a unified diff of the source
+	Diff string
+}
+
+// Rewrite applies opts to the first /** */ block of source and reports
+// whether anything changed. Code outside the block is never touched.
+func Rewrite(source string, opts Options) (string, bool) {
+	start := strings.Index(source, "/**")
+	if start == -1 {
+		return source, false
+	}
+	end := strings.Index(source[start:], "*/")
+	if end == -1 {
+		return source, false
+	}
+	end += start
+
+	lines := strings.Split(source[start:end], "\n")
+	changed := false
+	for i, line := range lines {
+		if rewritten := rewriteLine(line, opts); rewritten != line {
+			lines[i] = rewritten
+			changed = true
+		}
+	}
+	if !changed {
+		return source, false
+	}
+	return source[:start] + strings.Join(lines, "\n") + source[end:], true
+}
+
+// rewriteLine applies opts to a single comment line, keeping its " * "
+// prefix and line ending
+func rewriteLine(line string, opts Options) string {
+	body := strings.TrimRight(line, "\r")
+	ending := line[len(body):]
+
+	trimmed := strings.TrimLeft(body, " \t")
+	if strings.HasPrefix(trimmed, "/**") {
+		trimmed = trimmed[3:]
+	} else {
+		trimmed = strings.TrimPrefix(trimmed, "*")
+	}
+	trimmed = strings.TrimLeft(trimmed, " \t")
+	prefix := body[:len(body)-len(trimmed)]
+	if !strings.HasPrefix(trimmed, "@") {
+		return line
+	}
+
+	fields := strings.Fields(trimmed)
+	tag := fields[0]
+	rest := strings.TrimSpace(strings.TrimPrefix(trimmed, tag))
+
+	if renamed, ok := opts.RenameTags[tag]; ok {
+		tag = renamed
+	}
+
+	if tag == "@param" && opts.NormalizeDirections && len(fields) > 2 {
+		switch direction := strings.ToUpper(fields[2]); direction {
+		case "IN", "OUT", "INOUT":
+			if fields[2] != direction {
+				afterName := strings.TrimLeft(strings.TrimPrefix(rest, fields[1]), " \t")
+				rest = fields[1] + " " + direction + strings.TrimPrefix(afterName, fields[2])
+			}
+		}
+	}
+
+	if tag == "@created" && opts.DateLayout != "" {
+		if date, err := time.Parse(opts.DateLayout, rest); err == nil {
+			rest = date.Format(isoDate)
+		}
+	}
+
+	rewritten := prefix + tag
+	if rest != "" {
+		rewritten += " " + rest
+	}
+	if rewritten == body {
+		return line
+	}
+	return rewritten + ending
+}
+
+// Run rewrites every object and returns a patch for each one that changed,
+// in the order of objects
+func Run(objects []model.GXObject, opts Options) []Patch {
+	var patches []Patch
+	for _, obj := range objects {
+		rewritten, changed := Rewrite(obj.SourceCode, opts)
+		if !changed {
+			continue
+		}
+		patches = append(patches, Patch{Object: obj.Path, Diff: UnifiedDiff(obj.Path, obj.SourceCode, rewritten)})
+	}
+	return patches
+}
+
+// UnifiedDiff returns a unified diff between two versions of a source with
+// the same number of lines, as produced by Rewrite
+func UnifiedDiff(name, before, after string) string {
+	oldLines := strings.Split(before, "\n")
+	newLines := strings.Split(after, "\n")
+
+	var sb strings.Builder
+	sb.WriteString("--- a/" + name + "\n")
+	sb.WriteString("+++ b/" + name + "\n")
+
+	for i := 0; i < len(oldLines) && i < len(newLines); i++ {
+		if oldLines[i] == newLines[i] {
+			continue
+		}
+
+		// Extend the hunk while changes are closer than two contexts apart
+		first := max(i-contextLines, 0)
+		last := i
+		for j := i + 1; j < len(oldLines) && j <= last+2*contextLines; j++ {
+			if oldLines[j] != newLines[j] {
+				last = j
+			}
+		}
+		end := min(last+contextLines, len(oldLines)-1)
+
+		count := end - first + 1
+		sb.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", first+1, count, first+1, count))
+		for j := first; j <= end; {
+			if oldLines[j] == newLines[j] {
+				sb.WriteString(" " + oldLines[j] + "\n")
+				j++
+				continue
+			}
+			// A run of changed lines lists the removed lines, then the added ones
+			k := j
+			for k <= end && oldLines[k] != newLines[k] {
+				k++
+			}
+			for _, line := range oldLines[j:k] {
+				sb.WriteString("-" + line + "\n")
+			}
+			for _, line := range newLines[j:k] {
+				sb.WriteString("+" + line + "\n")
+			}
+			j = k
+		}
+		i = end
+	}
+	return sb.String()
+}
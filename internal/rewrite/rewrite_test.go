@@ -0,0 +1,79 @@
+package rewrite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+const source = `/**
+ * @brief Get a user
+ * @param UserId in Numeric - User identifier
+ * @param Name Out Character
+ * @created 31/12/2024
+ */
+parm(in:&UserId, out:&Name);
+// @brief outside the block is left alone
+`
+
+func TestRewrite(t *testing.T) {
+	opts := Options{
+		RenameTags:          map[string]string{"@brief": "@summary"},
+		NormalizeDirections: true,
+		DateLayout:          "02/01/2006",
+	}
+
+	got, changed := Rewrite(source, opts)
+	if !changed {
+		t.Fatal("Expected the source to change")
+	}
+
+	expected := `/**
+ * @summary Get a user
+ * @param UserId IN Numeric - User identifier
+ * @param Name OUT Character
+ * @created 2024-12-31
+ */
+parm(in:&UserId, out:&Name);
+// @brief outside the block is left alone
+`
+	if got != expected {
+		t.Errorf("Unexpected rewrite:\n%s", got)
+	}
+
+	if _, changed := Rewrite(expected, opts); changed {
+		t.Error("Rewriting an already fixed source should change nothing")
+	}
+}
+
+func TestRewriteKeepsLineEndings(t *testing.T) {
+	got, changed := Rewrite("/**\r\n * @brief Get\r\n */\r\n", Options{RenameTags: map[string]string{"@brief": "@summary"}})
+	if !changed || got != "/**\r\n * @summary Get\r\n */\r\n" {
+		t.Errorf("Unexpected rewrite: %q", got)
+	}
+}
+
+func TestRun(t *testing.T) {
+	objects := []model.GXObject{
+		{Path: "GetUser", SourceCode: source},
+		{Path: "Clean", SourceCode: "/**\n * @summary Clean\n */"},
+	}
+
+	patches := Run(objects, Options{NormalizeDirections: true})
+	if len(patches) != 1 || patches[0].Object != "GetUser" {
+		t.Fatalf("Expected one patch for GetUser, got %+v", patches)
+	}
+
+	diff := patches[0].Diff
+	for _, want := range []string{
+		"--- a/GetUser\n+++ b/GetUser\n",
+		"@@ -1,7 +1,7 @@\n",
+		"- * @param UserId in Numeric - User identifier\n- * @param Name Out Character\n",
+		"+ * @param UserId IN Numeric - User identifier\n+ * @param Name OUT Character\n",
+	} {
+		if !strings.Contains(diff, want) {
+			t.Errorf("Expected diff to contain %q, got:\n%s", want, diff)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package rewrite
+
+import (
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// todo marks stub text the maintainer has to replace
+const todo = "TODO"
+
+// Stub returns a /** */ block for an undocumented procedure, pre-filled with
+// what the extractor inferred (summary, package, parameters) and TODO
+// markers for the rest. Lines end with newline.
+func Stub(obj model.GXObject) string {
+	var sb strings.Builder
+	sb.WriteString("/**\n")
+	if doc := obj.Documentation; doc != nil && doc.Package != "" {
+		sb.WriteString(" * @package " + doc.Package + "\n")
+	}
+	summary := todo
+	if doc := obj.Documentation; doc != nil && doc.Summary != "" {
+		summary = doc.Summary
+	}
+	sb.WriteString(" * @summary " + summary + "\n")
+	sb.WriteString(" * @description " + todo + "\n")
+	for _, param := range obj.Parameters {
+		direction := strings.ToUpper(param.Direction)
+		if direction == "" {
+			direction = "IN"
+		}
+		line := " * @param " + param.Name + " " + direction
+		if param.Type != "" {
+			line += " " + param.Type
+		}
+		description := param.Description
+		if description == "" {
+			description = todo
+		}
+		sb.WriteString(line + " - " + description + "\n")
+	}
+	sb.WriteString(" */\n")
+	return sb.String()
+}
+
+// InjectStub prepends stub to source, using the line endings of source. It
+// reports false when source already has a /** */ block.
+func InjectStub(source, stub string) (string, bool) {
+	if strings.Contains(source, "/**") {
+		return source, false
+	}
+	if strings.Contains(source, "\r\n") {
+		stub = strings.ReplaceAll(stub, "\n", "\r\n")
+	}
+	return stub + source, true
+}
@@ -0,0 +1,38 @@
+package rewrite
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/parser"
+)
+
+func TestStub(t *testing.T) {
+	obj := model.GXObject{
+		Path:          "GetUser",
+		Documentation: &model.DocComment{IsAutoGenerated: true, Package: "users", Summary: "Get User"},
+		Parameters: []model.ParameterDoc{
+			{Name: "UserID", Direction: "in", Type: "Numeric", Description: "The id"},
+			{Name: "User", Direction: "OUT", Type: "sdtUser"},
+		},
+	}
+
+	source, ok := InjectStub("&User.Load(&UserID)\r\n", Stub(obj))
+	if !ok {
+		t.Fatal("Expected a stub to be injected")
+	}
+	doc, err := parser.Parse(source)
+	if err != nil || doc == nil {
+		t.Fatalf("Stub does not parse: %v", err)
+	}
+	if doc.Package != "users" || doc.Summary != "Get User" || len(doc.Parameters) != 2 {
+		t.Errorf("Unexpected stub documentation: %+v", doc)
+	}
+	if doc.Parameters[0].Direction != "IN" || doc.Parameters[1].Description != "TODO" {
+		t.Errorf("Unexpected stub parameters: %+v", doc.Parameters)
+	}
+
+	if _, ok := InjectStub(source, Stub(obj)); ok {
+		t.Error("Expected no stub for a source with a doc comment")
+	}
+}
@@ -0,0 +1,267 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Difference kinds
+const (
+	Missing = "missing" // in the golden directory but not generated
+	Extra   = "extra"   // generated but not in the golden directory
+	Changed = "changed" // present in both with different content
+)
+
+// maxDiffLines caps the diff lines reported per file
+const maxDiffLines = 40
+
+// goldenManifest lists the files Update wrote to a golden directory, so
+// the next update removes only those
+const goldenManifest = ".gxdocgen-snapshot.json"
+
+// Ignored lists generated files that never take part in a comparison
+var Ignored = map[string]bool{
+	".gxdocgen-manifest.json": true,
+	".gxdocgen-state.json":    true,
+	goldenManifest:            true,
+}
+
+// Difference is a file that does not match its golden copy
+type Difference struct {
+	File string
+	Kind string
+	// Diff holds a line diff for changed text files
+	Diff []string
+}
+
+// Compare checks every file under actual against golden and returns the
+// differences sorted by file name. Paths use forward slashes.
+func Compare(golden, actual string) ([]Difference, error) {
+	goldenFiles, err := listFiles(golden)
+	if err != nil {
+		return nil, err
+	}
+	actualFiles, err := listFiles(actual)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []Difference
+	for file := range goldenFiles {
+		if !actualFiles[file] {
+			diffs = append(diffs, Difference{File: file, Kind: Missing})
+		}
+	}
+	for file := range actualFiles {
+		if !goldenFiles[file] {
+			diffs = append(diffs, Difference{File: file, Kind: Extra})
+			continue
+		}
+		want, err := os.ReadFile(filepath.Join(golden, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, err
+		}
+		got, err := os.ReadFile(filepath.Join(actual, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(want, got) {
+			continue
+		}
+		d := Difference{File: file, Kind: Changed}
+		if isText(want) && isText(got) {
+			d.Diff = LineDiff(string(want), string(got))
+		}
+		diffs = append(diffs, d)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].File < diffs[j].File
+	})
+	return diffs, nil
+}
+
+// Update replaces the files of golden with the files under actual. Only
+// files listed in the manifest of a previous update are removed, and a
+// non-empty directory without one is refused, so a mistyped path never
+// loses unrelated files.
+func Update(golden, actual string) error {
+	previous, err := readGoldenManifest(golden)
+	if err != nil {
+		return err
+	}
+	files, err := listFiles(actual)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range previous {
+		if err := removeGoldenFile(golden, file); err != nil {
+			return err
+		}
+	}
+
+	written := make([]string, 0, len(files))
+	for file := range files {
+		data, err := os.ReadFile(filepath.Join(actual, filepath.FromSlash(file)))
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(golden, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return err
+		}
+		written = append(written, file)
+	}
+
+	sort.Strings(written)
+	data, err := json.MarshalIndent(written, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(golden, 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(golden, goldenManifest), append(data, '\n'), 0644)
+}
+
+// readGoldenManifest returns the files a previous update wrote to golden.
+// A missing or empty directory has none; a non-empty one without a
+// manifest is not a golden directory and is refused.
+func readGoldenManifest(golden string) ([]string, error) {
+	entries, err := os.ReadDir(golden)
+	if os.IsNotExist(err) || (err == nil && len(entries) == 0) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(golden, goldenManifest))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s is not empty and has no %s, so it was not written by snapshot; refusing to overwrite it", golden, goldenManifest)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	if err := json.Unmarshal(data, &files); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", goldenManifest, err)
+	}
+	for _, file := range files {
+		if !filepath.IsLocal(filepath.FromSlash(file)) {
+			return nil, fmt.Errorf("%s lists %q, which is outside %s", goldenManifest, file, golden)
+		}
+	}
+	return files, nil
+}
+
+// removeGoldenFile removes file from golden, along with the directories it
+// leaves empty
+func removeGoldenFile(golden, file string) error {
+	path := filepath.Join(golden, filepath.FromSlash(file))
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for dir := filepath.Dir(path); dir != filepath.Clean(golden); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// listFiles returns the set of regular files under root, relative to it.
+// A missing root is treated as empty.
+func listFiles(root string) (map[string]bool, error) {
+	files := make(map[string]bool)
+	if _, err := os.Stat(root); os.IsNotExist(err) {
+		return files, nil
+	}
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !Ignored[rel] {
+			files[rel] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", root, err)
+	}
+	return files, nil
+}
+
+// isText reports whether data looks like text rather than binary content
+func isText(data []byte) bool {
+	return !bytes.Contains(data, []byte{0})
+}
+
+// LineDiff returns a minimal line diff from want to got. Removed lines are
+// prefixed with "- ", added lines with "+ " and each hunk is introduced by
+// an "@@ line N @@" header. Output is capped at maxDiffLines lines.
+func LineDiff(want, got string) []string {
+	a := strings.Split(strings.TrimSuffix(want, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+
+	// Longest common subsequence table, filled from the end
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out []string
+	inHunk := false
+	emit := func(line string, golden int) {
+		if !inHunk {
+			out = append(out, fmt.Sprintf("@@ line %d @@", golden+1))
+			inHunk = true
+		}
+		out = append(out, line)
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			inHunk = false
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			emit("- "+a[i], i)
+			i++
+		default:
+			emit("+ "+b[j], i)
+			j++
+		}
+	}
+
+	if len(out) > maxDiffLines {
+		hidden := len(out) - maxDiffLines
+		out = append(out[:maxDiffLines], fmt.Sprintf("... %d more line(s)", hidden))
+	}
+	return out
+}
@@ -0,0 +1,103 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTree(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	golden, actual := t.TempDir(), t.TempDir()
+	writeTree(t, golden, map[string]string{
+		"README.md":        "# KB\n\nsame\n",
+		"users/GetUser.md": "# GetUser\n\nold line\nkept\n",
+		"removed.md":       "gone\n",
+	})
+	writeTree(t, actual, map[string]string{
+		"README.md":               "# KB\n\nsame\n",
+		"users/GetUser.md":        "# GetUser\n\nnew line\nkept\n",
+		"added.md":                "new\n",
+		".gxdocgen-manifest.json": "{}",
+	})
+
+	diffs, err := Compare(golden, actual)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+
+	want := []Difference{
+		{File: "added.md", Kind: Extra},
+		{File: "removed.md", Kind: Missing},
+		{File: "users/GetUser.md", Kind: Changed, Diff: []string{"@@ line 3 @@", "- old line", "+ new line"}},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("Compare = %#v, want %#v", diffs, want)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	golden, first, second := filepath.Join(t.TempDir(), "golden"), t.TempDir(), t.TempDir()
+	writeTree(t, first, map[string]string{"stale/old.md": "old\n", "pkg/page.md": "v1\n"})
+	writeTree(t, second, map[string]string{"pkg/page.md": "page\n"})
+
+	if err := Update(golden, first); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+	writeTree(t, golden, map[string]string{"notes.txt": "kept\n"})
+	if err := Update(golden, second); err != nil {
+		t.Fatalf("second Update failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(golden, "stale")); !os.IsNotExist(err) {
+		t.Errorf("Expected the stale snapshot files removed, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(golden, "notes.txt")); err != nil {
+		t.Errorf("Expected a file the snapshot did not write kept: %v", err)
+	}
+	diffs, err := Compare(golden, second)
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	want := []Difference{{File: "notes.txt", Kind: Missing}}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("Compare after Update = %#v, want %#v", diffs, want)
+	}
+}
+
+func TestUpdateRefusesForeignDirectory(t *testing.T) {
+	golden, actual := t.TempDir(), t.TempDir()
+	writeTree(t, golden, map[string]string{"src/main.go": "package main\n"})
+	writeTree(t, actual, map[string]string{"page.md": "page\n"})
+
+	if err := Update(golden, actual); err == nil {
+		t.Fatal("Expected Update to refuse a non-empty directory without a snapshot manifest")
+	}
+	if _, err := os.Stat(filepath.Join(golden, "src", "main.go")); err != nil {
+		t.Errorf("Expected the directory left untouched: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(golden, "page.md")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing written, got %v", err)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	got := LineDiff("a\nb\nc\nd\n", "a\nc\nd\ne\n")
+	want := []string{"@@ line 2 @@", "- b", "@@ line 5 @@", "+ e"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LineDiff = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,227 @@
+// Package storage abstracts where generated documentation is written, so
+// renderers don't need to know whether files end up in a local directory,
+// in memory or in an archive.
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Storage receives generated files. Names are slash-separated paths relative
+// to the output root, such as "users/GetUser.md". Open (from fs.FS) reads
+// files back, e.g. the manifest of a previous run; implementations must be
+// safe for concurrent use.
+type Storage interface {
+	fs.FS
+	WriteFile(name string, data []byte) error
+}
+
+// dirStorage writes files under a local directory
+type dirStorage struct {
+	fs.FS
+	root string
+}
+
+// Dir returns a Storage writing to the local directory root, creating it
+// and any subdirectories as needed
+func Dir(root string) Storage {
+	return &dirStorage{FS: os.DirFS(root), root: root}
+}
+
+func (d *dirStorage) WriteFile(name string, data []byte) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	target := filepath.Join(d.root, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(target, data, 0644)
+}
+
+// Memory keeps files in memory. It can be served with http.FS or copied to
+// another Storage.
+type Memory struct {
+	mu    sync.RWMutex
+	files map[string][]byte
+}
+
+// NewMemory returns an empty in-memory Storage
+func NewMemory() *Memory {
+	return &Memory{files: make(map[string][]byte)}
+}
+
+// WriteFile stores a copy of data under name, replacing any previous content
+func (m *Memory) WriteFile(name string, data []byte) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+// Files returns the names of all stored files, sorted
+func (m *Memory) Files() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Open opens a stored file, or a directory implied by the stored names
+func (m *Memory) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if data, ok := m.files[name]; ok {
+		return &memFile{info: fileInfo{name: path.Base(name), size: int64(len(data))}, Reader: bytes.NewReader(data)}, nil
+	}
+
+	prefix := name + "/"
+	if name == "." {
+		prefix = ""
+	}
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for file, data := range m.files {
+		if !strings.HasPrefix(file, prefix) {
+			continue
+		}
+		child, rest, isDir := strings.Cut(file[len(prefix):], "/")
+		if seen[child] {
+			continue
+		}
+		seen[child] = true
+		if isDir && rest != "" {
+			entries = append(entries, fs.FileInfoToDirEntry(fileInfo{name: child, dir: true}))
+		} else {
+			entries = append(entries, fs.FileInfoToDirEntry(fileInfo{name: child, size: int64(len(data))}))
+		}
+	}
+	if len(entries) == 0 && name != "." {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return &memDir{info: fileInfo{name: path.Base(name), dir: true}, entries: entries}, nil
+}
+
+// CopyTo writes every stored file to dst, in name order
+func (m *Memory) CopyTo(dst Storage) error {
+	for _, name := range m.Files() {
+		m.mu.RLock()
+		data := m.files[name]
+		m.mu.RUnlock()
+		if err := dst.WriteFile(name, data); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Zip collects files in memory and writes them as a zip archive on Close,
+// sorted by name so the archive is reproducible
+type Zip struct {
+	*Memory
+	w io.Writer
+}
+
+// NewZip returns a Storage archiving its files to w when closed
+func NewZip(w io.Writer) *Zip {
+	return &Zip{Memory: NewMemory(), w: w}
+}
+
+// Close writes the archive. It does not close the underlying writer.
+func (z *Zip) Close() error {
+	archive := zip.NewWriter(z.w)
+	for _, name := range z.Files() {
+		z.mu.RLock()
+		data := z.files[name]
+		z.mu.RUnlock()
+		entry, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		if err != nil {
+			return err
+		}
+		if _, err := entry.Write(data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// fileInfo describes an in-memory file or directory
+type fileInfo struct {
+	name string
+	size int64
+	dir  bool
+}
+
+func (fi fileInfo) Name() string { return fi.name }
+func (fi fileInfo) Size() int64  { return fi.size }
+func (fi fileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+func (fi fileInfo) ModTime() time.Time { return time.Time{} }
+func (fi fileInfo) IsDir() bool        { return fi.dir }
+func (fi fileInfo) Sys() any           { return nil }
+
+// memFile is an open in-memory file
+type memFile struct {
+	*bytes.Reader
+	info fileInfo
+}
+
+func (f *memFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *memFile) Close() error               { return nil }
+
+// memDir is an open in-memory directory
+type memDir struct {
+	info    fileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *memDir) Stat() (fs.FileInfo, error) { return d.info, nil }
+func (d *memDir) Close() error               { return nil }
+func (d *memDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.name, Err: fs.ErrInvalid}
+}
+
+// ReadDir implements fs.ReadDirFile
+func (d *memDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	rest := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return rest, nil
+	}
+	if len(rest) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(rest) {
+		n = len(rest)
+	}
+	d.offset += n
+	return rest[:n], nil
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMemory(t *testing.T) {
+	m := NewMemory()
+	for name, content := range map[string]string{
+		"README.md":          "# KB",
+		"users/GetUser.md":   "# GetUser",
+		"badges/kb-test.svg": "<svg/>",
+	} {
+		if err := m.WriteFile(name, []byte(content)); err != nil {
+			t.Fatalf("WriteFile(%s) failed: %v", name, err)
+		}
+	}
+	if err := fstest.TestFS(m, "README.md", "users/GetUser.md", "badges/kb-test.svg"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.WriteFile("../outside.md", nil); err == nil {
+		t.Error("Expected invalid names to be rejected")
+	}
+
+	dir := t.TempDir()
+	if err := m.CopyTo(Dir(dir)); err != nil {
+		t.Fatalf("CopyTo failed: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "users", "GetUser.md")); err != nil || string(data) != "# GetUser" {
+		t.Errorf("Unexpected copied file %q (%v)", data, err)
+	}
+}
+
+func TestZip(t *testing.T) {
+	var buf bytes.Buffer
+	z := NewZip(&buf)
+	z.WriteFile("b.md", []byte("b"))
+	z.WriteFile("a/c.md", []byte("c"))
+	if _, err := fs.ReadFile(z, "b.md"); err != nil {
+		t.Errorf("Expected written files to be readable before Close: %v", err)
+	}
+	if err := z.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.File) != 2 || r.File[0].Name != "a/c.md" || r.File[1].Name != "b.md" {
+		t.Fatalf("Unexpected entries: %v", r.File)
+	}
+	entry, _ := r.File[0].Open()
+	if data, _ := io.ReadAll(entry); string(data) != "c" {
+		t.Errorf("Unexpected content %q", data)
+	}
+}
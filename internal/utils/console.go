@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"os"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	consoleOnce sync.Once
+	stdoutANSI  bool
+	stderrANSI  bool
+
+	// colorDisabled is set by SetColor(false)
+	colorDisabled int32
+)
+
+// SetColor turns colored output off (false) or back to automatic detection (true)
+func SetColor(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&colorDisabled, 0)
+	} else {
+		atomic.StoreInt32(&colorDisabled, 1)
+	}
+}
+
+// ansiSupported reports whether f is an interactive terminal that understands
+// ANSI escape sequences, enabling virtual terminal processing on Windows
+func ansiSupported(f *os.File) bool {
+	consoleOnce.Do(func() {
+		stdoutANSI = isTerminal(os.Stdout) && enableVirtualTerminal(os.Stdout)
+		stderrANSI = isTerminal(os.Stderr) && enableVirtualTerminal(os.Stderr)
+	})
+	switch f {
+	case os.Stdout:
+		return stdoutANSI
+	case os.Stderr:
+		return stderrANSI
+	default:
+		return false
+	}
+}
+
+// colorsEnabled reports whether messages written to f should be colored.
+// Colors are off with --no-color, when NO_COLOR is set (https://no-color.org)
+// or when f is not a terminal (pipes, files, CI logs).
+func colorsEnabled(f *os.File) bool {
+	return atomic.LoadInt32(&colorDisabled) == 0 && os.Getenv("NO_COLOR") == "" && ansiSupported(f)
+}
+
+// paint wraps text in an ANSI color when colors are enabled for f
+func paint(f *os.File, color, text string) string {
+	if !colorsEnabled(f) {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// isTerminal reports whether f is an interactive terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
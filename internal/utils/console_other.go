@@ -0,0 +1,10 @@
+//go:build !windows
+
+package utils
+
+import "os"
+
+// enableVirtualTerminal is a no-op: Unix terminals handle ANSI sequences natively
+func enableVirtualTerminal(f *os.File) bool {
+	return true
+}
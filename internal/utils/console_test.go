@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"os"
+	"testing"
+)
+
+func TestPaint(t *testing.T) {
+	// A regular file is never a terminal
+	f, err := os.CreateTemp(t.TempDir(), "log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if got := paint(f, colorRed, "[ERROR]"); got != "[ERROR]" {
+		t.Errorf("Expected no color for a non-terminal, got %q", got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if colorsEnabled(os.Stdout) {
+		t.Error("Expected NO_COLOR to disable colors")
+	}
+}
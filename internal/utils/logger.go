@@ -3,8 +3,40 @@ package utils
 import (
 	"fmt"
 	"os"
+	"sync/atomic"
 )
 
+// Level controls which messages are printed
+type Level int32
+
+// Log levels, from least to most output. Warnings and errors are always printed.
+const (
+	LevelQuiet   Level = iota // warnings and errors only
+	LevelNormal               // plus info and success messages
+	LevelVerbose              // plus verbose details
+	LevelDebug                // plus debug traces (XPath misses, extraction decisions)
+)
+
+var currentLevel = int32(LevelNormal)
+
+// Number of warnings and errors logged so far, for the run summary
+var warningCount, errorCount int64
+
+// SetLevel sets the minimum level of printed messages
+func SetLevel(level Level) {
+	atomic.StoreInt32(&currentLevel, int32(level))
+}
+
+// enabled reports whether messages of the given level are printed
+func enabled(level Level) bool {
+	return Level(atomic.LoadInt32(&currentLevel)) >= level
+}
+
+// Counts returns how many warnings and errors have been logged
+func Counts() (warnings, errors int) {
+	return int(atomic.LoadInt64(&warningCount)), int(atomic.LoadInt64(&errorCount))
+}
+
 // ANSI color codes
 const (
 	colorReset  = "\033[0m"
@@ -13,30 +45,57 @@ const (
 	colorYellow = "\033[33m"
 	colorBlue   = "\033[34m"
 	colorCyan   = "\033[36m"
+	colorGray   = "\033[90m"
 )
 
 // Info logs an informational message with cyan color
 func Info(format string, args ...interface{}) {
+	if !enabled(LevelNormal) {
+		return
+	}
 	message := fmt.Sprintf(format, args...)
-	fmt.Fprintf(os.Stdout, "%s[INFO]%s %s\n", colorCyan, colorReset, message)
+	fmt.Fprintf(os.Stdout, "%s %s\n", paint(os.Stdout, colorCyan, "[INFO]"), message)
 }
 
 // Success logs a success message with green color
 func Success(format string, args ...interface{}) {
+	if !enabled(LevelNormal) {
+		return
+	}
+	message := fmt.Sprintf(format, args...)
+	fmt.Fprintf(os.Stdout, "%s %s\n", paint(os.Stdout, colorGreen, "[SUCCESS]"), message)
+}
+
+// Verbose logs a detail message, shown with --verbose or --debug
+func Verbose(format string, args ...interface{}) {
+	if !enabled(LevelVerbose) {
+		return
+	}
+	message := fmt.Sprintf(format, args...)
+	fmt.Fprintf(os.Stdout, "%s %s\n", paint(os.Stdout, colorBlue, "[VERBOSE]"), message)
+}
+
+// Debug logs a trace message, shown with --debug
+func Debug(format string, args ...interface{}) {
+	if !enabled(LevelDebug) {
+		return
+	}
 	message := fmt.Sprintf(format, args...)
-	fmt.Fprintf(os.Stdout, "%s[SUCCESS]%s %s\n", colorGreen, colorReset, message)
+	fmt.Fprintf(os.Stderr, "%s %s\n", paint(os.Stderr, colorGray, "[DEBUG]"), message)
 }
 
 // Warning logs a warning message with yellow color
 func Warning(format string, args ...interface{}) {
+	atomic.AddInt64(&warningCount, 1)
 	message := fmt.Sprintf(format, args...)
-	fmt.Fprintf(os.Stderr, "%s[WARNING]%s %s\n", colorYellow, colorReset, message)
+	fmt.Fprintf(os.Stderr, "%s %s\n", paint(os.Stderr, colorYellow, "[WARNING]"), message)
 }
 
 // Error logs an error message with red color
 func Error(format string, args ...interface{}) {
+	atomic.AddInt64(&errorCount, 1)
 	message := fmt.Sprintf(format, args...)
-	fmt.Fprintf(os.Stderr, "%s[ERROR]%s %s\n", colorRed, colorReset, message)
+	fmt.Fprintf(os.Stderr, "%s %s\n", paint(os.Stderr, colorRed, "[ERROR]"), message)
 }
 
 // Fatal logs a fatal error message and exits the program
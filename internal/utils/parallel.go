@@ -0,0 +1,35 @@
+package utils
+
+import "sync"
+
+// Parallel calls fn for every index in [0, count) using up to jobs workers.
+// With jobs <= 1 the calls run sequentially in order. Callers keep output
+// deterministic by writing results into a slice at the given index.
+func Parallel(count, jobs int, fn func(i int)) {
+	if jobs <= 1 || count <= 1 {
+		for i := 0; i < count; i++ {
+			fn(i)
+		}
+		return
+	}
+	if jobs > count {
+		jobs = count
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < jobs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
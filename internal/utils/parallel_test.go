@@ -0,0 +1,26 @@
+package utils
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallel(t *testing.T) {
+	for _, jobs := range []int{0, 1, 4, 100} {
+		results := make([]int, 50)
+		var calls int32
+		Parallel(len(results), jobs, func(i int) {
+			atomic.AddInt32(&calls, 1)
+			results[i] = i * i
+		})
+
+		if calls != 50 {
+			t.Errorf("jobs=%d: expected 50 calls, got %d", jobs, calls)
+		}
+		for i, r := range results {
+			if r != i*i {
+				t.Errorf("jobs=%d: results[%d] = %d, expected %d", jobs, i, r, i*i)
+			}
+		}
+	}
+}
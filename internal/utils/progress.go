@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// progressInterval limits how often the progress line is redrawn
+const progressInterval = 100 * time.Millisecond
+
+// Progress shows a "label: done/total (percent)" line that is redrawn in
+// place. It only draws on an interactive terminal, so logs and CI output
+// stay clean. Safe for concurrent use.
+type Progress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	label    string
+	total    int
+	done     int
+	lastDraw time.Time
+}
+
+// NewProgress creates a progress indicator for total items
+func NewProgress(label string, total int) *Progress {
+	p := &Progress{label: label, total: total}
+	if ansiSupported(os.Stderr) {
+		p.out = os.Stderr
+	}
+	return p
+}
+
+// Increment marks one more item as done
+func (p *Progress) Increment() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.done++
+	if p.out != nil && (p.done == p.total || time.Since(p.lastDraw) >= progressInterval) {
+		p.draw()
+	}
+}
+
+// Finish clears the progress line
+func (p *Progress) Finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.out != nil {
+		fmt.Fprint(p.out, "\r\033[K")
+	}
+}
+
+// draw writes the current progress line
+func (p *Progress) draw() {
+	percent := 100
+	if p.total > 0 {
+		percent = p.done * 100 / p.total
+	}
+	fmt.Fprintf(p.out, "\r\033[K%s: %d/%d (%d%%)", p.label, p.done, p.total, percent)
+	p.lastDraw = time.Now()
+}
@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Timings accumulates the duration of named phases (extract, parse,
+// generate, ...) in the order they first ran. Safe for concurrent use.
+type Timings struct {
+	mu        sync.Mutex
+	order     []string
+	durations map[string]time.Duration
+
+	// objects holds the per-phase durations of each object
+	objects map[string]*ObjectTiming
+}
+
+// ObjectTiming is the time spent on a single object
+type ObjectTiming struct {
+	Name  string
+	Total time.Duration
+
+	order  []string
+	phases map[string]time.Duration
+}
+
+// String renders the object's breakdown, e.g. "GetUser 1.2s (parse 200ms, render 1s)"
+func (o ObjectTiming) String() string {
+	var parts []string
+	for _, phase := range o.order {
+		parts = append(parts, fmt.Sprintf("%s %s", phase, o.phases[phase].Round(time.Microsecond)))
+	}
+	return fmt.Sprintf("%s %s (%s)", o.Name, o.Total.Round(time.Microsecond), strings.Join(parts, ", "))
+}
+
+// NewTimings creates an empty phase timing breakdown
+func NewTimings() *Timings {
+	return &Timings{durations: make(map[string]time.Duration), objects: make(map[string]*ObjectTiming)}
+}
+
+// Start begins timing a phase and returns a function that stops it.
+// Calling Start for a phase again adds to its total. A nil Timings is a no-op.
+func (t *Timings) Start(phase string) func() {
+	if t == nil {
+		return func() {}
+	}
+	started := time.Now()
+	return func() {
+		t.Add(phase, time.Since(started))
+	}
+}
+
+// Add records d against a phase
+func (t *Timings) Add(phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, exists := t.durations[phase]; !exists {
+		t.order = append(t.order, phase)
+	}
+	t.durations[phase] += d
+}
+
+// AddObject records d against a phase of a single object and logs it in
+// verbose mode
+func (t *Timings) AddObject(name, phase string, d time.Duration) {
+	if t == nil {
+		return
+	}
+	Verbose("%s: %s took %s", name, phase, d.Round(time.Microsecond))
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	obj := t.objects[name]
+	if obj == nil {
+		obj = &ObjectTiming{Name: name, phases: make(map[string]time.Duration)}
+		t.objects[name] = obj
+	}
+	if _, exists := obj.phases[phase]; !exists {
+		obj.order = append(obj.order, phase)
+	}
+	obj.phases[phase] += d
+	obj.Total += d
+}
+
+// Slowest returns the n objects that took longest, slowest first
+func (t *Timings) Slowest(n int) []ObjectTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	objects := make([]ObjectTiming, 0, len(t.objects))
+	for _, obj := range t.objects {
+		objects = append(objects, *obj)
+	}
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].Total != objects[j].Total {
+			return objects[i].Total > objects[j].Total
+		}
+		return objects[i].Name < objects[j].Name
+	})
+	if len(objects) > n {
+		objects = objects[:n]
+	}
+	return objects
+}
+
+// Duration returns the accumulated duration of a phase
+func (t *Timings) Duration(phase string) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.durations[phase]
+}
+
+// String renders the breakdown, e.g. "extract 120ms, parse 1.3s, generate 800ms"
+func (t *Timings) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var parts []string
+	for _, phase := range t.order {
+		parts = append(parts, fmt.Sprintf("%s %s", phase, t.durations[phase].Round(time.Millisecond)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Report logs the per-phase timing breakdown and the total. In verbose mode
+// it is followed by the slowest objects when slowest > 0.
+func (t *Timings) Report(total time.Duration, slowest int) {
+	Info("Timing: %s (total %s)", t.String(), total.Round(time.Millisecond))
+	if slowest <= 0 {
+		return
+	}
+	objects := t.Slowest(slowest)
+	if len(objects) == 0 {
+		return
+	}
+	Verbose("Slowest objects:")
+	for i, obj := range objects {
+		Verbose("  %d. %s", i+1, obj)
+	}
+}
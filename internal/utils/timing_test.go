@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimings(t *testing.T) {
+	timings := NewTimings()
+	timings.Add("extract", 100*time.Millisecond)
+	timings.Add("parse", 2*time.Second)
+	timings.Add("extract", 50*time.Millisecond)
+
+	if d := timings.Duration("extract"); d != 150*time.Millisecond {
+		t.Errorf("Expected extract to total 150ms, got %s", d)
+	}
+	if s := timings.String(); s != "extract 150ms, parse 2s" {
+		t.Errorf("Unexpected breakdown: %q", s)
+	}
+
+	stop := timings.Start("generate")
+	stop()
+	if timings.String() == "extract 150ms, parse 2s" {
+		t.Error("Expected generate phase to be recorded")
+	}
+
+	var none *Timings
+	none.Start("noop")()
+	none.Add("noop", time.Second)
+}
+
+func TestTimingsSlowest(t *testing.T) {
+	timings := NewTimings()
+	timings.AddObject("GetUser", "parse", 2*time.Millisecond)
+	timings.AddObject("Sales/Huge", "parse", 30*time.Millisecond)
+	timings.AddObject("Sales/Huge", "render", 1200*time.Millisecond)
+	timings.AddObject("Small", "parse", time.Millisecond)
+	timings.AddObject("GetUser", "render", 3*time.Millisecond)
+
+	slowest := timings.Slowest(2)
+	if len(slowest) != 2 || slowest[0].Name != "Sales/Huge" || slowest[1].Name != "GetUser" {
+		t.Fatalf("Expected Sales/Huge and GetUser, got %v", slowest)
+	}
+	if s := slowest[0].String(); s != "Sales/Huge 1.23s (parse 30ms, render 1.2s)" {
+		t.Errorf("Unexpected breakdown: %q", s)
+	}
+	if len(timings.Slowest(10)) != 3 {
+		t.Error("Expected every object when asking for more than were timed")
+	}
+
+	var none *Timings
+	none.AddObject("noop", "parse", time.Second)
+}
@@ -0,0 +1,195 @@
+package verify
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Issue is a syntax problem found in a generated file
+type Issue struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (i Issue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.File, i.Message)
+}
+
+var (
+	// Markdown link or image target: [text](target)
+	linkRegex = regexp.MustCompile(`\]\(([^)\s]+)\)`)
+	// Inline code spans, which may contain link-like text
+	inlineCodeRegex = regexp.MustCompile("`[^`]*`")
+	// Table separator row: | --- | :---: |
+	separatorRegex = regexp.MustCompile(`^\|(\s*:?-+:?\s*\|)+$`)
+)
+
+// Dir re-parses every generated file under root and reports syntax problems:
+// malformed JSON, SVG or PDF files, and Markdown with broken tables,
+// unclosed code fences or relative links to missing files
+func Dir(root string) ([]Issue, error) {
+	return FS(os.DirFS(root))
+}
+
+// FS is Dir for generated files held in fsys, e.g. in-memory output
+func FS(fsys fs.FS) ([]Issue, error) {
+	var issues []Issue
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		var found []Issue
+		switch strings.ToLower(path.Ext(name)) {
+		case ".md":
+			found = checkMarkdown(string(data), fsys, path.Dir(name))
+		case ".json":
+			found = checkJSON(data)
+		case ".svg":
+			found = checkXML(data)
+		case ".pdf":
+			found = checkPDF(data)
+		}
+		for _, issue := range found {
+			issue.File = name
+			issues = append(issues, issue)
+		}
+		return nil
+	})
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		return issues[i].Line < issues[j].Line
+	})
+	return issues, err
+}
+
+// checkJSON reports whether data is valid JSON
+func checkJSON(data []byte) []Issue {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return []Issue{{Message: "invalid JSON: " + err.Error()}}
+	}
+	return nil
+}
+
+// checkXML reports whether data is well-formed XML
+func checkXML(data []byte) []Issue {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return []Issue{{Message: "malformed XML: " + err.Error()}}
+		}
+	}
+}
+
+// checkPDF checks the PDF header and trailer markers
+func checkPDF(data []byte) []Issue {
+	if !bytes.HasPrefix(data, []byte("%PDF-")) {
+		return []Issue{{Message: "missing %PDF header"}}
+	}
+	if !bytes.HasSuffix(bytes.TrimSpace(data), []byte("%%EOF")) {
+		return []Issue{{Message: "missing %%EOF trailer"}}
+	}
+	return nil
+}
+
+// checkMarkdown validates tables, code fences and relative links
+func checkMarkdown(content string, fsys fs.FS, dir string) []Issue {
+	var issues []Issue
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	inFence, fenceLine := false, 0
+	tableStart, tableColumns := 0, 0
+
+	for i, line := range lines {
+		lineNo := i + 1
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+			fenceLine = lineNo
+			tableStart = 0
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		// Tables: a header row, a separator row, then rows with the same number of cells
+		if strings.HasPrefix(trimmed, "|") {
+			cells := countCells(trimmed)
+			switch {
+			case tableStart == 0:
+				tableStart, tableColumns = lineNo, cells
+			case lineNo == tableStart+1:
+				if !separatorRegex.MatchString(trimmed) {
+					issues = append(issues, Issue{Line: lineNo, Message: "table header is not followed by a separator row"})
+				} else if cells != tableColumns {
+					issues = append(issues, Issue{Line: lineNo, Message: fmt.Sprintf("table separator has %d columns, header has %d", cells, tableColumns)})
+				}
+			case cells != tableColumns:
+				issues = append(issues, Issue{Line: lineNo, Message: fmt.Sprintf("table row has %d cells, header has %d", cells, tableColumns)})
+			}
+		} else {
+			tableStart = 0
+		}
+
+		// Relative links must point to existing files
+		for _, m := range linkRegex.FindAllStringSubmatch(inlineCodeRegex.ReplaceAllString(line, ""), -1) {
+			if target := localTarget(m[1]); target != "" {
+				if _, err := fs.Stat(fsys, path.Join(dir, target)); err != nil {
+					issues = append(issues, Issue{Line: lineNo, Message: "broken link: " + m[1]})
+				}
+			}
+		}
+	}
+
+	if inFence {
+		issues = append(issues, Issue{Line: fenceLine, Message: "code fence is never closed"})
+	}
+	return issues
+}
+
+// countCells counts the cells of a table row, ignoring escaped pipes
+func countCells(row string) int {
+	row = strings.ReplaceAll(row, `\|`, "")
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+	return strings.Count(row, "|") + 1
+}
+
+// localTarget returns the file part of a relative link, or "" for external
+// links and same-page anchors
+func localTarget(link string) string {
+	if strings.Contains(link, "://") || strings.HasPrefix(link, "mailto:") || strings.HasPrefix(link, "#") {
+		return ""
+	}
+	if idx := strings.Index(link, "#"); idx != -1 {
+		link = link[:idx]
+	}
+	return link
+}
@@ -0,0 +1,53 @@
+package verify
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDir(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"README.md": "# KB\n\n[Ok](./users/GetUser.md#signature) [Web](https://example.com) [Top](#kb)\n" +
+			"`[not a link](missing.md)`\n\n" +
+			"| Name | Description |\n|------|-------------|\n| A | pipe \\| escaped |\n| B | extra | cell |\n\n" +
+			"[Broken](./missing.md)\n",
+		"users/GetUser.md": "| Name |\n| Type |\n\n```genexus\n| not | a | table |\n",
+		"summary.json":     `{"kb": "KB"`,
+		"badges/kb.svg":    `<svg><text>ok</svg>`,
+		"cheatsheet.pdf":   "%PDF-1.4\n...\n%%EOF\n",
+		"coverage.json":    `{"total": 1}`,
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	issues, err := Dir(root)
+	if err != nil {
+		t.Fatalf("Dir failed: %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.String())
+	}
+	expected := []string{
+		"README.md:9: table row has 3 cells, header has 2",
+		"README.md:11: broken link: ./missing.md",
+		"badges/kb.svg: malformed XML: XML syntax error on line 1: element <text> closed by </svg>",
+		"summary.json: invalid JSON: unexpected end of JSON input",
+		"users/GetUser.md:2: table header is not followed by a separator row",
+		"users/GetUser.md:4: code fence is never closed",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Unexpected issues:\n%q\nexpected:\n%q", got, expected)
+	}
+}
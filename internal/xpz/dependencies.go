@@ -0,0 +1,69 @@
+package xpz
+
+import (
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// typePrefixes maps ATTCUSTOMTYPE prefixes to the kind of object they reference
+var typePrefixes = map[string]string{
+	"sdt": "SDT",
+	"bc":  "Transaction", // Business Component of a Transaction
+}
+
+// ExtractDependencies lists the SDTs and Transactions an object references
+// through the types of its variables. Built-in GeneXus types are ignored.
+func ExtractDependencies(objNode *xmlquery.Node) []model.Dependency {
+	variablesPart := xmlquery.FindOne(objNode, "//Part[@type='"+GXPartVariables+"']")
+	if variablesPart == nil {
+		return nil
+	}
+
+	var deps []model.Dependency
+	seen := make(map[string]bool)
+
+	for _, varNode := range xmlquery.Find(variablesPart, "//Variable") {
+		for _, prop := range xmlquery.Find(varNode, "Properties/Property") {
+			if GetText(prop, "Name") != "ATTCUSTOMTYPE" {
+				continue
+			}
+			if dep, ok := parseTypeDependency(GetText(prop, "Value")); ok && !seen[dep.Type+"|"+dep.Name] {
+				seen[dep.Type+"|"+dep.Name] = true
+				deps = append(deps, dep)
+			}
+		}
+	}
+
+	return deps
+}
+
+// parseTypeDependency converts a raw ATTCUSTOMTYPE value (e.g., "sdt:Order"
+// or "sdt:Messages, GeneXus.Common") into a Dependency
+func parseTypeDependency(rawType string) (model.Dependency, bool) {
+	parts := strings.SplitN(strings.TrimSpace(rawType), ":", 2)
+	if len(parts) != 2 {
+		return model.Dependency{}, false
+	}
+
+	kind, ok := typePrefixes[strings.ToLower(parts[0])]
+	if !ok {
+		return model.Dependency{}, false
+	}
+
+	name := parts[1]
+	if commaIdx := strings.Index(name, ","); commaIdx != -1 {
+		module := strings.TrimSpace(name[commaIdx+1:])
+		if strings.HasPrefix(module, "GeneXus") {
+			return model.Dependency{}, false
+		}
+		name = name[:commaIdx]
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return model.Dependency{}, false
+	}
+	return model.Dependency{Name: name, Type: kind}, true
+}
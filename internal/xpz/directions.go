@@ -0,0 +1,72 @@
+package xpz
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// mutatingMethods are variable methods that fill or change the variable,
+// e.g. &Customers.Add(&Customer) or &Order.FromJson(&Json)
+const mutatingMethods = "load|fromjson|fromxml|add|remove|clear|sort|setempty"
+
+// InferDirections guesses the direction of IsParm parameters, which legacy
+// exports don't record, from how the source uses them. A parameter that is
+// assigned (or filled through Load, Add, FromJson, ...) is OUT, or INOUT when
+// its value is read before the first assignment; other parameters stay IN.
+// The analysis follows statement order, not control flow.
+func InferDirections(params []model.ParameterDoc, source string) []model.ParameterDoc {
+	if len(params) == 0 || source == "" {
+		return params
+	}
+	lines := strings.Split(literalOrCommentRegex.ReplaceAllString(source, " "), "\n")
+
+	result := make([]model.ParameterDoc, len(params))
+	copy(result, params)
+	for i, param := range result {
+		if param.Direction != "IN" {
+			continue
+		}
+		result[i].Direction = inferDirection(param.Name, lines)
+	}
+	return result
+}
+
+// inferDirection classifies the uses of &name in source lines
+func inferDirection(name string, lines []string) string {
+	variable := `&` + regexp.QuoteMeta(name) + `\b`
+	useRegex := regexp.MustCompile(`(?i)` + variable)
+	// &Var = ..., &Var.Member = ..., &Var += ..., For &Var = ...
+	assignRegex := regexp.MustCompile(`(?i)^(?:for\s+)?` + variable + `(?:\.\w+|\([^)]*\))*\s*([-+*/]?)=([^=].*|)$`)
+	// &Var.Load(...), &Var.Add(...), ...
+	mutateRegex := regexp.MustCompile(`(?i)^` + variable + `\.(?:` + mutatingMethods + `)\s*\(`)
+
+	written, readFirst := false, false
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if !useRegex.MatchString(line) {
+			continue
+		}
+		switch m := assignRegex.FindStringSubmatch(line); {
+		case m != nil:
+			// &X = &X + 1 and &X += 1 read the value they replace
+			if !written && (m[1] != "" || useRegex.MatchString(m[2])) {
+				readFirst = true
+			}
+			written = true
+		case mutateRegex.MatchString(line):
+			written = true
+		case !written:
+			readFirst = true
+		}
+	}
+
+	switch {
+	case written && readFirst:
+		return "INOUT"
+	case written:
+		return "OUT"
+	}
+	return "IN"
+}
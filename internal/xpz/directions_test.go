@@ -0,0 +1,41 @@
+package xpz
+
+import (
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestInferDirections(t *testing.T) {
+	source := `// &Name = "ignored in comments"
+&Customer.Load(&CustomerId)
+If &Total > 0
+	&Message = "Total is " + &Total.ToString()
+EndIf
+&Total = &Total + 1
+&Order.Date = Today()
+&Counter += 1
+&Label = 'uses &Items'`
+
+	params := []model.ParameterDoc{
+		{Name: "CustomerId", Direction: "IN"},
+		{Name: "Customer", Direction: "IN"},
+		{Name: "Message", Direction: "IN"},
+		{Name: "Total", Direction: "IN"},
+		{Name: "Order", Direction: "IN"},
+		{Name: "Counter", Direction: "IN"},
+		{Name: "Items", Direction: "IN"},
+		{Name: "Mode", Direction: "OUT"},
+	}
+	expected := []string{"IN", "OUT", "OUT", "INOUT", "OUT", "INOUT", "IN", "OUT"}
+
+	result := InferDirections(params, source)
+	for i, param := range result {
+		if param.Direction != expected[i] {
+			t.Errorf("%s: expected %s, got %s", param.Name, expected[i], param.Direction)
+		}
+	}
+	if params[1].Direction != "IN" {
+		t.Error("Expected the input parameters to be left unchanged")
+	}
+}
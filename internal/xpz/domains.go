@@ -0,0 +1,49 @@
+package xpz
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+var (
+	// Domain.Value not preceded by &, a dot or an identifier character
+	domainValueRegex = regexp.MustCompile(`(?:^|[^&\w.])([A-Za-z_]\w*)\.([A-Za-z_]\w*)\b`)
+	// Comparison or assignment operator, or a Case keyword, ending the text before a value
+	comparisonBeforeRegex = regexp.MustCompile(`(?i)(?:=|<>|<|>|\bcase)\s*$`)
+	// Comparison operator starting the text after a value
+	comparisonAfterRegex = regexp.MustCompile(`^\s*(?:=|<>|<|>)`)
+	// Quoted literals and comments, removed before scanning
+	literalOrCommentRegex = regexp.MustCompile(`(?s)/\*.*?\*/|//[^\n]*|"[^"\n]*"|'[^'\n]*'`)
+)
+
+// ExtractDomainValues detects enumerated domain values used in comparisons
+// or assignments (e.g., "&Status = StatusDomain.Active"), in order of appearance
+func ExtractDomainValues(source string) []model.DomainValue {
+	source = literalOrCommentRegex.ReplaceAllString(source, " ")
+
+	var values []model.DomainValue
+	seen := make(map[string]bool)
+
+	for _, m := range domainValueRegex.FindAllStringSubmatchIndex(source, -1) {
+		before, after := source[:m[2]], source[m[5]:]
+
+		// Method calls and nested members (Proc.Call(), Module.Proc.Udp()) are not values
+		if next := strings.TrimLeft(after, " \t"); strings.HasPrefix(next, "(") || strings.HasPrefix(next, ".") {
+			continue
+		}
+		if !comparisonBeforeRegex.MatchString(before) && !comparisonAfterRegex.MatchString(after) {
+			continue
+		}
+
+		value := model.DomainValue{Domain: source[m[2]:m[3]], Value: source[m[4]:m[5]]}
+		key := strings.ToLower(value.Domain + "." + value.Value)
+		if !seen[key] {
+			seen[key] = true
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,38 @@
+package xpz
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestExtractDomainValues(t *testing.T) {
+	source := `// &Status = CommentDomain.Ignored
+If &Status = StatusDomain.Active
+	&Message = "Status.Literal"
+	Billing.CreateInvoice.Call(&Order)
+	&Total = &Order.Total
+	&Status = StatusDomain.Suspended
+Else
+	If OrderType.Express <> &Type
+		&Result = Calc.Udp(&Type)
+	EndIf
+EndIf
+Do Case
+	Case &Status = statusdomain.active
+	Case PaymentMethod.Card
+EndCase`
+
+	expected := []model.DomainValue{
+		{Domain: "StatusDomain", Value: "Active"},
+		{Domain: "StatusDomain", Value: "Suspended"},
+		{Domain: "OrderType", Value: "Express"},
+		{Domain: "PaymentMethod", Value: "Card"},
+	}
+	result := ExtractDomainValues(source)
+
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("ExtractDomainValues() = %v, expected %v", result, expected)
+	}
+}
@@ -0,0 +1,55 @@
+package xpz
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// declaredEncodingRegex matches the encoding of the XML declaration
+var declaredEncodingRegex = regexp.MustCompile(`^(\s*<\?xml[^>]*?encoding\s*=\s*)["'][^"']*["']`)
+
+// utf8Reader returns a reader of r as UTF-8 without a byte order mark.
+// GeneXus may write exports in UTF-16, with or without a BOM, which the XML
+// parser cannot read; those are converted and their declaration is changed
+// to encoding="utf-8" so the parser does not convert them again.
+func utf8Reader(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(4)
+
+	var endianness unicode.Endianness
+	switch {
+	case bytes.HasPrefix(head, []byte{0xEF, 0xBB, 0xBF}):
+		br.Discard(3)
+		return br, nil
+	case bytes.HasPrefix(head, []byte{0xFF, 0xFE}), bytes.HasPrefix(head, []byte{'<', 0, '?', 0}):
+		endianness = unicode.LittleEndian
+	case bytes.HasPrefix(head, []byte{0xFE, 0xFF}), bytes.HasPrefix(head, []byte{0, '<', 0, '?'}):
+		endianness = unicode.BigEndian
+	default:
+		return br, nil
+	}
+
+	// The BOM, when present, overrides the guessed byte order and is removed
+	decoder := unicode.UTF16(endianness, unicode.UseBOM).NewDecoder()
+	data, err := io.ReadAll(transform.NewReader(br, decoder))
+	if err != nil {
+		return nil, fmt.Errorf("invalid UTF-16 XML: %w", err)
+	}
+	data = declaredEncodingRegex.ReplaceAll(data, []byte(`${1}"utf-8"`))
+	return bytes.NewReader(data), nil
+}
+
+// utf8Bytes returns data as UTF-8 without a byte order mark (see utf8Reader)
+func utf8Bytes(data []byte) ([]byte, error) {
+	r, err := utf8Reader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
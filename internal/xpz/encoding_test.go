@@ -0,0 +1,41 @@
+package xpz
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestParseGXExportEncodings(t *testing.T) {
+	utf16XML := strings.Replace(testExportXML, `encoding="utf-8"`, `encoding="utf-16"`, 1)
+	encode := func(endianness unicode.Endianness, bom unicode.BOMPolicy) []byte {
+		data, err := unicode.UTF16(endianness, bom).NewEncoder().Bytes([]byte(utf16XML))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	tests := map[string][]byte{
+		"UTF-8":                []byte(testExportXML),
+		"UTF-8 with BOM":       append([]byte{0xEF, 0xBB, 0xBF}, testExportXML...),
+		"UTF-16LE with BOM":    encode(unicode.LittleEndian, unicode.UseBOM),
+		"UTF-16BE with BOM":    encode(unicode.BigEndian, unicode.UseBOM),
+		"UTF-16LE without BOM": encode(unicode.LittleEndian, unicode.IgnoreBOM),
+		"UTF-16BE without BOM": encode(unicode.BigEndian, unicode.IgnoreBOM),
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			result, err := parseGXExport(context.Background(), bytes.NewReader(data), Options{})
+			if err != nil {
+				t.Fatalf("parseGXExport failed: %v", err)
+			}
+			if result.KBName != "TestKB" || len(result.Objects) != 1 || result.Objects[0].Path != "GetUser" {
+				t.Errorf("Expected GetUser from TestKB, got %q with %+v", result.KBName, result.Objects)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package xpz
+
+import (
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+)
+
+// ExtractExposure reads whether an object is a main program and how it is
+// exposed to external consumers from its object-level properties: "REST" or
+// "SOAP" for web services, "HTTP" or "Command Line" for main programs with
+// that call protocol, "" for internal objects.
+func ExtractExposure(objNode *xmlquery.Node) (isMain bool, exposure string) {
+	var protocol string
+	var webService, rest bool
+	for _, prop := range xmlquery.Find(objNode, "Properties/Property") {
+		value := GetText(prop, "Value")
+		enabled := strings.EqualFold(value, "true") || strings.EqualFold(value, "yes")
+		switch strings.ToLower(GetText(prop, "Name")) {
+		case "ismain", "main_program":
+			isMain = enabled
+		case "call_protocol", "callprotocol":
+			protocol = value
+		case "exposeaswebservice", "isws", "expose_as_web_service":
+			webService = enabled
+		case "rest_protocol", "restprotocol", "expose_as_rest":
+			rest = enabled
+		}
+	}
+
+	switch {
+	case webService && rest:
+		return isMain, "REST"
+	case webService:
+		return isMain, "SOAP"
+	case strings.EqualFold(protocol, "soap"):
+		return isMain, "SOAP"
+	case strings.EqualFold(protocol, "rest"):
+		return isMain, "REST"
+	case isMain && protocol != "" && !strings.EqualFold(protocol, "internal"):
+		return isMain, protocol
+	}
+	return isMain, ""
+}
@@ -0,0 +1,36 @@
+package xpz
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antchfx/xmlquery"
+)
+
+func TestExtractExposure(t *testing.T) {
+	tests := []struct {
+		properties string
+		isMain     bool
+		exposure   string
+	}{
+		{"", false, ""},
+		{"<Property><Name>IsMain</Name><Value>True</Value></Property>", true, ""},
+		{"<Property><Name>IsMain</Name><Value>True</Value></Property><Property><Name>CALL_PROTOCOL</Name><Value>HTTP</Value></Property>", true, "HTTP"},
+		{"<Property><Name>IsMain</Name><Value>False</Value></Property><Property><Name>CALL_PROTOCOL</Name><Value>HTTP</Value></Property>", false, ""},
+		{"<Property><Name>ExposeAsWebService</Name><Value>True</Value></Property>", false, "SOAP"},
+		{"<Property><Name>ExposeAsWebService</Name><Value>True</Value></Property><Property><Name>REST_PROTOCOL</Name><Value>True</Value></Property>", false, "REST"},
+	}
+
+	for _, tt := range tests {
+		xml := "<Object><Properties>" + tt.properties + "</Properties>" +
+			"<Part><Variable><Properties><Property><Name>IsMain</Name><Value>True</Value></Property></Properties></Variable></Part></Object>"
+		doc, err := xmlquery.Parse(strings.NewReader(xml))
+		if err != nil {
+			t.Fatal(err)
+		}
+		isMain, exposure := ExtractExposure(xmlquery.FindOne(doc, "Object"))
+		if isMain != tt.isMain || exposure != tt.exposure {
+			t.Errorf("ExtractExposure(%s) = %v, %q; expected %v, %q", tt.properties, isMain, exposure, tt.isMain, tt.exposure)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package xpz
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// Options controls which objects are parsed during extraction
+type Options struct {
+	// Types limits parsing to these object type names (e.g. "Procedure").
+	// Empty means all supported types.
+	Types []string
+
+	// Include, when non-empty, keeps only objects whose name or path
+	// matches at least one pattern
+	Include []*regexp.Regexp
+
+	// Exclude skips objects whose name or path matches any pattern
+	Exclude []*regexp.Regexp
+
+	// Packages, when non-empty, keeps only objects whose package or KB
+	// folder matches one of these names (case-insensitive)
+	Packages []string
+
+	// Jobs is the number of objects parsed concurrently; <= 1 parses sequentially
+	Jobs int
+
+	// MaxSourceSize skips objects whose source part is larger, in bytes;
+	// 0 means no limit
+	MaxSourceSize int
+
+	// MaxObjects skips the objects of an export file beyond this count;
+	// 0 means no limit
+	MaxObjects int
+
+	// MaxEntrySize skips archive entries larger than this once
+	// decompressed, in bytes; 0 means no limit
+	MaxEntrySize int64
+
+	// Recover parses export files that are not well-formed object by
+	// object, skipping the broken objects, instead of dropping the file
+	Recover bool
+
+	// Timings, when set, records the "extract" (XML decoding) and "parse"
+	// (object analysis) phases, and the parse time of each object
+	Timings *utils.Timings
+}
+
+// ParseTypes parses a comma-separated list of object type names, matching
+// them case-insensitively against the supported types
+func ParseTypes(list string) ([]string, error) {
+	var types []string
+	for _, item := range ParseList(list) {
+		name := ""
+		for _, supported := range SupportedTypes() {
+			if strings.EqualFold(item, supported) {
+				name = supported
+				break
+			}
+		}
+		if name == "" {
+			return nil, fmt.Errorf("unknown object type '%s' (supported: %s)", item, strings.Join(SupportedTypes(), ", "))
+		}
+		types = append(types, name)
+	}
+	return types, nil
+}
+
+// CompilePatterns compiles a list of regular expressions for Include/Exclude
+func CompilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	var compiled []*regexp.Regexp
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// selectsName reports whether an object passes the include/exclude filters.
+// Patterns are matched against the object name and its parent/name path.
+func (o Options) selectsName(name, parent string) bool {
+	candidates := []string{name}
+	if parent != "" {
+		candidates = append(candidates, parent+"/"+name)
+	}
+	matchesAny := func(patterns []*regexp.Regexp) bool {
+		for _, re := range patterns {
+			for _, candidate := range candidates {
+				if re.MatchString(candidate) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if len(o.Include) > 0 && !matchesAny(o.Include) {
+		return false
+	}
+	return !matchesAny(o.Exclude)
+}
+
+// ParseList splits a comma-separated flag value, dropping empty items
+func ParseList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// selectsPackage reports whether an object filed under pkg, or stored in
+// the KB folder parent, passes the package filter
+func (o Options) selectsPackage(pkg, parent string) bool {
+	if len(o.Packages) == 0 {
+		return true
+	}
+	for _, want := range o.Packages {
+		if strings.EqualFold(want, pkg) || strings.EqualFold(want, parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeFilter returns the set of type names to parse, or nil for all types
+func (o Options) typeFilter() map[string]bool {
+	if len(o.Types) == 0 {
+		return nil
+	}
+	filter := make(map[string]bool)
+	for _, t := range o.Types {
+		filter[t] = true
+	}
+	return filter
+}
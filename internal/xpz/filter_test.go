@@ -0,0 +1,76 @@
+package xpz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTypes(t *testing.T) {
+	types, err := ParseTypes(" procedure , Procedure,")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"Procedure", "Procedure"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("expected %v, got %v", want, types)
+	}
+
+	if types, err := ParseTypes(""); err != nil || types != nil {
+		t.Errorf("expected no types for empty list, got %v (%v)", types, err)
+	}
+
+	if _, err := ParseTypes("Procedure,Widget"); err == nil {
+		t.Error("expected error for unknown type")
+	}
+}
+
+func TestOptionsSelectsName(t *testing.T) {
+	include, _ := CompilePatterns([]string{"^Billing/", "^GetUser$"})
+	exclude, _ := CompilePatterns([]string{"^Test"})
+	opts := Options{Include: include, Exclude: exclude}
+
+	tests := []struct {
+		name, parent string
+		expected     bool
+	}{
+		{"CalcTotal", "Billing", true},
+		{"GetUser", "", true},
+		{"DeleteUser", "Users", false},
+		{"TestCalcTotal", "Billing", false},
+	}
+
+	for _, tt := range tests {
+		if got := opts.selectsName(tt.name, tt.parent); got != tt.expected {
+			t.Errorf("selectsName(%q, %q) = %v, expected %v", tt.name, tt.parent, got, tt.expected)
+		}
+	}
+
+	if !(Options{}).selectsName("Anything", "") {
+		t.Error("expected empty options to select every object")
+	}
+	if _, err := CompilePatterns([]string{"("}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestOptionsSelectsPackage(t *testing.T) {
+	opts := Options{Packages: ParseList("billing, Users,")}
+
+	tests := []struct {
+		pkg, parent string
+		expected    bool
+	}{
+		{"Billing", "", true},
+		{"Reports", "users", true},
+		{"Reports", "Admin", false},
+	}
+
+	for _, tt := range tests {
+		if got := opts.selectsPackage(tt.pkg, tt.parent); got != tt.expected {
+			t.Errorf("selectsPackage(%q, %q) = %v, expected %v", tt.pkg, tt.parent, got, tt.expected)
+		}
+	}
+
+	if !(Options{}).selectsPackage("Anything", "") {
+		t.Error("expected empty options to select every package")
+	}
+}
@@ -0,0 +1,84 @@
+package xpz
+
+import (
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// exportMerger combines the export files of a split XPZ into one result.
+// Objects found in several files are kept once, and the KB name and
+// metadata are taken from the first file recording them.
+type exportMerger struct {
+	result *ExtractResult
+
+	// seen maps object keys to the file the object was first found in
+	seen map[string]string
+
+	// unknown counts the unknown object types of all files
+	unknown *unknownTypes
+}
+
+// newExportMerger returns a merger adding to result
+func newExportMerger(result *ExtractResult) *exportMerger {
+	return &exportMerger{result: result, seen: make(map[string]string), unknown: newUnknownTypes()}
+}
+
+// merge adds the objects and metadata of the export file name
+func (m *exportMerger) merge(name string, entry *ExtractResult) {
+	result := m.result
+	switch {
+	case result.KBName == "":
+		result.KBName = entry.KBName
+	case entry.KBName != "" && !strings.EqualFold(entry.KBName, result.KBName):
+		utils.Warning("%s was exported from KB %s, not %s; merging it anyway", name, entry.KBName, result.KBName)
+	}
+	mergeInfo(&result.Info, entry.Info)
+
+	added := 0
+	for _, obj := range entry.Objects {
+		key := objectKey(obj)
+		if first, ok := m.seen[key]; ok {
+			utils.Verbose("Skipping %s from %s, already extracted from %s", obj.Path, name, first)
+			continue
+		}
+		m.seen[key] = name
+		result.Objects = append(result.Objects, obj)
+		added++
+	}
+	if len(entry.Objects) > 0 {
+		utils.Info("Found %d objects in %s", added, name)
+	}
+	result.Skipped = append(result.Skipped, entry.Skipped...)
+
+	for _, t := range entry.UnknownTypes {
+		m.unknown.add(t.GUID, t.Count, t.Samples...)
+	}
+	if len(entry.UnknownTypes) > 0 {
+		result.UnknownTypes = m.unknown.list()
+	}
+}
+
+// objectKey identifies an object across export files: by GUID when the
+// export records it, otherwise by name, type and parent
+func objectKey(obj model.GXObject) string {
+	if obj.GUID != "" {
+		return strings.ToLower(obj.GUID)
+	}
+	return obj.Path + "|" + obj.Type + "|" + obj.Parent
+}
+
+// mergeInfo fills the metadata missing from info with the metadata of
+// another export file. The earliest export date is kept.
+func mergeInfo(info *model.KBInfo, entry model.KBInfo) {
+	if info.GeneXusVersion == "" {
+		info.GeneXusVersion = entry.GeneXusVersion
+	}
+	if info.Environment == "" {
+		info.Environment = entry.Environment
+	}
+	if !entry.ExportDate.IsZero() && (info.ExportDate.IsZero() || entry.ExportDate.Before(info.ExportDate)) {
+		info.ExportDate = entry.ExportDate
+	}
+}
@@ -1,9 +1,13 @@
 package xpz
 
 import (
-	"os"
+	"context"
+	"fmt"
+	"io"
+	"path"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/antchfx/xmlquery"
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
@@ -11,30 +15,44 @@ import (
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
 )
 
-// parseGXExportFileXMLQuery parses GX export using xmlquery (refactored version)
-func parseGXExportFileXMLQuery(filePath string) ([]model.GXObject, string, error) {
-	xmlFile, err := os.Open(filePath)
+// parseGXExport parses GX export XML using xmlquery.
+// Only objects selected by opts are parsed.
+func parseGXExport(ctx context.Context, r io.Reader, opts Options) (*ExtractResult, error) {
+	stopExtract := opts.Timings.Start("extract")
+	r, err := utf8Reader(r)
 	if err != nil {
-		return nil, "", err
+		stopExtract()
+		return nil, err
 	}
-	defer xmlFile.Close()
-
-	doc, err := xmlquery.Parse(xmlFile)
+	doc, err := xmlquery.Parse(r)
+	stopExtract()
 	if err != nil {
-		return nil, "", err
+		return nil, err
 	}
 
 	// Extract KB Name from Source/Version/@name
-	kbName := GetAttr(doc, "//Source/Version", "name")
+	result := &ExtractResult{KBName: GetAttr(doc, "//Source/Version", "name")}
+	result.Info = model.KBInfo{
+		GeneXusVersion: exportVersion(doc),
+		Environment:    GetAttr(doc, "/ExportFile/Source/Environment", "name"),
+	}
 
 	// Find all Object nodes
 	objectNodes := FindAll(doc, "//Objects/Object")
 	if len(objectNodes) == 0 {
-		return nil, kbName, nil
+		return result, nil
+	}
+
+	// objectEntry is an object selected for parsing
+	type objectEntry struct {
+		node                                                   *xmlquery.Node
+		typeName, name, displayName, description, parent, user string
 	}
 
-	var objects []model.GXObject
+	var entries []objectEntry
 	seenObjects := make(map[string]bool)
+	types := opts.typeFilter()
+	unknown := newUnknownTypes()
 
 	for _, objNode := range objectNodes {
 		// Extract object attributes
@@ -44,14 +62,25 @@ func parseGXExportFileXMLQuery(filePath string) ([]model.GXObject, string, error
 		objParent := GetAttrDirect(objNode, "parent")
 		objUser := GetAttrDirect(objNode, "user")
 
-		// Map type GUID to name
-		typeName := gxTypeMap[objType]
+		// Map type GUID to name, counting GUIDs no mapping knows; attributes
+		// are read with their Transactions
+		typeName, known := lookupType(objType)
+		if !known && objType != "" && normalizeGUID(objType) != GXTypeAttribute {
+			unknown.add(objType, 1, objName)
+		}
 		if typeName == "" || typeName == "Unknown" {
 			continue
 		}
+		if types != nil && !types[typeName] {
+			continue
+		}
+		if !opts.selectsName(objName, objParent) {
+			continue
+		}
 
-		// Skip duplicates
-		objKey := objName + "|" + objType
+		// Skip duplicates; objects sharing a name in different modules or
+		// folders are kept
+		objKey := objName + "|" + objType + "|" + objParent
 		if seenObjects[objKey] {
 			continue
 		}
@@ -63,35 +92,117 @@ func parseGXExportFileXMLQuery(filePath string) ([]model.GXObject, string, error
 			displayName = objDescription
 		}
 
-		// Process based on type
-		if typeName == "Procedure" {
-			gxObj, shouldInclude := parseProcedure(objNode, objName, displayName, objDescription, objParent, objUser)
-			if shouldInclude {
-				objects = append(objects, gxObj)
+		if opts.MaxObjects > 0 && len(entries) >= opts.MaxObjects {
+			result.Skipped = append(result.Skipped, model.SkippedObject{
+				Name:   objName,
+				Reason: fmt.Sprintf("object limit of %d reached", opts.MaxObjects),
+			})
+			continue
+		}
+		entries = append(entries, objectEntry{objNode, typeName, objName, displayName, objDescription, objParent, objUser})
+	}
+	result.UnknownTypes = unknown.list()
+
+	// Parse selected objects concurrently, keeping export order
+	defer opts.Timings.Start("parse")()
+	var attributes map[string]model.Attribute
+	if len(entries) > 0 {
+		attributes = attributeDefinitions(doc)
+	}
+	progress := utils.NewProgress("Parsing objects", len(entries))
+	defer progress.Finish()
+
+	parsed := make([]*model.GXObject, len(entries))
+	oversized := make([]*model.SkippedObject, len(entries))
+	utils.Parallel(len(entries), opts.Jobs, func(i int) {
+		defer progress.Increment()
+		if ctx.Err() != nil {
+			return
+		}
+		e := entries[i]
+		if opts.MaxSourceSize > 0 {
+			if size := sourceSize(e.node); size > opts.MaxSourceSize {
+				oversized[i] = &model.SkippedObject{
+					Name:   e.name,
+					Reason: fmt.Sprintf("source is %d bytes, limit is %d", size, opts.MaxSourceSize),
+				}
+				return
 			}
 		}
-		// Future: Add Data Provider, WebPanel, etc.
+		started := time.Now()
+		var gxObj model.GXObject
+		shouldInclude := true
+		if e.typeName == "Transaction" {
+			gxObj = parseTransaction(e.node, e.name, e.displayName, e.description, e.parent, e.user, attributes)
+		} else {
+			// Types registered beyond the built-in ones are read like procedures
+			gxObj, shouldInclude = parseProcedure(e.node, e.typeName, e.name, e.displayName, e.description, e.parent, e.user)
+		}
+		opts.Timings.AddObject(path.Join(e.parent, e.name), "parse", time.Since(started))
+		if shouldInclude && opts.selectsPackage(gxObj.Documentation.Package, e.parent) {
+			gxObj.Parent = e.parent
+			gxObj.GUID = GetAttrDirect(e.node, "guid")
+			gxObj.Hash = hashNode(e.node)
+			parsed[i] = &gxObj
+		}
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, obj := range parsed {
+		if obj != nil {
+			result.Objects = append(result.Objects, *obj)
+		}
+		if oversized[i] != nil {
+			result.Skipped = append(result.Skipped, *oversized[i])
+		}
+	}
+
+	return result, nil
+}
+
+// sourceSize returns the length in bytes of an object's source part
+func sourceSize(objNode *xmlquery.Node) int {
+	source := xmlquery.FindOne(objNode, "Part[@type='"+GXPartSourceCode+"']/Source")
+	if source == nil {
+		return 0
 	}
+	return len(source.InnerText())
+}
 
-	return objects, kbName, nil
+// exportVersion returns the Knowledge Manager version of the KMW element,
+// e.g. "4.0.169212"
+func exportVersion(doc *xmlquery.Node) string {
+	var parts []string
+	for _, field := range []string{"MajorVersion", "MinorVersion", "Build"} {
+		if value := GetText(doc, "/ExportFile/KMW/"+field); value != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, ".")
 }
 
-// parseProcedure extracts all procedure information.
+// parseProcedure extracts all procedure information, also used for the
+// types registered through objectTypes, which are returned as typeName.
 // Returns the GXObject and a boolean indicating whether it should be included in documentation.
-func parseProcedure(objNode *xmlquery.Node, name, displayName, xmlDescription, parent, xmlUser string) (model.GXObject, bool) {
+func parseProcedure(objNode *xmlquery.Node, typeName, name, displayName, xmlDescription, parent, xmlUser string) (model.GXObject, bool) {
 	// Extract source code
 	sourceCode := GetText(objNode, "//Part[@type='"+GXPartSourceCode+"']/Source")
 	sourceCode = strings.TrimSpace(sourceCode)
 
 	// Extract signature with multi-layer fallback
 	sig := ExtractProcedureSignature(objNode, name)
+	utils.Debug("%s: %d parameter(s) extracted via %s", name, len(sig.Parameters), sig.ExtractionMode)
 	
 	// Check if procedure is empty or only contains comments
 	hasRealCode := sourceCode != "" && !isOnlyComments(sourceCode)
 	hasParameters := len(sig.Parameters) > 0
 	
-	// Skip empty procedures with no parameters
-	if !hasRealCode && !hasParameters {
+	// Skip empty procedures with no parameters. Other types keep their
+	// content outside the source part, so they are never empty here.
+	if typeName == "Procedure" && !hasRealCode && !hasParameters {
 		utils.Warning("Skipping empty procedure '%s' (no code or parameters)", name)
 		return model.GXObject{}, false
 	}
@@ -110,9 +221,18 @@ func parseProcedure(objNode *xmlquery.Node, name, displayName, xmlDescription, p
 		}
 	}
 
+	// Stale @param tags are a frequent source of wrong docs
+	if documentation != nil {
+		for _, mismatch := range parser.CompareParams(documentation.Parameters, sig.Parameters) {
+			utils.Warning("%s: %s", name, mismatch)
+		}
+		parser.ApplyDirections(documentation.Parameters, sig.Parameters)
+	}
+
 	// Determine if auto-generated and handle parameter merging
 	if documentation == nil {
 		// No annotations found - create auto-generated doc
+		utils.Debug("%s: no /** */ block, using auto-generated documentation", name)
 		documentation = &model.DocComment{
 			IsAutoGenerated: true,
 			Parameters:      sig.Parameters,
@@ -136,6 +256,7 @@ func parseProcedure(objNode *xmlquery.Node, name, displayName, xmlDescription, p
 
 	// Determine package with fallback logic
 	packageName := determinePackage(documentation, parent, name)
+	utils.Debug("%s: filed under package '%s'", name, packageName)
 	if documentation != nil {
 		documentation.Package = packageName
 	}
@@ -152,17 +273,56 @@ func parseProcedure(objNode *xmlquery.Node, name, displayName, xmlDescription, p
 		documentation.Description = description
 	}
 
+	isMain, exposure := ExtractExposure(objNode)
+
 	return model.GXObject{
 		Name:           displayName,
-		Type:           "Procedure",
+		Type:           typeName,
 		Path:           name,
 		SourceCode:     sourceCode,
 		ParmSignature:  sig.RawSignature,
+		Parameters:     sig.Parameters,
+		Rules:          strings.TrimSpace(GetText(objNode, "//Part[@type='"+GXPartRules+"']/Source")),
+		Variables:      ExtractVariables(objNode),
 		XMLDescription: xmlDescription,
 		Documentation:  documentation,
+		Dependencies:   ExtractDependencies(objNode),
+		DomainValues:   ExtractDomainValues(sourceCode),
+		IsMain:         isMain,
+		Exposure:       exposure,
 	}, true
 }
 
+// parseTransaction extracts a Transaction with its structure. Transactions
+// have no doc comments, so their documentation comes from XML metadata.
+func parseTransaction(objNode *xmlquery.Node, name, displayName, xmlDescription, parent, xmlUser string, attributes map[string]model.Attribute) model.GXObject {
+	documentation := &model.DocComment{
+		IsAutoGenerated: true,
+		Author:          xmlUser,
+		Tags:            make([]string, 0),
+	}
+	if documentation.Author == "" {
+		documentation.Author = "Unknown"
+	}
+	documentation.Package = determinePackage(documentation, parent, name)
+	documentation.Summary = determineSummary(documentation, name)
+	documentation.Description = determineDescription(documentation, name, xmlDescription)
+
+	levels := ExtractStructure(objNode, attributes)
+	utils.Debug("%s: %d structure level(s) extracted", name, len(levels))
+
+	return model.GXObject{
+		Name:           displayName,
+		Type:           "Transaction",
+		Path:           name,
+		XMLDescription: xmlDescription,
+		Variables:      ExtractVariables(objNode),
+		Documentation:  documentation,
+		Dependencies:   ExtractDependencies(objNode),
+		Levels:         levels,
+	}
+}
+
 // isOnlyComments checks if source code contains only comments and whitespace.
 func isOnlyComments(source string) bool {
 	lines := strings.Split(source, "\n")
@@ -181,9 +341,12 @@ func isOnlyComments(source string) bool {
 }
 
 // determinePackage implements package fallback logic
-// Priority: @package/@tag → Parent/module → name prefix → "Root"
+// Priority: @docgroup → @package/@tag → Parent/module → name prefix → "Root"
 func determinePackage(doc *model.DocComment, parent, name string) string {
-	// 1. Check @package or @tag from annotations
+	// 1. Check @docgroup, @package or @tag from annotations
+	if doc != nil && doc.DocGroup != "" {
+		return doc.DocGroup
+	}
 	if doc != nil && doc.Package != "" {
 		return doc.Package
 	}
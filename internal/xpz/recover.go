@@ -0,0 +1,101 @@
+package xpz
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+var (
+	// objectStartRegex matches the start tag of an Object element
+	objectStartRegex = regexp.MustCompile(`<Object[\s>]`)
+
+	// objectNameRegex captures the name attribute of an Object start tag
+	objectNameRegex = regexp.MustCompile(`^<Object[^>]*?\sname\s*=\s*["']([^"']*)["']`)
+
+	// exportHeaderRegex matches the KB metadata elements preceding the objects
+	exportHeaderRegex = regexp.MustCompile(`(?s)<KMW>.*?</KMW>|<Source[\s>].*?</Source>`)
+)
+
+// objectEnd is the end tag of an Object element
+var objectEnd = []byte("</Object>")
+
+// recoverExport parses the well-formed objects of an export XML that failed
+// to parse as a whole. Every Object element is checked on its own; broken
+// ones are reported in Skipped with the parse error and the others are
+// parsed together, so references between them still resolve.
+func recoverExport(ctx context.Context, data []byte, opts Options) (*ExtractResult, error) {
+	data, err := utf8Bytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var header bytes.Buffer
+	for _, element := range exportHeaderRegex.FindAll(data, 2) {
+		if wellFormed(element) == nil {
+			header.Write(element)
+		}
+	}
+
+	var objects bytes.Buffer
+	var skipped []model.SkippedObject
+	starts := objectStartRegex.FindAllIndex(data, -1)
+	for i, start := range starts {
+		end := len(data)
+		if i+1 < len(starts) {
+			end = starts[i+1][0]
+		}
+		chunk := data[start[0]:end]
+		if last := bytes.LastIndex(chunk, objectEnd); last >= 0 {
+			chunk = chunk[:last+len(objectEnd)]
+		}
+
+		if err := wellFormed(chunk); err != nil {
+			name := fmt.Sprintf("object at byte %d", start[0])
+			if m := objectNameRegex.FindSubmatch(chunk); m != nil {
+				name = string(m[1])
+			}
+			skipped = append(skipped, model.SkippedObject{Name: name, Reason: malformedReason(err, data[:start[0]])})
+			continue
+		}
+		objects.Write(chunk)
+		objects.WriteByte('\n')
+	}
+
+	var export bytes.Buffer
+	export.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n<ExportFile>")
+	export.Write(header.Bytes())
+	export.WriteString("<Objects>\n")
+	export.Write(objects.Bytes())
+	export.WriteString("</Objects></ExportFile>\n")
+
+	result, err := parseGXExport(ctx, &export, opts)
+	if err != nil {
+		return nil, err
+	}
+	result.Skipped = append(skipped, result.Skipped...)
+	return result, nil
+}
+
+// wellFormed returns the error parsing an XML fragment, or nil
+func wellFormed(fragment []byte) error {
+	_, err := xmlquery.Parse(bytes.NewReader(fragment))
+	return err
+}
+
+// malformedReason describes a parse error of an object, with the line
+// number counted from the start of the export file before the object
+func malformedReason(err error, before []byte) string {
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		line := bytes.Count(before, []byte("\n")) + syntaxErr.Line
+		return fmt.Sprintf("malformed XML on line %d: %s", line, syntaxErr.Msg)
+	}
+	return fmt.Sprintf("malformed XML: %v", err)
+}
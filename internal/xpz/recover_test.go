@@ -0,0 +1,37 @@
+package xpz
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestExtractArchiveRecover(t *testing.T) {
+	object := testExportXML[strings.Index(testExportXML, "<Object ") : strings.Index(testExportXML, "</Object>")+len("</Object>")]
+	broken := strings.NewReplacer(`name="GetUser"`, `name="BrokenUser"`, "</Source></Part>", "</Part>").Replace(object)
+	saveUser := strings.Replace(object, `name="GetUser"`, `name="SaveUser"`, 1)
+	xml := strings.Replace(testExportXML, object, object+"\n"+broken+"\n"+saveUser, 1)
+	archive := buildArchive(t, map[string]string{"export.xml": xml})
+
+	result, err := extractArchive(context.Background(), archive, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 0 {
+		t.Fatalf("Expected the malformed file to be dropped without recovery, got %d object(s)", len(result.Objects))
+	}
+
+	result, err = extractArchive(context.Background(), archive, Options{Recover: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.KBName != "TestKB" {
+		t.Errorf("Expected the KB name to be recovered, got %q", result.KBName)
+	}
+	if len(result.Objects) != 2 || result.Objects[0].Path != "GetUser" || result.Objects[1].Path != "SaveUser" {
+		t.Errorf("Expected GetUser and SaveUser, got %+v", result.Objects)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Name != "BrokenUser" || result.Skipped[0].Reason != "malformed XML on line 16: element <Source> closed by </Part>" {
+		t.Errorf("Expected BrokenUser to be reported as malformed, got %+v", result.Skipped)
+	}
+}
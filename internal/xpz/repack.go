@@ -0,0 +1,275 @@
+package xpz
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SourceEdit returns the new Source part of the named procedure in module
+// (empty for the KB root), or false to keep the original
+type SourceEdit func(module, name, source string) (string, bool)
+
+// sourceEscaper escapes text for use as element content
+var sourceEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// Repack copies the XPZ archive r to w, replacing the Source parts that edit
+// changes. Every other entry, and every other byte of the export XML, is
+// copied unchanged so the result can be imported back into GeneXus; edited
+// UTF-16 export files are written as UTF-8. Sources are passed to edit with
+// their original line endings. It returns the number of procedures edited.
+func Repack(r *zip.Reader, w io.Writer, edit SourceEdit) (int, error) {
+	archive := NewWriter(w)
+	edited := 0
+
+	for _, file := range r.File {
+		if file.FileInfo().IsDir() || !strings.HasSuffix(strings.ToLower(file.Name), ".xml") {
+			if err := archive.Copy(file); err != nil {
+				return edited, err
+			}
+			continue
+		}
+
+		data, err := readEntry(file)
+		if err != nil {
+			return edited, fmt.Errorf("failed to read %s: %w", file.Name, err)
+		}
+		// UTF-16 exports are written back as UTF-8 when they are edited
+		text, err := utf8Bytes(data)
+		if err != nil {
+			return edited, fmt.Errorf("failed to read %s: %w", file.Name, err)
+		}
+		text, count, err := repackXML(text, edit)
+		if err != nil {
+			return edited, fmt.Errorf("failed to repack %s: %w", file.Name, err)
+		}
+		if count > 0 {
+			data = text
+		}
+		edited += count
+
+		if err := archive.writeEntry(file.FileHeader, data); err != nil {
+			return edited, err
+		}
+	}
+
+	return edited, archive.Close()
+}
+
+// RepackFile repacks the XPZ file at inputPath into outputPath with Repack.
+// The output is written to a temporary file next to it and renamed over
+// outputPath only once complete, so a failure never leaves a truncated
+// archive. Writing over the input itself is refused, since the input is
+// still being read.
+func RepackFile(inputPath, outputPath string, edit SourceEdit) (int, error) {
+	in, err := os.Stat(inputPath)
+	if err != nil {
+		return 0, err
+	}
+	if out, err := os.Stat(outputPath); err == nil && os.SameFile(in, out) {
+		return 0, fmt.Errorf("output %s is the input file; write the repacked XPZ elsewhere", outputPath)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return 0, err
+	}
+
+	reader, err := zip.OpenReader(inputPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open XPZ archive: %w", err)
+	}
+	defer reader.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(outputPath), "."+filepath.Base(outputPath)+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	edited, err := Repack(&reader.Reader, tmp, edit)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return edited, err
+	}
+	return edited, os.Rename(tmp.Name(), outputPath)
+}
+
+// readEntry returns the uncompressed content of an archive entry
+func readEntry(file *zip.File) ([]byte, error) {
+	entry, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer entry.Close()
+	return io.ReadAll(entry)
+}
+
+// sourceSpan is the byte range of a procedure's Source part content
+type sourceSpan struct {
+	module     string
+	name       string
+	start, end int64
+	text       string
+}
+
+// repackXML applies edit to the procedure Source parts of an export XML,
+// splicing the new text into the original bytes
+func repackXML(data []byte, edit SourceEdit) ([]byte, int, error) {
+	spans, err := procedureSources(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var out bytes.Buffer
+	var last int64
+	edited := 0
+	for _, span := range spans {
+		source, ok := edit(span.module, span.name, span.text)
+		if !ok || source == span.text {
+			continue
+		}
+		out.Write(data[last:span.start])
+		out.WriteString(sourceEscaper.Replace(source))
+		last = span.end
+		edited++
+	}
+	if edited == 0 {
+		return data, 0, nil
+	}
+	out.Write(data[last:])
+	return out.Bytes(), edited, nil
+}
+
+// procedureSources locates the source code part of every procedure in an
+// export XML, in document order. Empty self-closing Source elements are
+// skipped since there is no content to splice into. The text is read from
+// the raw bytes because encoding/xml turns CRLF line endings into LF.
+func procedureSources(data []byte) ([]sourceSpan, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var (
+		spans    []sourceSpan
+		object   string
+		module   string
+		inSource bool
+		inPart   bool
+		current  *sourceSpan
+	)
+	for {
+		offset := decoder.InputOffset()
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return spans, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "Object":
+				object, module = "", ""
+				if normalizeGUID(attr(t, "type")) == GXTypeProcedure {
+					object, module = attr(t, "name"), attr(t, "parent")
+				}
+			case "Part":
+				inPart = object != "" && attr(t, "type") == GXPartSourceCode
+			case "Source":
+				if inPart {
+					inSource = true
+					current = &sourceSpan{module: module, name: object, start: decoder.InputOffset()}
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "Source":
+				if inSource {
+					inSource = false
+					current.end = offset
+					current.text = rawText(data[current.start:current.end])
+					if current.end > current.start || !bytes.HasSuffix(data[:current.start], []byte("/>")) {
+						spans = append(spans, *current)
+					}
+				}
+			case "Part":
+				inPart = false
+			case "Object":
+				object = ""
+			}
+		}
+	}
+}
+
+// rawText returns the text of element content without normalizing line
+// endings: CDATA sections are kept verbatim, entity and character references
+// are resolved and comments are dropped. The content has already been
+// checked by the decoder.
+func rawText(content []byte) string {
+	var sb strings.Builder
+	for len(content) > 0 {
+		switch {
+		case bytes.HasPrefix(content, []byte("<![CDATA[")):
+			content = content[len("<![CDATA["):]
+			end := bytes.Index(content, []byte("]]>"))
+			sb.Write(content[:end])
+			content = content[end+len("]]>"):]
+		case bytes.HasPrefix(content, []byte("<!--")):
+			end := bytes.Index(content, []byte("-->"))
+			content = content[end+len("-->"):]
+		case content[0] == '&':
+			end := bytes.IndexByte(content, ';')
+			sb.WriteString(entityText(string(content[1:end])))
+			content = content[end+1:]
+		default:
+			sb.WriteByte(content[0])
+			content = content[1:]
+		}
+	}
+	return sb.String()
+}
+
+// entityText resolves a predefined entity or character reference, given
+// without its "&" and ";"
+func entityText(entity string) string {
+	switch entity {
+	case "lt":
+		return "<"
+	case "gt":
+		return ">"
+	case "amp":
+		return "&"
+	case "quot":
+		return `"`
+	case "apos":
+		return "'"
+	}
+	var code uint64
+	var err error
+	if hex, ok := strings.CutPrefix(entity, "#x"); ok {
+		code, err = strconv.ParseUint(hex, 16, 32)
+	} else {
+		code, err = strconv.ParseUint(strings.TrimPrefix(entity, "#"), 10, 32)
+	}
+	if err != nil {
+		return "&" + entity + ";"
+	}
+	return string(rune(code))
+}
+
+// attr returns the value of an attribute of a start element
+func attr(element xml.StartElement, name string) string {
+	for _, a := range element.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
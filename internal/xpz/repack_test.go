@@ -0,0 +1,193 @@
+package xpz
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/encoding/unicode"
+)
+
+func TestRepack(t *testing.T) {
+	archive := buildArchive(t, map[string]string{
+		"export.xml": testExportXML,
+		"readme.txt": "not xml",
+	})
+
+	var buf bytes.Buffer
+	edited, err := Repack(archive, &buf, func(module, name, source string) (string, bool) {
+		if module != "Users" || name != "GetUser" {
+			t.Errorf("Unexpected object %q in %q", name, module)
+		}
+		return strings.Replace(source, "Gets a user", "Loads a <user>", 1), true
+	})
+	if err != nil {
+		t.Fatalf("Repack failed: %v", err)
+	}
+	if edited != 1 {
+		t.Errorf("Expected 1 edited object, got %d", edited)
+	}
+
+	repacked, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := extractArchive(context.Background(), repacked, Options{})
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Documentation.Summary != "Loads a <user>" {
+		t.Errorf("Unexpected repacked objects: %+v", result.Objects)
+	}
+	if !strings.Contains(result.Objects[0].SourceCode, "&User.Load(&UserID)") {
+		t.Errorf("Expected the code to be kept, got %q", result.Objects[0].SourceCode)
+	}
+}
+
+func TestRepackUnchanged(t *testing.T) {
+	data := []byte(testExportXML)
+	out, edited, err := repackXML(data, func(module, name, source string) (string, bool) {
+		return source, false
+	})
+	if err != nil || edited != 0 || !bytes.Equal(out, data) {
+		t.Errorf("Expected the XML to be kept byte for byte, got %d edit(s), %v", edited, err)
+	}
+}
+
+func TestRepackLineEndings(t *testing.T) {
+	xml := strings.ReplaceAll(testExportXML, "\n", "\r\n")
+	xml = strings.Replace(xml, "<Source>parm(in:&amp;UserID);</Source>", "<Source><![CDATA[parm(in:&UserID);]]></Source>", 1)
+	data := []byte(xml)
+
+	var sources []string
+	out, edited, err := repackXML(data, func(module, name, source string) (string, bool) {
+		sources = append(sources, source)
+		return source, true
+	})
+	if err != nil || edited != 0 || !bytes.Equal(out, data) {
+		t.Errorf("Expected the XML to be kept byte for byte, got %d edit(s), %v", edited, err)
+	}
+	if len(sources) != 1 || sources[0] != "/**\r\n * @summary Gets a user\r\n */\r\n&User.Load(&UserID)" {
+		t.Errorf("Expected the source with its CRLF line endings, got %q", sources)
+	}
+
+	out, edited, err = repackXML(data, func(module, name, source string) (string, bool) {
+		return "/** @summary Stub */\r\n" + source, true
+	})
+	if err != nil || edited != 1 {
+		t.Fatalf("Expected 1 edited object, got %d (%v)", edited, err)
+	}
+	if !strings.Contains(string(out), "<Source>/** @summary Stub */\r\n/**\r\n * @summary Gets a user\r\n */\r\n&amp;User.Load") {
+		t.Errorf("Expected CRLF line endings to be kept:\n%q", out)
+	}
+}
+
+func TestRawText(t *testing.T) {
+	tests := map[string]string{
+		"&amp;x = 1\r\n&lt;&#65;&#x42;&gt;":          "&x = 1\r\n<AB>",
+		"a<![CDATA[ &amp; <b>\r\n]]>c<!-- note -->d": "a &amp; <b>\r\ncd",
+		"": "",
+	}
+	for content, want := range tests {
+		if got := rawText([]byte(content)); got != want {
+			t.Errorf("%q: expected %q, got %q", content, want, got)
+		}
+	}
+}
+
+func TestRepackSameNameInModules(t *testing.T) {
+	users := `<Object name="Load" type="{84A12160-F59B-4AD7-A683-EA4481AC23E9}" parent="Users">
+      <Part type="528d1c06-a9c2-420d-bd35-21dca83f12ff"><Source>&amp;User.Load()</Source></Part>
+    </Object>`
+	orders := strings.Replace(strings.Replace(users, "Users", "Orders", 1), "&amp;User", "&amp;Order", 1)
+	data := []byte("<ExportFile><Objects>" + users + orders + "</Objects></ExportFile>")
+
+	out, edited, err := repackXML(data, func(module, name, source string) (string, bool) {
+		if module != "Orders" {
+			return source, false
+		}
+		return "/** @summary Loads an order */\n" + source, true
+	})
+	if err != nil || edited != 1 {
+		t.Fatalf("Expected 1 edited object, got %d (%v)", edited, err)
+	}
+	if !strings.Contains(string(out), "<Source>/** @summary Loads an order */\n&amp;Order.Load()</Source>") ||
+		!strings.Contains(string(out), "<Source>&amp;User.Load()</Source>") {
+		t.Errorf("Expected only the Orders procedure to be edited:\n%s", out)
+	}
+}
+
+func TestRepackUTF16(t *testing.T) {
+	xml := strings.Replace(testExportXML, `encoding="utf-8"`, `encoding="utf-16"`, 1)
+	data, err := unicode.UTF16(unicode.LittleEndian, unicode.UseBOM).NewEncoder().Bytes([]byte(xml))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	edited, err := Repack(buildArchive(t, map[string]string{"export.xml": string(data)}), &buf, func(module, name, source string) (string, bool) {
+		return strings.Replace(source, "Gets a user", "Loads a user", 1), true
+	})
+	if err != nil || edited != 1 {
+		t.Fatalf("Expected 1 edited object, got %d (%v)", edited, err)
+	}
+
+	repacked, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := extractArchive(context.Background(), repacked, Options{})
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Documentation.Summary != "Loads a user" {
+		t.Errorf("Unexpected repacked objects: %+v", result.Objects)
+	}
+}
+
+func TestRepackFile(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "export.xpz")
+	data := archiveBytes(t, map[string]string{"export.xml": testExportXML})
+	if err := os.WriteFile(input, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	stub := func(module, name, source string) (string, bool) {
+		return "/** @summary Stub */\n" + source, true
+	}
+
+	if _, err := RepackFile(input, filepath.Join(dir, ".", "export.xpz"), stub); err == nil {
+		t.Error("Expected writing over the input to be refused")
+	}
+	if kept, _ := os.ReadFile(input); !bytes.Equal(kept, data) {
+		t.Fatal("Expected the input to be left untouched")
+	}
+
+	output := filepath.Join(dir, "repacked.xpz")
+	if edited, err := RepackFile(input, output, stub); err != nil || edited != 1 {
+		t.Fatalf("Expected 1 edited object, got %d, %v", edited, err)
+	}
+	if _, err := zip.OpenReader(output); err != nil {
+		t.Errorf("Expected a valid repacked archive: %v", err)
+	}
+
+	// A failed repack keeps the previous output and leaves no temporary file
+	broken := filepath.Join(dir, "broken.xpz")
+	if err := os.WriteFile(broken, archiveBytes(t, map[string]string{"export.xml": "<ExportFile><Objects>"}), 0644); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := os.ReadFile(output)
+	if _, err := RepackFile(broken, output, stub); err == nil {
+		t.Fatal("Expected malformed XML to fail the repack")
+	}
+	if after, _ := os.ReadFile(output); !bytes.Equal(after, before) {
+		t.Error("Expected the previous output to be kept after a failure")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 3 {
+		t.Errorf("Expected no temporary files left, got %v", entries)
+	}
+}
@@ -6,19 +6,23 @@ import (
 
 	"github.com/antchfx/xmlquery"
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/parser"
 )
 
 // Pre-compiled regular expressions for performance
 var (
 	parmRegex      = regexp.MustCompile(`(?i)parm\s*\((.*?)\)`)
-	paramRegex     = regexp.MustCompile(`(?i)^(in|out|inout)\s*:\s*&(.+)$`)
+	paramRegex     = regexp.MustCompile(`(?i)^(?:(in|out|inout)\s*:\s*)?&(.+)$`)
 	directionRegex = regexp.MustCompile(`(?i)\b(in|out|inout)\s*:`)
-	directionMatch = regexp.MustCompile(`(?i)\b(in|out|inout)`)
+	directionMatch = regexp.MustCompile(`(?i)\b(inout|in|out)`)
 	colonSpaceRegex = regexp.MustCompile(`:\s+&`)
 	commaSpaceRegex = regexp.MustCompile(`,\s*`)
 	typeColonRegex = regexp.MustCompile(`:`)
 )
 
+// maxSignatureLength caps the raw signature kept for oversized Parm rules
+const maxSignatureLength = 4096
+
 // Signature represents a procedure's parameter signature
 type Signature struct {
 	Parameters     []model.ParameterDoc
@@ -38,6 +42,9 @@ func ExtractProcedureSignature(objNode *xmlquery.Node, procedureName string) Sig
 	// Try 2: Extract from Variables with IsParm="true" (legacy format)
 	if sig := extractFromIsParmVariables(objNode, procedureName); sig.Parameters != nil {
 		sig.ExtractionMode = "IsParm"
+		source := GetText(objNode, "//Part[@type='"+GXPartSourceCode+"']/Source")
+		sig.Parameters = InferDirections(sig.Parameters, source)
+		sig.RawSignature = buildRawSignature(procedureName, sig.Parameters)
 		return sig
 	}
 
@@ -102,7 +109,7 @@ func extractFromIsParmVariables(objNode *xmlquery.Node, procedureName string) Si
 		if isParm && name != "" {
 			params = append(params, model.ParameterDoc{
 				Name:        name,
-				Direction:   "IN", // Default direction for IsParm fallback
+				Direction:   "IN", // Default direction for IsParm fallback, see InferDirections
 				Type:        varType,
 				Description: description,
 			})
@@ -124,7 +131,10 @@ func extractFromIsParmVariables(objNode *xmlquery.Node, procedureName string) Si
 
 // parseParmString parses a Parm(...) declaration from source text.
 // It handles various formats: parm(...), Parm(...), in:/In:, out:/Out:, etc.
+// At most parser.MaxParameters parameters are kept.
 func parseParmString(source, procedureName string) Signature {
+	source = strings.ToValidUTF8(source, "")
+
 	// Filter out commented lines (starting with //)
 	lines := strings.Split(source, "\n")
 	var activeLines []string
@@ -160,10 +170,14 @@ func parseParmString(source, procedureName string) Signature {
 			continue
 		}
 
-		// Parse direction:&Name or direction: &Name using pre-compiled regex
+		// Parse direction:&Name, direction: &Name or &Name using pre-compiled
+		// regex. A parameter without a direction is INOUT, as in GeneXus.
 		matches := paramRegex.FindStringSubmatch(part)
 		if len(matches) == 3 {
 			direction := strings.ToUpper(matches[1])
+			if direction == "" {
+				direction = "INOUT"
+			}
 			name := strings.TrimSpace(matches[2])
 
 			params = append(params, model.ParameterDoc{
@@ -171,12 +185,15 @@ func parseParmString(source, procedureName string) Signature {
 				Direction: direction,
 				Type:      "", // Type will be enriched later
 			})
+			if len(params) == parser.MaxParameters {
+				break
+			}
 		}
 	}
 
 	// Build raw signature
 	// Replace "parm"/"Parm" (case-insensitive) with actual procedure name
-	rawSig := parmRegex.ReplaceAllString(source, procedureName+"("+paramsStr+")")
+	rawSig := parmRegex.ReplaceAllLiteralString(source, procedureName+"("+paramsStr+")")
 	// Normalize directions to lowercase using pre-compiled regex
 	rawSig = directionRegex.ReplaceAllStringFunc(rawSig, func(match string) string {
 		dir := directionMatch.FindString(match)
@@ -187,6 +204,9 @@ func parseParmString(source, procedureName string) Signature {
 	// Ensure single space after commas: ",out:" -> ", out:"
 	rawSig = commaSpaceRegex.ReplaceAllString(rawSig, ", ")
 	rawSig = strings.TrimSpace(rawSig)
+	if len(rawSig) > maxSignatureLength {
+		rawSig = strings.ToValidUTF8(rawSig[:maxSignatureLength], "") + "..."
+	}
 
 	return Signature{
 		Parameters:   params,
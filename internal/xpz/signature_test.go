@@ -1,11 +1,14 @@
 package xpz
 
 import (
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/antchfx/xmlquery"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/lint"
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/parser"
 )
 
 func TestExtractProcedureSignature_ParmRule(t *testing.T) {
@@ -216,3 +219,147 @@ func TestParseParmString(t *testing.T) {
 		})
 	}
 }
+
+func TestParseParmString_RawSignatureKeepsInout(t *testing.T) {
+	// "inout" must be tried before its "in" prefix
+	sig := parseParmString("parm(in:&UserID, InOut: &Total, OUT:&Messages);", "GetUser")
+
+	expected := "GetUser(in:&UserID, inout:&Total, out:&Messages);"
+	if sig.RawSignature != expected {
+		t.Errorf("Expected raw signature %q, got %q", expected, sig.RawSignature)
+	}
+}
+
+func TestParseParmString_Undirected(t *testing.T) {
+	sig := parseParmString("parm(&UserID, in:&Mode);", "GetUser")
+
+	expected := []model.ParameterDoc{
+		{Name: "UserID", Direction: "INOUT"},
+		{Name: "Mode", Direction: "IN"},
+	}
+	if !reflect.DeepEqual(sig.Parameters, expected) {
+		t.Fatalf("Expected parameters %+v, got %+v", expected, sig.Parameters)
+	}
+
+	documented := []model.ParameterDoc{
+		{Name: "UserID", Direction: "INOUT"},
+		{Name: "Mode", Direction: "IN"},
+	}
+	if messages := parser.CompareParams(documented, sig.Parameters); len(messages) != 0 {
+		t.Errorf("Expected no mismatch for an undirected parameter, got %v", messages)
+	}
+}
+
+func TestExtractProcedureSignature_UndirectedLint(t *testing.T) {
+	doc, err := xmlquery.Parse(strings.NewReader(`<Object><Part type="9b0a32a3-de6d-4be1-a4dd-1b85d3741534"><Source><![CDATA[parm(&UserID);]]></Source></Part></Object>`))
+	if err != nil {
+		t.Fatalf("Failed to parse XML: %v", err)
+	}
+
+	obj := model.GXObject{
+		Name:          "GetUser",
+		Type:          "Procedure",
+		Path:          "GetUser",
+		SourceCode:    "/**\n * @summary Get a user\n * @param UserID INOUT Numeric - The id\n */",
+		Parameters:    ExtractProcedureSignature(doc, "GetUser").Parameters,
+		Documentation: &model.DocComment{},
+	}
+	for _, f := range lint.Run([]model.GXObject{obj}, nil) {
+		if f.Rule == "param-count-mismatch" {
+			t.Errorf("Expected no param-count-mismatch for parm(&UserID), got %+v", f)
+		}
+	}
+}
+
+func TestExtractDependencies(t *testing.T) {
+	xmlContent := `
+	<Object>
+		<Part type="e4c4ade7-53f0-4a56-bdfd-843735b66f47">
+			<Variable Name="Order">
+				<Properties>
+					<Property><Name>ATTCUSTOMTYPE</Name><Value>sdt:Order</Value></Property>
+				</Properties>
+			</Variable>
+			<Variable Name="Customer">
+				<Properties>
+					<Property><Name>ATTCUSTOMTYPE</Name><Value>bc:Customer</Value></Property>
+				</Properties>
+			</Variable>
+			<Variable Name="Messages">
+				<Properties>
+					<Property><Name>ATTCUSTOMTYPE</Name><Value>sdt:Messages, GeneXus.Common</Value></Property>
+				</Properties>
+			</Variable>
+			<Variable Name="Total">
+				<Properties>
+					<Property><Name>ATTCUSTOMTYPE</Name><Value>bas:Numeric</Value></Property>
+				</Properties>
+			</Variable>
+		</Part>
+	</Object>
+	`
+
+	doc, err := xmlquery.Parse(strings.NewReader(xmlContent))
+	if err != nil {
+		t.Fatalf("Failed to parse XML: %v", err)
+	}
+
+	deps := ExtractDependencies(doc)
+
+	expected := []model.Dependency{
+		{Name: "Order", Type: "SDT"},
+		{Name: "Customer", Type: "Transaction"},
+	}
+	if len(deps) != len(expected) {
+		t.Fatalf("Expected %d dependencies, got %d: %+v", len(expected), len(deps), deps)
+	}
+	for i := range expected {
+		if deps[i] != expected[i] {
+			t.Errorf("Dependency %d: expected %+v, got %+v", i, expected[i], deps[i])
+		}
+	}
+}
+
+func TestParseParmString_Literal(t *testing.T) {
+	// "$1" must not be expanded as a regexp replacement reference
+	sig := parseParmString("parm(in:&Amount$1);", "Pay")
+	if sig.RawSignature != "Pay(in:&Amount$1);" {
+		t.Errorf("Unexpected raw signature: %q", sig.RawSignature)
+	}
+}
+
+func TestParseParmString_Limits(t *testing.T) {
+	var parts []string
+	for i := 0; i < 1000; i++ {
+		parts = append(parts, "in:&P"+strings.Repeat("x", 10))
+	}
+	sig := parseParmString("parm("+strings.Join(parts, ", ")+");", "Big")
+	if len(sig.Parameters) != parser.MaxParameters {
+		t.Errorf("Expected %d parameters, got %d", parser.MaxParameters, len(sig.Parameters))
+	}
+	if len(sig.RawSignature) > maxSignatureLength+3 {
+		t.Errorf("Raw signature not truncated: %d bytes", len(sig.RawSignature))
+	}
+}
+
+func FuzzParseParmString(f *testing.F) {
+	f.Add("parm(in:&UserID, out:&UserName);")
+	f.Add("// parm(in:&Old);\nParm( INOUT : &A ,out:&B)")
+	f.Add("parm(in:&$0${1}, , inout:&)")
+	f.Add("\x00\xffparm(")
+
+	f.Fuzz(func(t *testing.T, source string) {
+		sig := parseParmString(source, "Proc")
+		if len(sig.Parameters) > parser.MaxParameters {
+			t.Errorf("%d parameters exceed the limit", len(sig.Parameters))
+		}
+		if len(sig.RawSignature) > maxSignatureLength+3 {
+			t.Errorf("raw signature of %d bytes exceeds the limit", len(sig.RawSignature))
+		}
+		for _, p := range sig.Parameters {
+			if p.Direction != "IN" && p.Direction != "OUT" && p.Direction != "INOUT" {
+				t.Errorf("unexpected direction %q", p.Direction)
+			}
+		}
+	})
+}
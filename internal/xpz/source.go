@@ -0,0 +1,81 @@
+package xpz
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+// exportSource is an export XML file, read from an XPZ entry or from disk
+type exportSource struct {
+	// name identifies the file in messages, relative to the archive or
+	// export directory
+	name     string
+	modified time.Time
+	open     func() (io.ReadCloser, error)
+}
+
+// read returns the whole content of the file, within opts.MaxEntrySize
+func (s exportSource) read(opts Options) ([]byte, error) {
+	r, err := s.open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(limitEntry(r, opts))
+}
+
+// fileSource returns the export XML file at path, reported as name
+func fileSource(path, name string, info fs.FileInfo) exportSource {
+	return exportSource{name: name, modified: info.ModTime(), open: func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}}
+}
+
+// isExportXML reports whether a file name has the .xml extension of export files
+func isExportXML(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".xml")
+}
+
+// extractDir parses the export XML files under an already extracted
+// export directory, in path order, as if they were the entries of an XPZ
+func extractDir(ctx context.Context, dir string, opts Options) (*ExtractResult, error) {
+	var sources []exportSource
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isExportXML(path) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if opts.MaxEntrySize > 0 && info.Size() > opts.MaxEntrySize {
+			utils.Warning("Skipping export file %s: %d bytes, over the %d byte limit", name, info.Size(), opts.MaxEntrySize)
+			return nil
+		}
+		sources = append(sources, fileSource(path, name, info))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read export directory: %w", err)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no export XML files found in %s", dir)
+	}
+	return extractSources(ctx, sources, opts)
+}
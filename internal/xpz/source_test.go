@@ -0,0 +1,51 @@
+package xpz
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractDirectory(t *testing.T) {
+	dir := t.TempDir()
+	saveUser := strings.Replace(testExportXML, `name="GetUser"`, `name="SaveUser"`, 1)
+	if err := os.MkdirAll(filepath.Join(dir, "part2"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{
+		"export.xml":       testExportXML,
+		"part2/export.xml": saveUser,
+		"notes.txt":        "not an export",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := ExtractWithOptions(dir, Options{})
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if result.KBName != "TestKB" {
+		t.Errorf("Expected KB name TestKB, got %q", result.KBName)
+	}
+	if len(result.Objects) != 2 || result.Objects[0].Path != "GetUser" || result.Objects[1].Path != "SaveUser" {
+		t.Errorf("Expected GetUser and SaveUser in path order, got %+v", result.Objects)
+	}
+	if result.Info.ExportDate.IsZero() {
+		t.Error("Expected the export date to fall back to the file time")
+	}
+
+	result, err = Extract(filepath.Join(dir, "part2", "export.xml"))
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Path != "SaveUser" {
+		t.Errorf("Expected SaveUser from the raw export XML, got %+v", result.Objects)
+	}
+
+	if _, err := Extract(t.TempDir()); err == nil || !strings.Contains(err.Error(), "no export XML files") {
+		t.Errorf("Expected an error for a directory without exports, got %v", err)
+	}
+}
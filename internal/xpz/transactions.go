@@ -0,0 +1,147 @@
+package xpz
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// attributeDefinitions reads the type and description of every Attribute
+// object in an export, keyed by attribute name
+func attributeDefinitions(doc *xmlquery.Node) map[string]model.Attribute {
+	defs := make(map[string]model.Attribute)
+	for _, node := range FindAll(doc, "//Objects/Object[@type='"+GXTypeAttribute+"']") {
+		attr := model.Attribute{
+			Name:        GetAttrDirect(node, "name"),
+			Description: GetAttrDirect(node, "description"),
+		}
+		for _, prop := range xmlquery.Find(node, "//Property") {
+			value := GetText(prop, "Value")
+			switch GetText(prop, "Name") {
+			case "ATTCUSTOMTYPE":
+				attr.Type = cleanType(value)
+			case "idBasedOn":
+				// A domain takes precedence over the underlying basic type
+				if domain, ok := strings.CutPrefix(value, "Domain:"); ok {
+					attr.Type = domain
+				}
+			case "Description":
+				if attr.Description == "" {
+					attr.Description = value
+				}
+			}
+		}
+		if attr.Name != "" {
+			defs[attr.Name] = attr
+		}
+	}
+	return defs
+}
+
+// ExtractStructure reads the levels of a Transaction's Structure part.
+// Attribute types and descriptions come from defs.
+func ExtractStructure(objNode *xmlquery.Node, defs map[string]model.Attribute) []model.Level {
+	structure := xmlquery.FindOne(objNode, "//Part[@type='"+GXPartStructure+"']")
+	if structure == nil {
+		return nil
+	}
+	var levels []model.Level
+	for _, levelNode := range xmlquery.Find(structure, "Level") {
+		levels = append(levels, parseLevel(levelNode, defs))
+	}
+	return levels
+}
+
+// parseLevel reads a structure Level element and its nested levels
+func parseLevel(node *xmlquery.Node, defs map[string]model.Attribute) model.Level {
+	level := model.Level{
+		Name:        firstAttr(node, "Name", "name"),
+		Description: firstAttr(node, "Description", "description"),
+	}
+	for _, child := range xmlquery.Find(node, "Attribute|Level") {
+		if child.Data == "Level" {
+			level.Levels = append(level.Levels, parseLevel(child, defs))
+			continue
+		}
+		name := strings.TrimSpace(child.InnerText())
+		if name == "" {
+			name = firstAttr(child, "Name", "name")
+		}
+		if name == "" {
+			continue
+		}
+		attr := defs[name]
+		attr.Name = name
+		attr.Key = strings.EqualFold(firstAttr(child, "key", "Key"), "true")
+		level.Attributes = append(level.Attributes, attr)
+	}
+	return level
+}
+
+// firstAttr returns the first non-empty attribute among names
+func firstAttr(node *xmlquery.Node, names ...string) string {
+	for _, name := range names {
+		if value := GetAttrDirect(node, name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// LinkForeignKeys marks the attributes of every Transaction level that hold
+// the primary key of another Transaction as foreign keys. A level references
+// a Transaction when it contains all of that Transaction's key attributes.
+func LinkForeignKeys(objects []model.GXObject) {
+	primaryKeys := make(map[string][]string)
+	var names []string
+	for _, obj := range objects {
+		if obj.Type != "Transaction" || len(obj.Levels) == 0 {
+			continue
+		}
+		if keys := obj.Levels[0].Keys(); len(keys) > 0 {
+			primaryKeys[obj.Path] = keys
+			names = append(names, obj.Path)
+		}
+	}
+
+	for i := range objects {
+		if objects[i].Type == "Transaction" {
+			linkLevels(objects[i].Path, objects[i].Levels, names, primaryKeys)
+		}
+	}
+}
+
+// linkLevels sets References on the attributes of levels owned by owner
+func linkLevels(owner string, levels []model.Level, names []string, primaryKeys map[string][]string) {
+	for l := range levels {
+		level := &levels[l]
+		present := make(map[string]bool)
+		for _, attr := range level.Attributes {
+			present[attr.Name] = true
+		}
+		for _, name := range names {
+			keys := primaryKeys[name]
+			if name == owner || !containsAll(present, keys) {
+				continue
+			}
+			for a := range level.Attributes {
+				if level.Attributes[a].References == "" && slices.Contains(keys, level.Attributes[a].Name) {
+					level.Attributes[a].References = name
+				}
+			}
+		}
+		linkLevels(owner, level.Levels, names, primaryKeys)
+	}
+}
+
+// containsAll reports whether set holds every name
+func containsAll(set map[string]bool, names []string) bool {
+	for _, name := range names {
+		if !set[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package xpz
+
+import (
+	"context"
+	"testing"
+)
+
+const testTransactionsXML = `<?xml version="1.0" encoding="utf-8"?>
+<ExportFile>
+  <Objects>
+    <Object name="Customer" type="1db606f2-af09-4cf9-a3b5-b481519d28f6" parent="Sales">
+      <Part type="264be5fb-1b28-4b25-a598-6ca900dd059f">
+        <Level Name="Customer" Type="Customer" Description="Customer">
+          <Attribute key="True">CustomerId</Attribute>
+          <Attribute>CustomerName</Attribute>
+        </Level>
+      </Part>
+    </Object>
+    <Object name="Invoice" type="1db606f2-af09-4cf9-a3b5-b481519d28f6" parent="Sales">
+      <Part type="264be5fb-1b28-4b25-a598-6ca900dd059f">
+        <Level Name="Invoice">
+          <Attribute key="True">InvoiceId</Attribute>
+          <Attribute>CustomerId</Attribute>
+          <Level Name="InvoiceLine" Description="Lines">
+            <Attribute key="True">InvoiceLineId</Attribute>
+          </Level>
+        </Level>
+      </Part>
+    </Object>
+    <Object name="CustomerId" type="adbb33c9-0906-4971-833c-998de27e0676" description="Customer Id">
+      <Properties>
+        <Property><Name>ATTCUSTOMTYPE</Name><Value>bas:Numeric</Value></Property>
+        <Property><Name>idBasedOn</Name><Value>Domain:Id</Value></Property>
+      </Properties>
+    </Object>
+    <Object name="CustomerName" type="adbb33c9-0906-4971-833c-998de27e0676">
+      <Properties>
+        <Property><Name>ATTCUSTOMTYPE</Name><Value>bas:Character</Value></Property>
+        <Property><Name>Description</Name><Value>Customer name</Value></Property>
+      </Properties>
+    </Object>
+  </Objects>
+</ExportFile>`
+
+func TestExtractTransactions(t *testing.T) {
+	archive := buildArchive(t, map[string]string{"export.xml": testTransactionsXML})
+	result, err := extractArchive(context.Background(), archive, Options{})
+	if err != nil {
+		t.Fatalf("extractArchive failed: %v", err)
+	}
+	if len(result.Objects) != 2 {
+		t.Fatalf("Expected 2 transactions, got %+v", result.Objects)
+	}
+
+	customer := result.Objects[0]
+	if customer.Type != "Transaction" || customer.Documentation.Package != "Sales" || len(customer.Levels) != 1 {
+		t.Fatalf("Unexpected transaction: %+v", customer)
+	}
+	id, name := customer.Levels[0].Attributes[0], customer.Levels[0].Attributes[1]
+	if !id.Key || id.Type != "Id" || id.Description != "Customer Id" {
+		t.Errorf("Unexpected key attribute: %+v", id)
+	}
+	if name.Key || name.Type != "Character" || name.Description != "Customer name" {
+		t.Errorf("Unexpected attribute: %+v", name)
+	}
+
+	invoice := result.Objects[1].Levels[0]
+	if fk := invoice.Attributes[1]; fk.Name != "CustomerId" || fk.Key || fk.References != "Customer" {
+		t.Errorf("Expected CustomerId to reference Customer, got %+v", fk)
+	}
+	if len(invoice.Levels) != 1 || invoice.Levels[0].Description != "Lines" || invoice.Levels[0].Keys()[0] != "InvoiceLineId" {
+		t.Errorf("Unexpected nested level: %+v", invoice.Levels)
+	}
+	if customer.Levels[0].Attributes[0].References != "" {
+		t.Error("Expected a transaction's own key not to reference itself")
+	}
+}
@@ -0,0 +1,141 @@
+package xpz
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// defaultTypesJSON maps the object type GUIDs of GeneXus exports to type names
+//
+//go:embed types.json
+var defaultTypesJSON []byte
+
+var (
+	// defaultTypes is the embedded GUID-to-type table
+	defaultTypes map[string]string
+
+	typesMu sync.RWMutex
+	// customTypes holds registered mappings, which take precedence over
+	// the defaults
+	customTypes = make(map[string]string)
+)
+
+func init() {
+	if err := json.Unmarshal(defaultTypesJSON, &defaultTypes); err != nil {
+		panic("xpz: invalid types.json: " + err.Error())
+	}
+}
+
+// RegisterType maps an object type GUID to a type name, adding a type or a
+// GUID used by another GeneXus version without a code change. Transactions
+// are parsed as such; objects of any other type are read like procedures
+// (source, Parm rule and variables). An empty name stops the GUID from
+// being recognized.
+func RegisterType(guid, name string) {
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	customTypes[normalizeGUID(guid)] = strings.TrimSpace(name)
+}
+
+// UnregisterType removes a registered mapping, restoring the default one
+func UnregisterType(guid string) {
+	typesMu.Lock()
+	defer typesMu.Unlock()
+	delete(customTypes, normalizeGUID(guid))
+}
+
+// objectTypeName returns the type name of an object type GUID, or "" when the
+// GUID is not recognized
+func objectTypeName(guid string) string {
+	name, _ := lookupType(guid)
+	return name
+}
+
+// lookupType returns the type name of an object type GUID and whether any
+// mapping knows the GUID; a GUID registered with an empty name is known
+// but not recognized
+func lookupType(guid string) (string, bool) {
+	guid = normalizeGUID(guid)
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+	if name, ok := customTypes[guid]; ok {
+		return name, true
+	}
+	name, ok := defaultTypes[guid]
+	return name, ok
+}
+
+// maxTypeSamples is how many object names are kept per unknown type
+const maxTypeSamples = 3
+
+// unknownTypes counts the objects of unknown type GUIDs
+type unknownTypes struct {
+	byGUID map[string]*model.UnknownType
+}
+
+func newUnknownTypes() *unknownTypes {
+	return &unknownTypes{byGUID: make(map[string]*model.UnknownType)}
+}
+
+// add counts count objects of an unknown type, keeping the first samples
+func (u *unknownTypes) add(guid string, count int, samples ...string) {
+	guid = normalizeGUID(guid)
+	t, ok := u.byGUID[guid]
+	if !ok {
+		t = &model.UnknownType{GUID: guid}
+		u.byGUID[guid] = t
+	}
+	t.Count += count
+	for _, name := range samples {
+		if len(t.Samples) < maxTypeSamples && !slices.Contains(t.Samples, name) {
+			t.Samples = append(t.Samples, name)
+		}
+	}
+}
+
+// list returns the unknown types, most frequent first
+func (u *unknownTypes) list() []model.UnknownType {
+	var types []model.UnknownType
+	for _, t := range u.byGUID {
+		types = append(types, *t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].Count != types[j].Count {
+			return types[i].Count > types[j].Count
+		}
+		return types[i].GUID < types[j].GUID
+	})
+	return types
+}
+
+// SupportedTypes returns the object type names the parser understands
+func SupportedTypes() []string {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+	seen := make(map[string]bool)
+	var names []string
+	for _, table := range []map[string]string{defaultTypes, customTypes} {
+		for guid, name := range table {
+			if custom, ok := customTypes[guid]; ok && custom != name {
+				continue
+			}
+			if name != "" && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// normalizeGUID lowercases a GUID and strips surrounding braces and spaces
+func normalizeGUID(guid string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(guid), "{}"))
+}
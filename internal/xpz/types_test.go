@@ -0,0 +1,100 @@
+package xpz
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+)
+
+func TestRegisterType(t *testing.T) {
+	const panelGUID = "0a1b2c3d-0000-4000-8000-000000000001"
+	const legacyGUID = "11111111-2222-3333-4444-555555555555"
+	panel := strings.NewReplacer(`name="GetUser"`, `name="WpUsers"`, GXTypeProcedure, panelGUID).Replace(testExportXML)
+	legacy := strings.NewReplacer(`name="GetUser"`, `name="OldProc"`, GXTypeProcedure, legacyGUID).Replace(testExportXML)
+	archive := buildArchive(t, map[string]string{"panel.xml": panel, "legacy.xml": legacy})
+
+	result, err := extractArchive(context.Background(), archive, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 0 {
+		t.Fatalf("Expected unknown type GUIDs to be skipped, got %+v", result.Objects)
+	}
+	if len(result.UnknownTypes) != 2 {
+		t.Fatalf("Expected both GUIDs reported as unknown types, got %+v", result.UnknownTypes)
+	}
+	for _, unknown := range result.UnknownTypes {
+		if unknown.Count != 1 || len(unknown.Samples) != 1 {
+			t.Errorf("Expected one sample object of %s, got %+v", unknown.GUID, unknown)
+		}
+		if unknown.GUID == panelGUID && unknown.Samples[0] != "WpUsers" {
+			t.Errorf("Expected WpUsers as the sample of the panel type, got %v", unknown.Samples)
+		}
+	}
+
+	RegisterType("{"+strings.ToUpper(panelGUID)+"}", "WebPanel")
+	RegisterType(legacyGUID, "Procedure")
+	defer UnregisterType(panelGUID)
+	defer UnregisterType(legacyGUID)
+
+	if !slices.Contains(SupportedTypes(), "WebPanel") {
+		t.Errorf("Expected WebPanel among the supported types, got %v", SupportedTypes())
+	}
+	if _, err := ParseTypes("webpanel"); err != nil {
+		t.Errorf("Expected a registered type to be accepted by --types: %v", err)
+	}
+
+	result, err = extractArchive(context.Background(), archive, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.UnknownTypes) != 0 {
+		t.Errorf("Expected registered GUIDs not to be reported, got %+v", result.UnknownTypes)
+	}
+	types := make(map[string]string)
+	for _, obj := range result.Objects {
+		types[obj.Path] = obj.Type
+	}
+	if types["WpUsers"] != "WebPanel" || types["OldProc"] != "Procedure" {
+		t.Errorf("Expected WpUsers as a WebPanel and OldProc as a Procedure, got %v", types)
+	}
+
+	RegisterType(GXTypeProcedure, "")
+	defer UnregisterType(GXTypeProcedure)
+	if objectTypeName(GXTypeProcedure) != "" {
+		t.Error("Expected an empty name to stop the GUID from being recognized")
+	}
+	UnregisterType(GXTypeProcedure)
+	if objectTypeName(GXTypeProcedure) != "Procedure" {
+		t.Error("Expected UnregisterType to restore the default mapping")
+	}
+}
+
+func TestRegisterType_SkipsProcedureChecks(t *testing.T) {
+	const providerGUID = "0a1b2c3d-0000-4000-8000-000000000002"
+	export := `<?xml version="1.0" encoding="utf-8"?>
+<ExportFile>
+  <Objects>
+    <Object name="DPUsers" type="` + providerGUID + `" parent="Users" />
+  </Objects>
+</ExportFile>`
+	archive := buildArchive(t, map[string]string{"provider.xml": export})
+
+	RegisterType(providerGUID, "DataProvider")
+	defer UnregisterType(providerGUID)
+
+	warnings, _ := utils.Counts()
+	result, err := extractArchive(context.Background(), archive, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Type != "DataProvider" {
+		t.Fatalf("Expected DPUsers kept as a DataProvider, got %+v", result.Objects)
+	}
+	if after, _ := utils.Counts(); after != warnings {
+		t.Errorf("Expected no procedure warnings for a DataProvider, got %d", after-warnings)
+	}
+}
@@ -0,0 +1,178 @@
+package xpz
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+// suspiciousRatio is the compression ratio above which an entry is reported
+// as a possible zip bomb; export XML rarely compresses better than 20:1
+const suspiciousRatio = 100
+
+// Issue is a problem found in an archive entry by Validate. Entry is empty
+// for problems with the archive as a whole.
+type Issue struct {
+	Entry   string
+	Problem string
+}
+
+// EntryPath returns the cleaned path of an archive entry name, or an error
+// when the name is absolute or climbs out of the archive with "..", so it
+// can never be joined into a directory outside the destination (zip slip)
+func EntryPath(name string) (string, error) {
+	slashed := strings.ReplaceAll(name, `\`, "/")
+	if slashed == "" || strings.HasPrefix(slashed, "/") || (len(slashed) >= 2 && slashed[1] == ':') {
+		return "", fmt.Errorf("absolute path %q", name)
+	}
+	cleaned := path.Clean(slashed)
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("path %q escapes the archive", name)
+	}
+	return cleaned, nil
+}
+
+// checkEntry returns why an entry must not be extracted: an unsafe name or a
+// declared size over opts.MaxEntrySize
+func checkEntry(file *zip.File, opts Options) error {
+	if _, err := EntryPath(file.Name); err != nil {
+		return err
+	}
+	if opts.MaxEntrySize > 0 && file.UncompressedSize64 > uint64(opts.MaxEntrySize) {
+		return fmt.Errorf("%d bytes uncompressed, over the %d byte limit", file.UncompressedSize64, opts.MaxEntrySize)
+	}
+	return nil
+}
+
+// errEntryTooLarge is returned reading an entry past Options.MaxEntrySize
+var errEntryTooLarge = errors.New("entry is larger than its declared size limit")
+
+// limitedReader fails with errEntryTooLarge after n bytes, so an entry whose
+// header understates its size cannot exhaust memory
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+// limitEntry returns r limited to opts.MaxEntrySize bytes, or r without a limit
+func limitEntry(r io.Reader, opts Options) io.Reader {
+	if opts.MaxEntrySize <= 0 {
+		return r
+	}
+	return &limitedReader{r: r, n: opts.MaxEntrySize}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.n < 0 {
+		return 0, errEntryTooLarge
+	}
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	if l.n < 0 {
+		return n, errEntryTooLarge
+	}
+	return n, err
+}
+
+// Validate checks the XPZ archive at path without extracting it and returns
+// the suspicious or corrupt entries it finds
+func Validate(path string, opts Options) ([]Issue, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, fmt.Errorf("XPZ file not found: %s", path)
+	}
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XPZ archive: %w", err)
+	}
+	defer reader.Close()
+	return validateArchive(&reader.Reader, opts), nil
+}
+
+// validateArchive checks every entry of an opened archive: its name, its
+// sizes, that it decompresses with a matching checksum and, for export XML,
+// that it is well-formed
+func validateArchive(archive *zip.Reader, opts Options) []Issue {
+	var issues []Issue
+	report := func(entry, format string, args ...any) {
+		issues = append(issues, Issue{Entry: entry, Problem: fmt.Sprintf(format, args...)})
+	}
+
+	seen := make(map[string]bool)
+	exports := 0
+	for _, file := range archive.File {
+		name, err := EntryPath(file.Name)
+		if err != nil {
+			report(file.Name, "unsafe name: %v", err)
+			continue
+		}
+		if seen[strings.ToLower(name)] {
+			report(file.Name, "duplicate entry")
+		}
+		seen[strings.ToLower(name)] = true
+
+		if file.Mode()&os.ModeSymlink != 0 {
+			report(file.Name, "symbolic link")
+			continue
+		}
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		if file.Flags&0x1 != 0 {
+			report(file.Name, "encrypted entry")
+			continue
+		}
+		if err := checkEntry(file, opts); err != nil {
+			report(file.Name, "too large: %v", err)
+			continue
+		}
+		if file.CompressedSize64 > 0 && file.UncompressedSize64 > 1<<20 && file.UncompressedSize64/file.CompressedSize64 > suspiciousRatio {
+			report(file.Name, "compression ratio %d:1 (possible zip bomb)", file.UncompressedSize64/file.CompressedSize64)
+		}
+
+		isExport := strings.HasSuffix(strings.ToLower(name), ".xml")
+		data, err := readLimitedEntry(file, opts, isExport)
+		if err != nil {
+			report(file.Name, "corrupt: %v", err)
+			continue
+		}
+		if !isExport {
+			continue
+		}
+		exports++
+		if text, err := utf8Bytes(data); err != nil {
+			report(file.Name, "corrupt: %v", err)
+		} else if err := wellFormed(text); err != nil {
+			report(file.Name, "%s", malformedReason(err, nil))
+		}
+	}
+
+	if exports == 0 {
+		report("", "no export XML files")
+	}
+	return issues
+}
+
+// readLimitedEntry reads an entry within opts.MaxEntrySize, checking its
+// checksum. Only export XML is kept in memory; other entries are discarded
+// once read.
+func readLimitedEntry(file *zip.File, opts Options, keep bool) ([]byte, error) {
+	entry, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer entry.Close()
+
+	r := limitEntry(entry, opts)
+	if !keep {
+		_, err = io.Copy(io.Discard, r)
+		return nil, err
+	}
+	return io.ReadAll(r)
+}
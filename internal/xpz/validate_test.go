@@ -0,0 +1,109 @@
+package xpz
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestEntryPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  bool
+	}{
+		{"export.xml", "export.xml", false},
+		{"dir/./export.xml", "dir/export.xml", false},
+		{"dir/../export.xml", "export.xml", false},
+		{"../export.xml", "", true},
+		{`..\..\evil.xml`, "", true},
+		{"dir/../../evil.xml", "", true},
+		{"/etc/passwd", "", true},
+		{`C:\Windows\evil.xml`, "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		result, err := EntryPath(tt.name)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("EntryPath(%q) error = %v, wantErr %v", tt.name, err, tt.wantErr)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("EntryPath(%q) = %q, want %q", tt.name, result, tt.expected)
+		}
+	}
+}
+
+func TestValidateArchive(t *testing.T) {
+	archive := buildArchive(t, map[string]string{
+		"export.xml":       testExportXML,
+		"../../evil.xml":   testExportXML,
+		"Export.XML":       testExportXML,
+		"broken.xml":       "<ExportFile><Objects></ExportFile>",
+		"large/data.bin":   strings.Repeat("x", 2048),
+		"readme/notes.txt": "notes",
+	})
+
+	issues := validateArchive(archive, Options{MaxEntrySize: 1024})
+	problems := make(map[string]string)
+	for _, issue := range issues {
+		problems[issue.Entry] = issue.Problem
+	}
+	expected := map[string]string{
+		"../../evil.xml": `unsafe name: path "../../evil.xml" escapes the archive`,
+		"broken.xml":     "malformed XML on line 1: element <Objects> closed by </ExportFile>",
+		"large/data.bin": "too large: 2048 bytes uncompressed, over the 1024 byte limit",
+	}
+	// Map iteration decides which of the two case-insensitive names comes second
+	for _, name := range []string{"export.xml", "Export.XML"} {
+		if problems[name] == "duplicate entry" {
+			expected[name] = "duplicate entry"
+		}
+	}
+	if len(expected) != 4 {
+		t.Errorf("Expected export.xml and Export.XML to be reported as duplicates, got %+v", issues)
+	}
+	if len(issues) != len(expected) {
+		t.Errorf("Expected %d issues, got %+v", len(expected), issues)
+	}
+	for entry, problem := range expected {
+		if problems[entry] != problem {
+			t.Errorf("Expected %q for %s, got %q", problem, entry, problems[entry])
+		}
+	}
+
+	issues = validateArchive(buildArchive(t, map[string]string{"notes.txt": "notes"}), Options{})
+	if len(issues) != 1 || issues[0].Entry != "" || issues[0].Problem != "no export XML files" {
+		t.Errorf("Expected an archive without exports to be reported, got %+v", issues)
+	}
+}
+
+func TestExtractArchiveSkipsUnsafeEntries(t *testing.T) {
+	renamed := strings.Replace(testExportXML, `name="GetUser"`, `name="EvilUser"`, 1)
+	archive := buildArchive(t, map[string]string{
+		"export.xml":     testExportXML,
+		"../evil.xml":    renamed,
+		"big/export.xml": strings.Replace(renamed, "EvilUser", "BigUser", 1) + strings.Repeat(" ", 4096),
+	})
+
+	result, err := extractArchive(context.Background(), archive, Options{MaxEntrySize: int64(len(testExportXML) + 100)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Path != "GetUser" {
+		t.Errorf("Expected only GetUser to be extracted, got %+v", result.Objects)
+	}
+}
+
+func TestLimitEntry(t *testing.T) {
+	data, err := io.ReadAll(limitEntry(strings.NewReader("0123456789"), Options{MaxEntrySize: 10}))
+	if err != nil || string(data) != "0123456789" {
+		t.Errorf("Expected an entry at the limit to be read, got %q, %v", data, err)
+	}
+	_, err = io.ReadAll(limitEntry(strings.NewReader("0123456789!"), Options{MaxEntrySize: 10}))
+	if !errors.Is(err, errEntryTooLarge) {
+		t.Errorf("Expected errEntryTooLarge past the limit, got %v", err)
+	}
+}
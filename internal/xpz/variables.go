@@ -0,0 +1,41 @@
+package xpz
+
+import (
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// ExtractVariables lists the variables declared in an object's Variables
+// part, in declaration order
+func ExtractVariables(objNode *xmlquery.Node) []model.Variable {
+	variablesPart := xmlquery.FindOne(objNode, "//Part[@type='"+GXPartVariables+"']")
+	if variablesPart == nil {
+		return nil
+	}
+
+	var variables []model.Variable
+	for _, varNode := range xmlquery.Find(variablesPart, "//Variable") {
+		variable := model.Variable{Name: GetAttrDirect(varNode, "Name")}
+		for _, prop := range xmlquery.Find(varNode, "Properties/Property") {
+			value := GetText(prop, "Value")
+			switch GetText(prop, "Name") {
+			case "Name":
+				if variable.Name == "" {
+					variable.Name = value
+				}
+			case "ATTCUSTOMTYPE":
+				variable.Type = cleanType(value)
+			case "Description":
+				variable.Description = value
+			case "AttCollection":
+				variable.IsCollection = strings.EqualFold(value, "true")
+			}
+		}
+		if variable.Name != "" {
+			variables = append(variables, variable)
+		}
+	}
+	return variables
+}
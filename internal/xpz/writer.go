@@ -0,0 +1,280 @@
+package xpz
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+// typeGUIDs maps object type names to the GUIDs written to exports. It
+// covers more types than the extractor parses.
+var typeGUIDs = map[string]string{
+	"Procedure":   GXTypeProcedure,
+	"Transaction": GXTypeTransaction,
+}
+
+// Writer builds XPZ archives, either from model objects or by copying the
+// entries of another archive
+type Writer struct {
+	archive *zip.Writer
+}
+
+// NewWriter returns a Writer writing an XPZ archive to w. Call Close to
+// finish the archive.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{archive: zip.NewWriter(w)}
+}
+
+// WriteExport adds an export XML entry (e.g. "export.xml") holding objects.
+// Objects are written in the given order and can be extracted again.
+func (w *Writer) WriteExport(name, kbName string, objects []model.GXObject) error {
+	data, err := ExportXML(kbName, objects)
+	if err != nil {
+		return err
+	}
+	return w.writeEntry(zip.FileHeader{Name: name, Method: zip.Deflate}, data)
+}
+
+// Copy adds an entry of another archive without decompressing it
+func (w *Writer) Copy(file *zip.File) error {
+	if err := w.archive.Copy(file); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", file.Name, err)
+	}
+	return nil
+}
+
+// writeEntry adds an entry with the given header and content
+func (w *Writer) writeEntry(header zip.FileHeader, data []byte) error {
+	entry, err := w.archive.CreateHeader(&header)
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(data)
+	return err
+}
+
+// Close finishes the archive. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	return w.archive.Close()
+}
+
+// ExportXML returns the export XML of a knowledge base holding objects. The
+// object name comes from Path, the description from XMLDescription, the KB
+// folder from the documented package and the user from the author. Without
+// Rules and Variables, parameters become the parm() rule and variables.
+// Transaction attributes with a type or description are also written as
+// Attribute objects.
+func ExportXML(kbName string, objects []model.GXObject) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	buf.WriteString("<ExportFile>\n")
+	buf.WriteString(fmt.Sprintf("  <Source><Version name=\"%s\" /></Source>\n", escapeAttr(kbName)))
+	buf.WriteString("  <Objects>\n")
+	for _, obj := range objects {
+		if err := writeObject(&buf, obj); err != nil {
+			return nil, err
+		}
+	}
+	writeAttributes(&buf, objects)
+	buf.WriteString("  </Objects>\n")
+	buf.WriteString("</ExportFile>\n")
+	return buf.Bytes(), nil
+}
+
+// writeObject appends the Object element of obj
+func writeObject(buf *bytes.Buffer, obj model.GXObject) error {
+	guid, ok := typeGUIDs[obj.Type]
+	if !ok {
+		return fmt.Errorf("cannot write %s: unsupported object type %q", obj.Path, obj.Type)
+	}
+	if obj.Path == "" {
+		return fmt.Errorf("cannot write %s object without a name", obj.Type)
+	}
+
+	buf.WriteString(fmt.Sprintf("    <Object name=\"%s\" type=\"%s\"", escapeAttr(obj.Path), guid))
+	if obj.GUID != "" {
+		buf.WriteString(fmt.Sprintf(" guid=\"%s\"", escapeAttr(obj.GUID)))
+	}
+	if obj.XMLDescription != "" {
+		buf.WriteString(fmt.Sprintf(" description=\"%s\"", escapeAttr(obj.XMLDescription)))
+	}
+	if obj.Parent != "" {
+		buf.WriteString(fmt.Sprintf(" parent=\"%s\"", escapeAttr(obj.Parent)))
+	}
+	if doc := obj.Documentation; doc != nil {
+		if doc.Package != "" && obj.Parent == "" {
+			buf.WriteString(fmt.Sprintf(" parent=\"%s\"", escapeAttr(doc.Package)))
+		}
+		if doc.Author != "" {
+			buf.WriteString(fmt.Sprintf(" user=\"%s\"", escapeAttr(doc.Author)))
+		}
+	}
+	buf.WriteString(">\n")
+
+	if obj.IsMain || obj.Exposure != "" {
+		buf.WriteString("      <Properties>")
+		if obj.IsMain {
+			writeProperty(buf, "IsMain", "True")
+		}
+		switch obj.Exposure {
+		case "":
+		case "REST", "SOAP":
+			writeProperty(buf, "ExposeAsWebService", "True")
+			if obj.Exposure == "REST" {
+				writeProperty(buf, "REST_PROTOCOL", "True")
+			}
+		default:
+			writeProperty(buf, "CALL_PROTOCOL", obj.Exposure)
+		}
+		buf.WriteString("</Properties>\n")
+	}
+
+	if obj.SourceCode != "" {
+		writePart(buf, GXPartSourceCode, obj.SourceCode)
+	}
+	if len(obj.Levels) > 0 {
+		buf.WriteString(fmt.Sprintf("      <Part type=\"%s\">\n", GXPartStructure))
+		for _, level := range obj.Levels {
+			writeLevel(buf, level, "        ")
+		}
+		buf.WriteString("      </Part>\n")
+	}
+	rules := obj.Rules
+	if rules == "" && len(obj.Parameters) > 0 {
+		rules = parmRule(obj.Parameters)
+	}
+	if rules != "" {
+		writePart(buf, GXPartRules, rules)
+	}
+
+	variables := obj.Variables
+	if len(variables) == 0 {
+		for _, param := range obj.Parameters {
+			variables = append(variables, model.Variable{Name: param.Name, Type: param.Type, Description: param.Description})
+		}
+	}
+	if len(variables) > 0 {
+		buf.WriteString(fmt.Sprintf("      <Part type=\"%s\">\n", GXPartVariables))
+		for _, variable := range variables {
+			writeVariable(buf, variable, obj.Dependencies)
+		}
+		buf.WriteString("      </Part>\n")
+	}
+
+	buf.WriteString("    </Object>\n")
+	return nil
+}
+
+// writeLevel appends a structure Level element and its nested levels
+func writeLevel(buf *bytes.Buffer, level model.Level, indent string) {
+	buf.WriteString(fmt.Sprintf("%s<Level Name=\"%s\"", indent, escapeAttr(level.Name)))
+	if level.Description != "" {
+		buf.WriteString(fmt.Sprintf(" Description=\"%s\"", escapeAttr(level.Description)))
+	}
+	buf.WriteString(">\n")
+	for _, attr := range level.Attributes {
+		key := ""
+		if attr.Key {
+			key = ` key="True"`
+		}
+		buf.WriteString(fmt.Sprintf("%s  <Attribute%s>%s</Attribute>\n", indent, key, sourceEscaper.Replace(attr.Name)))
+	}
+	for _, nested := range level.Levels {
+		writeLevel(buf, nested, indent+"  ")
+	}
+	buf.WriteString(indent + "</Level>\n")
+}
+
+// writeAttributes appends an Attribute object for every distinct
+// Transaction attribute with a type or description, in first-use order
+func writeAttributes(buf *bytes.Buffer, objects []model.GXObject) {
+	seen := make(map[string]bool)
+	var visit func(levels []model.Level)
+	visit = func(levels []model.Level) {
+		for _, level := range levels {
+			for _, attr := range level.Attributes {
+				if seen[attr.Name] || (attr.Type == "" && attr.Description == "") {
+					continue
+				}
+				seen[attr.Name] = true
+				buf.WriteString(fmt.Sprintf("    <Object name=\"%s\" type=\"%s\"", escapeAttr(attr.Name), GXTypeAttribute))
+				if attr.Description != "" {
+					buf.WriteString(fmt.Sprintf(" description=\"%s\"", escapeAttr(attr.Description)))
+				}
+				buf.WriteString("><Properties>")
+				if attr.Type != "" {
+					writeProperty(buf, "ATTCUSTOMTYPE", "bas:"+attr.Type)
+				}
+				buf.WriteString("</Properties></Object>\n")
+			}
+			visit(level.Levels)
+		}
+	}
+	for _, obj := range objects {
+		visit(obj.Levels)
+	}
+}
+
+// writePart appends a Part holding a Source element
+func writePart(buf *bytes.Buffer, partType, source string) {
+	buf.WriteString(fmt.Sprintf("      <Part type=\"%s\"><Source>%s</Source></Part>\n", partType, sourceEscaper.Replace(source)))
+}
+
+// parmRule returns the parm() rule declaring params
+func parmRule(params []model.ParameterDoc) string {
+	parts := make([]string, len(params))
+	for i, param := range params {
+		direction := strings.ToLower(param.Direction)
+		if direction == "" {
+			direction = "in"
+		}
+		parts[i] = direction + ":&" + param.Name
+	}
+	return "parm(" + strings.Join(parts, ", ") + ");"
+}
+
+// writeVariable appends a Variable definition. Types naming an SDT or
+// Transaction dependency get its prefix; other types are written as basic
+// types.
+func writeVariable(buf *bytes.Buffer, variable model.Variable, deps []model.Dependency) {
+	buf.WriteString(fmt.Sprintf("        <Variable Name=\"%s\"><Properties>", escapeAttr(variable.Name)))
+	writeProperty(buf, "Name", variable.Name)
+	if variable.Type != "" && variable.Type != "-" {
+		prefix := "bas"
+		for _, dep := range deps {
+			if dep.Name != variable.Type {
+				continue
+			}
+			for p, kind := range typePrefixes {
+				if kind == dep.Type {
+					prefix = p
+				}
+			}
+		}
+		writeProperty(buf, "ATTCUSTOMTYPE", prefix+":"+variable.Type)
+	}
+	if variable.Description != "" {
+		writeProperty(buf, "Description", variable.Description)
+	}
+	if variable.IsCollection {
+		writeProperty(buf, "AttCollection", "True")
+	}
+	buf.WriteString("</Properties></Variable>\n")
+}
+
+// writeProperty appends a Property element
+func writeProperty(buf *bytes.Buffer, name, value string) {
+	buf.WriteString("<Property><Name>" + name + "</Name><Value>" + sourceEscaper.Replace(value) + "</Value></Property>")
+}
+
+// escapeAttr encodes text for use in an XML attribute
+func escapeAttr(text string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
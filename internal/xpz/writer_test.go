@@ -0,0 +1,93 @@
+package xpz
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+)
+
+func TestWriterRoundTrip(t *testing.T) {
+	objects := []model.GXObject{
+		{
+			Type:           "Procedure",
+			Path:           "GetOrder",
+			XMLDescription: "Get <order>",
+			SourceCode:     "/**\n * @summary Gets an order\n */\n&Order.Load(&OrderId)",
+			Parameters: []model.ParameterDoc{
+				{Name: "OrderId", Direction: "IN", Type: "Numeric", Description: "Order id"},
+				{Name: "Order", Direction: "OUT", Type: "Order"},
+			},
+			Dependencies:  []model.Dependency{{Name: "Order", Type: "SDT"}},
+			Documentation: &model.DocComment{Package: "Sales", Author: "jane"},
+			IsMain:        true,
+			Exposure:      "REST",
+			GUID:          "8d3c2f4e-1f0b-4a6e-9d2a-5b7c0e9f1a23",
+		},
+		{Type: "Transaction", Path: "Customer", Levels: []model.Level{{
+			Name: "Customer",
+			Attributes: []model.Attribute{
+				{Name: "CustomerId", Type: "Numeric", Key: true},
+				{Name: "CustomerName", Description: "Customer <name>"},
+			},
+			Levels: []model.Level{{Name: "Phone", Attributes: []model.Attribute{{Name: "PhoneId", Key: true}}}},
+		}}},
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.WriteExport("export.xml", "Shop", objects); err != nil {
+		t.Fatalf("WriteExport failed: %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := extractArchive(context.Background(), archive, Options{})
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	if result.KBName != "Shop" || len(result.Objects) != 2 {
+		t.Fatalf("Unexpected result: %s with %d objects", result.KBName, len(result.Objects))
+	}
+
+	obj := result.Objects[0]
+	if obj.Name != "Get <order>" || obj.Documentation.Summary != "Gets an order" || obj.Documentation.Author != "jane" {
+		t.Errorf("Unexpected object: %+v", obj)
+	}
+	if obj.ParmSignature != "GetOrder(in:&OrderId, out:&Order);" {
+		t.Errorf("Unexpected signature %q", obj.ParmSignature)
+	}
+	if len(obj.Dependencies) != 1 || obj.Dependencies[0].Name != "Order" {
+		t.Errorf("Expected the SDT dependency to survive, got %+v", obj.Dependencies)
+	}
+	if obj.GUID != "8d3c2f4e-1f0b-4a6e-9d2a-5b7c0e9f1a23" {
+		t.Errorf("Expected the GUID to survive, got %q", obj.GUID)
+	}
+	if !obj.IsMain || obj.Exposure != "REST" {
+		t.Errorf("Expected a main program exposed as REST, got main=%v exposure=%q", obj.IsMain, obj.Exposure)
+	}
+
+	trn := result.Objects[1]
+	if trn.Type != "Transaction" || len(trn.Levels) != 1 {
+		t.Fatalf("Unexpected transaction: %+v", trn)
+	}
+	if got := trn.Levels[0]; len(got.Attributes) != 2 || got.Attributes[0].Type != "Numeric" || got.Attributes[1].Description != "Customer <name>" || len(got.Levels) != 1 {
+		t.Errorf("Unexpected structure: %+v", got)
+	}
+	if keys := trn.Levels[0].Levels[0].Keys(); len(keys) != 1 || keys[0] != "PhoneId" {
+		t.Errorf("Expected the nested level key to survive, got %v", keys)
+	}
+}
+
+func TestWriterUnsupportedType(t *testing.T) {
+	if _, err := ExportXML("KB", []model.GXObject{{Type: "Theme", Path: "Carmine"}}); err == nil {
+		t.Error("Expected an error for an unsupported object type")
+	}
+}
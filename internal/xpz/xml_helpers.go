@@ -1,9 +1,12 @@
 package xpz
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"strings"
 
 	"github.com/antchfx/xmlquery"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
 )
 
 // GetText returns the text content of the first node matching the XPath
@@ -13,6 +16,7 @@ func GetText(node *xmlquery.Node, xpath string) string {
 	}
 	found := xmlquery.FindOne(node, xpath)
 	if found == nil {
+		debugMiss(node, xpath)
 		return ""
 	}
 	return strings.TrimSpace(found.InnerText())
@@ -25,6 +29,7 @@ func GetAttr(node *xmlquery.Node, xpath, attr string) string {
 	}
 	found := xmlquery.FindOne(node, xpath)
 	if found == nil {
+		debugMiss(node, xpath)
 		return ""
 	}
 	for _, a := range found.Attr {
@@ -55,3 +60,18 @@ func FindAll(node *xmlquery.Node, xpath string) []*xmlquery.Node {
 	}
 	return xmlquery.Find(node, xpath)
 }
+
+// debugMiss logs an XPath query that matched nothing, naming the context object
+func debugMiss(node *xmlquery.Node, xpath string) {
+	if name := GetAttrDirect(node, "name"); name != "" {
+		utils.Debug("XPath miss on %s: %s", name, xpath)
+		return
+	}
+	utils.Debug("XPath miss: %s", xpath)
+}
+
+// hashNode returns a SHA-256 hash of a node's serialized XML
+func hashNode(node *xmlquery.Node) string {
+	sum := sha256.Sum256([]byte(node.OutputXML(true)))
+	return hex.EncodeToString(sum[:])
+}
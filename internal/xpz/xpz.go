@@ -2,10 +2,13 @@ package xpz
 
 import (
 	"archive/zip"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
@@ -14,7 +17,9 @@ import (
 
 // GeneXus object type GUIDs
 const (
-	GXTypeProcedure = "84a12160-f59b-4ad7-a683-ea4481ac23e9"
+	GXTypeProcedure   = "84a12160-f59b-4ad7-a683-ea4481ac23e9"
+	GXTypeTransaction = "1db606f2-af09-4cf9-a3b5-b481519d28f6"
+	GXTypeAttribute   = "adbb33c9-0906-4971-833c-998de27e0676"
 )
 
 // GeneXus Part type GUIDs
@@ -22,21 +27,59 @@ const (
 	GXPartSourceCode = "528d1c06-a9c2-420d-bd35-21dca83f12ff" // Source code part
 	GXPartRules      = "9b0a32a3-de6d-4be1-a4dd-1b85d3741534" // Rules/Parm part
 	GXPartVariables  = "e4c4ade7-53f0-4a56-bdfd-843735b66f47" // Variables part
+	GXPartStructure  = "264be5fb-1b28-4b25-a598-6ca900dd059f" // Transaction structure part
 )
 
 // ExtractResult contains the extraction results
 type ExtractResult struct {
 	Objects []model.GXObject
 	KBName  string
+
+	// Info is the metadata of the export
+	Info model.KBInfo
+
+	// Skipped lists objects left out because they exceeded a limit
+	Skipped []model.SkippedObject
+
+	// UnknownTypes lists the object type GUIDs no type mapping recognized,
+	// most frequent first
+	UnknownTypes []model.UnknownType
 }
 
 // Extract extracts and parses a GeneXus XPZ file
 // Returns extraction results including objects and KB name
 func Extract(path string) (*ExtractResult, error) {
+	return ExtractWithOptions(path, Options{})
+}
+
+// ExtractWithOptions extracts and parses a GeneXus XPZ file, parsing only
+// the objects selected by opts. path may also be a raw export XML file or a
+// directory holding an already extracted export.
+func ExtractWithOptions(path string, opts Options) (*ExtractResult, error) {
+	return ExtractContext(context.Background(), path, opts)
+}
+
+// ExtractContext is ExtractWithOptions stopping early with ctx.Err() when
+// ctx is cancelled or its deadline passes
+func ExtractContext(ctx context.Context, path string, opts Options) (*ExtractResult, error) {
 	// Validate that the file exists
-	if _, err := os.Stat(path); os.IsNotExist(err) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
 		return nil, fmt.Errorf("XPZ file not found: %s", path)
 	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot access %s: %w", path, err)
+	}
+
+	// Exports already unzipped, or raw export XML, bypass the archive
+	if info.IsDir() {
+		utils.Info("Reading export directory: %s", path)
+		return extractDir(ctx, path, opts)
+	}
+	if isExportXML(path) {
+		utils.Info("Reading export file: %s", path)
+		return extractSources(ctx, []exportSource{fileSource(path, filepath.Base(path), info)}, opts)
+	}
 
 	utils.Info("Opening XPZ file: %s", path)
 
@@ -47,88 +90,141 @@ func Extract(path string) (*ExtractResult, error) {
 	}
 	defer reader.Close()
 
-	// Create a temporary directory for extraction
-	tempDir, err := os.MkdirTemp("", "gxdocgen-*")
+	return extractArchive(ctx, &reader.Reader, opts)
+}
+
+// ExtractReader extracts and parses an XPZ archive of the given size read
+// from r, so content held in memory (HTTP uploads, object storage) does not
+// need to be written to a temporary file first
+func ExtractReader(r io.ReaderAt, size int64) (*ExtractResult, error) {
+	return ExtractReaderWithOptions(r, size, Options{})
+}
+
+// ExtractReaderWithOptions is ExtractReader parsing only the objects
+// selected by opts
+func ExtractReaderWithOptions(r io.ReaderAt, size int64, opts Options) (*ExtractResult, error) {
+	return ExtractReaderContext(context.Background(), r, size, opts)
+}
+
+// ExtractReaderContext is ExtractReaderWithOptions with cancellation
+func ExtractReaderContext(ctx context.Context, r io.ReaderAt, size int64, opts Options) (*ExtractResult, error) {
+	reader, err := zip.NewReader(r, size)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+		return nil, fmt.Errorf("failed to open XPZ archive: %w", err)
 	}
-	defer os.RemoveAll(tempDir) // Clean up temp directory
-	utils.Info("Extracting to temporary directory: %s", tempDir)
-
-	var objects []model.GXObject
-	kbName := ""
-
-	// Iterate through files in the archive
-	for _, file := range reader.File {
-		// Extract the file
-		extractPath := filepath.Join(tempDir, file.Name)
-
-		if file.FileInfo().IsDir() {
-			// Create directory
-			if err := os.MkdirAll(extractPath, os.ModePerm); err != nil {
-				return nil, fmt.Errorf("failed to create directory %s: %w", extractPath, err)
-			}
+	return extractArchive(ctx, reader, opts)
+}
+
+// extractArchive parses the XML entries of an opened XPZ archive, streaming
+// each entry straight into the XML parser without writing it to disk
+func extractArchive(ctx context.Context, archive *zip.Reader, opts Options) (*ExtractResult, error) {
+	var sources []exportSource
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() || !isExportXML(file.Name) {
+			utils.Verbose("Skipping archive entry %s", file.Name)
 			continue
 		}
-
-		// Create parent directories if needed
-		if err := os.MkdirAll(filepath.Dir(extractPath), os.ModePerm); err != nil {
-			return nil, fmt.Errorf("failed to create parent directory for %s: %w", extractPath, err)
+		if err := checkEntry(file, opts); err != nil {
+			utils.Warning("Skipping archive entry %s: %v", file.Name, err)
+			continue
 		}
+		sources = append(sources, exportSource{name: file.Name, modified: file.Modified, open: func() (io.ReadCloser, error) {
+			return file.Open()
+		}})
+	}
+	return extractSources(ctx, sources, opts)
+}
+
+// extractSources parses export XML files and merges them into one KB
+func extractSources(ctx context.Context, sources []exportSource, opts Options) (*ExtractResult, error) {
+	result := &ExtractResult{}
+	merger := newExportMerger(result)
 
-		// Extract file content
-		if err := extractFile(file, extractPath); err != nil {
-			return nil, fmt.Errorf("failed to extract %s: %w", file.Name, err)
+	for _, source := range sources {
+		if err := ctx.Err(); err != nil {
+			return nil, err
 		}
 
 		// Parse XML files to identify GeneXus objects
-		if strings.HasSuffix(strings.ToLower(file.Name), ".xml") {
-			// Check if this is the main GeneXus export file
-			parsedObjects, extractedKBName, err := parseGXExportFileXMLQuery(extractPath)
-			if err != nil {
-				utils.Warning("Failed to parse %s: %v", file.Name, err)
-				continue
-			}
-			if kbName == "" && extractedKBName != "" {
-				kbName = extractedKBName
-			}
-			if len(parsedObjects) > 0 {
-				// This is the main export file with all objects
-				objects = append(objects, parsedObjects...)
-				utils.Info("Found %d objects in %s", len(parsedObjects), file.Name)
-			}
+		entry, err := parseExportSource(ctx, source, opts)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
 		}
+		if err != nil {
+			utils.Warning("Failed to parse %s: %v", source.name, err)
+			continue
+		}
+		if len(entry.Objects) > 0 && entry.Info.ExportDate.IsZero() {
+			// Export files are written when the export is made
+			entry.Info.ExportDate = source.modified
+		}
+		merger.merge(source.name, entry)
 	}
 
-	utils.Success("Extracted %d GeneXus objects", len(objects))
-	return &ExtractResult{
-		Objects: objects,
-		KBName:  kbName,
-	}, nil
+	// Foreign keys can point to Transactions from other export files
+	LinkForeignKeys(result.Objects)
+	result.Info.Modules = Modules(result.Objects)
+
+	for _, skipped := range result.Skipped {
+		utils.Warning("Skipped %s: %s", skipped.Name, skipped.Reason)
+	}
+	if len(result.UnknownTypes) > 0 {
+		count := 0
+		for _, t := range result.UnknownTypes {
+			utils.Verbose("Unknown object type %s: %d objects (e.g. %s)", t.GUID, t.Count, strings.Join(t.Samples, ", "))
+			count += t.Count
+		}
+		utils.Info("Left out %d object(s) of %d unknown type(s); map them with \"objectTypes\" in the config", count, len(result.UnknownTypes))
+	}
+	utils.Success("Extracted %d GeneXus objects", len(result.Objects))
+	return result, nil
 }
 
-// extractFile extracts a single file from the zip archive
-func extractFile(file *zip.File, destPath string) error {
-	// Open the file in the archive
-	srcFile, err := file.Open()
-	if err != nil {
-		return err
+// Modules returns the sorted, distinct modules and folders holding objects
+func Modules(objects []model.GXObject) []string {
+	var modules []string
+	for _, obj := range objects {
+		if obj.Parent != "" && !slices.Contains(modules, obj.Parent) {
+			modules = append(modules, obj.Parent)
+		}
 	}
-	defer srcFile.Close()
+	sort.Strings(modules)
+	return modules
+}
 
-	// Create the destination file
-	destFile, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
+// parseExportSource parses a single export XML file. With Options.Recover,
+// a file that is not well-formed is parsed object by object instead of
+// being dropped.
+func parseExportSource(ctx context.Context, source exportSource, opts Options) (*ExtractResult, error) {
+	entry, err := source.open()
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer entry.Close()
+
+	result, err := parseGXExport(ctx, contextReader{ctx, limitEntry(entry, opts)}, opts)
+	if err == nil || !opts.Recover || ctx.Err() != nil {
+		return result, err
 	}
-	defer destFile.Close()
 
-	// Copy the content
-	_, err = io.Copy(destFile, srcFile)
-	return err
+	data, readErr := source.read(opts)
+	if readErr != nil {
+		return nil, err
+	}
+	utils.Warning("Failed to parse %s: %v; recovering its well-formed objects", source.name, err)
+	return recoverExport(ctx, data, opts)
 }
 
-// GeneXus object type GUIDs to human-readable names
-var gxTypeMap = map[string]string{
-	GXTypeProcedure: "Procedure",
+// contextReader fails reads once ctx is done, so decoding a huge entry
+// stops promptly on cancellation
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
 }
@@ -0,0 +1,217 @@
+package xpz
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testExportXML = `<?xml version="1.0" encoding="utf-8"?>
+<ExportFile>
+  <Source><Version name="TestKB" /></Source>
+  <Objects>
+    <Object name="GetUser" type="84a12160-f59b-4ad7-a683-ea4481ac23e9" description="Get user" parent="Users" user="jane">
+      <Part type="528d1c06-a9c2-420d-bd35-21dca83f12ff"><Source>/**
+ * @summary Gets a user
+ */
+&amp;User.Load(&amp;UserID)</Source></Part>
+      <Part type="9b0a32a3-de6d-4be1-a4dd-1b85d3741534"><Source>parm(in:&amp;UserID);</Source></Part>
+    </Object>
+  </Objects>
+</ExportFile>`
+
+// buildArchive creates an in-memory zip with the given entries
+func buildArchive(t *testing.T, entries map[string]string) *zip.Reader {
+	t.Helper()
+	data := archiveBytes(t, entries)
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reader
+}
+
+// archiveBytes returns the bytes of a zip with the given entries
+func archiveBytes(t *testing.T, entries map[string]string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range entries {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractArchive(t *testing.T) {
+	archive := buildArchive(t, map[string]string{
+		"export.xml":  testExportXML,
+		"readme.txt":  "not xml",
+		"broken.xml":  "<Objects><Object",
+		"assets/dir/": "",
+	})
+
+	result, err := extractArchive(context.Background(), archive, Options{})
+	if err != nil {
+		t.Fatalf("extractArchive failed: %v", err)
+	}
+
+	if result.KBName != "TestKB" {
+		t.Errorf("Expected KB name 'TestKB', got '%s'", result.KBName)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Path != "GetUser" {
+		t.Fatalf("Expected GetUser to be extracted, got %+v", result.Objects)
+	}
+	if doc := result.Objects[0].Documentation; doc == nil || doc.Summary != "Gets a user" {
+		t.Errorf("Unexpected documentation: %+v", doc)
+	}
+}
+
+func TestExtractReader(t *testing.T) {
+	data := archiveBytes(t, map[string]string{"export.xml": testExportXML})
+
+	result, err := ExtractReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ExtractReader failed: %v", err)
+	}
+	if result.KBName != "TestKB" || len(result.Objects) != 1 {
+		t.Errorf("Unexpected result: %s with %d objects", result.KBName, len(result.Objects))
+	}
+
+	if _, err := ExtractReader(bytes.NewReader([]byte("not a zip")), 9); err == nil {
+		t.Error("Expected an error for content that is not a zip archive")
+	}
+}
+
+func TestExtractArchiveLimits(t *testing.T) {
+	export := strings.Replace(testExportXML, "</Objects>", `<Object name="Other" type="84a12160-f59b-4ad7-a683-ea4481ac23e9">
+      <Part type="528d1c06-a9c2-420d-bd35-21dca83f12ff"><Source>&amp;x = 1</Source></Part>
+    </Object>
+  </Objects>`, 1)
+	archive := buildArchive(t, map[string]string{"export.xml": export})
+
+	result, err := extractArchive(context.Background(), archive, Options{MaxObjects: 1})
+	if err != nil {
+		t.Fatalf("extractArchive failed: %v", err)
+	}
+	if len(result.Objects) != 1 || len(result.Skipped) != 1 || result.Skipped[0].Name != "Other" {
+		t.Errorf("Expected Other to be skipped by the object limit, got %+v", result.Skipped)
+	}
+
+	result, err = extractArchive(context.Background(), archive, Options{MaxSourceSize: 10})
+	if err != nil {
+		t.Fatalf("extractArchive failed: %v", err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Path != "Other" {
+		t.Errorf("Expected only Other to fit the source limit, got %+v", result.Objects)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].Name != "GetUser" {
+		t.Errorf("Expected GetUser to be skipped by the source limit, got %+v", result.Skipped)
+	}
+}
+
+func TestExtractArchiveCancelled(t *testing.T) {
+	archive := buildArchive(t, map[string]string{"export.xml": testExportXML})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := extractArchive(ctx, archive, Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestExtractArchiveKeepsSameNameInOtherModules(t *testing.T) {
+	users := testExportXML[strings.Index(testExportXML, "<Object ") : strings.Index(testExportXML, "</Object>")+len("</Object>")]
+	admin := strings.Replace(users, `parent="Users"`, `parent="Admin"`, 1)
+	xml := strings.Replace(testExportXML, users, users+"\n"+admin+"\n"+users, 1)
+
+	result, err := extractArchive(context.Background(), buildArchive(t, map[string]string{"export.xml": xml}), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 2 {
+		t.Fatalf("Expected GetUser from Users and Admin, without the repeated Users entry, got %d object(s)", len(result.Objects))
+	}
+	if result.Objects[0].Parent != "Users" || result.Objects[1].Parent != "Admin" {
+		t.Errorf("Expected the parents to be kept, got %q and %q", result.Objects[0].Parent, result.Objects[1].Parent)
+	}
+}
+
+func TestExtractArchiveInfo(t *testing.T) {
+	xml := strings.Replace(testExportXML, `<Source><Version name="TestKB" /></Source>`,
+		`<KMW><MajorVersion>4</MajorVersion><MinorVersion>0</MinorVersion><Build>169212</Build></KMW>
+  <Source><Version name="TestKB" /><Environment name="Java Web" /></Source>`, 1)
+	exported := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.CreateHeader(&zip.FileHeader{Name: "export.xml", Method: zip.Deflate, Modified: exported})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.Write([]byte(xml)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := ExtractReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	info := result.Info
+	if info.GeneXusVersion != "4.0.169212" {
+		t.Errorf("Expected version 4.0.169212, got %q", info.GeneXusVersion)
+	}
+	if info.Environment != "Java Web" {
+		t.Errorf("Expected environment 'Java Web', got %q", info.Environment)
+	}
+	if !info.ExportDate.Equal(exported) {
+		t.Errorf("Expected export date %v, got %v", exported, info.ExportDate)
+	}
+	if len(info.Modules) != 1 || info.Modules[0] != "Users" {
+		t.Errorf("Expected modules [Users], got %v", info.Modules)
+	}
+}
+
+func TestExtractArchiveMergesSplitExports(t *testing.T) {
+	users := strings.Replace(testExportXML, `<Object name="GetUser"`, `<Object guid="AB-1" name="GetUser"`, 1)
+	// The second file repeats GetUser (GUID in another case), adds SaveUser
+	// and records no KB name
+	orders := strings.Replace(users, `<Source><Version name="TestKB" /></Source>`, "", 1)
+	orders = strings.Replace(orders, `guid="AB-1"`, `guid="ab-1"`, 1)
+	object := orders[strings.Index(orders, "<Object ") : strings.Index(orders, "</Object>")+len("</Object>")]
+	saveUser := strings.NewReplacer(`guid="ab-1"`, `guid="ab-2"`, `name="GetUser"`, `name="SaveUser"`).Replace(object)
+	orders = strings.Replace(orders, object, object+"\n"+saveUser, 1)
+
+	result, err := extractArchive(context.Background(), buildArchive(t, map[string]string{
+		"export1.xml": users,
+		"export2.xml": orders,
+	}), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.KBName != "TestKB" {
+		t.Errorf("Expected KB name 'TestKB', got %q", result.KBName)
+	}
+	var names []string
+	for _, obj := range result.Objects {
+		names = append(names, obj.Path)
+	}
+	if len(names) != 2 || !strings.Contains(strings.Join(names, ","), "SaveUser") {
+		t.Errorf("Expected GetUser once and SaveUser, got %v", names)
+	}
+}
@@ -0,0 +1,232 @@
+// Package gxdocgen is the public API of GXDocGen. It lets other Go tools
+// extract GeneXus XPZ exports and generate documentation without shelling
+// out to the gxdocgen binary.
+//
+//	kb, err := gxdocgen.ExtractXPZ("export.xpz", gxdocgen.ExtractOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	err = gxdocgen.Generate(kb, "docs", gxdocgen.GenerateOptions{})
+package gxdocgen
+
+import (
+	"context"
+	"io"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/generator"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/graph"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/model"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/parser"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/storage"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/utils"
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/xpz"
+)
+
+// Model types shared with the generator
+type (
+	// Object is a GeneXus object extracted from an export
+	Object = model.GXObject
+	// DocComment is the parsed /** */ documentation of an object
+	DocComment = model.DocComment
+	// Parameter is a documented or extracted procedure parameter
+	Parameter = model.ParameterDoc
+	// SkippedObject is an object left out because it hit a limit
+	SkippedObject = model.SkippedObject
+	// KBInfo is the metadata of an export (GeneXus version, date, modules)
+	KBInfo = model.KBInfo
+	// KnowledgeBase indexes objects for lookups by name, type, package and
+	// references
+	KnowledgeBase = model.KnowledgeBase
+)
+
+// GenerateOptions controls documentation generation (sorting, cheat sheet,
+// badges, incremental mode, ...)
+type GenerateOptions = generator.Options
+
+// BadgeThresholds sets the green/yellow limits of generated badges
+type BadgeThresholds = generator.BadgeThresholds
+
+// Storage receives generated files when set as GenerateOptions.Storage.
+// Implement it to publish elsewhere, e.g. to cloud object storage.
+type Storage = storage.Storage
+
+// MemoryStorage keeps generated files in memory; it can be served with
+// http.FS
+type MemoryStorage = storage.Memory
+
+// ZipStorage archives generated files to a zip file on Close
+type ZipStorage = storage.Zip
+
+// DirStorage returns a Storage writing to a local directory
+func DirStorage(root string) Storage {
+	return storage.Dir(root)
+}
+
+// NewMemoryStorage returns an empty in-memory Storage
+func NewMemoryStorage() *MemoryStorage {
+	return storage.NewMemory()
+}
+
+// NewZipStorage returns a Storage writing a zip archive to w when closed
+func NewZipStorage(w io.Writer) *ZipStorage {
+	return storage.NewZip(w)
+}
+
+// KB is the content of an extracted export
+type KB struct {
+	Name    string
+	Objects []Object
+	// Info is the metadata of the export, shown at the top of the README
+	Info KBInfo
+	// Skipped lists objects left out by MaxSourceSize or MaxObjects, or
+	// malformed objects skipped by Recover
+	Skipped []SkippedObject
+}
+
+// Index returns a KnowledgeBase for navigating the objects of kb. Call it
+// again after changing Objects.
+func (kb *KB) Index() *KnowledgeBase {
+	calls := graph.Build(kb.Objects)
+	return model.NewKnowledgeBase(kb.Name, kb.Objects, func(obj Object) []string {
+		return calls.Callees(obj.Path)
+	})
+}
+
+// ExtractOptions selects which objects are parsed
+type ExtractOptions struct {
+	// Types limits parsing to these object type names (e.g. "Procedure")
+	Types []string
+	// Include keeps only objects whose name or path matches one of these
+	// regular expressions
+	Include []string
+	// Exclude skips objects whose name or path matches any of these
+	// regular expressions
+	Exclude []string
+	// Packages keeps only objects in these packages or KB folders
+	Packages []string
+	// Jobs is the number of objects parsed concurrently
+	Jobs int
+	// MaxSourceSize skips objects with a larger source, in bytes
+	MaxSourceSize int
+	// MaxEntrySize skips archive entries larger than this once
+	// decompressed, in bytes
+	MaxEntrySize int64
+	// MaxObjects skips objects beyond this count per export file
+	MaxObjects int
+	// Recover parses malformed export files object by object, listing the
+	// broken objects in KB.Skipped
+	Recover bool
+}
+
+// LogLevel controls the console output of extraction and generation
+type LogLevel = utils.Level
+
+// Log levels, from least to most output
+const (
+	LogQuiet   = utils.LevelQuiet
+	LogNormal  = utils.LevelNormal
+	LogVerbose = utils.LevelVerbose
+	LogDebug   = utils.LevelDebug
+)
+
+// SetLogLevel sets the console log level for all later calls
+func SetLogLevel(level LogLevel) {
+	utils.SetLevel(level)
+}
+
+// SupportedTypes returns the object type names the extractor understands
+func SupportedTypes() []string {
+	return xpz.SupportedTypes()
+}
+
+// ExtractXPZ reads a GeneXus XPZ export and parses the selected objects
+func ExtractXPZ(path string, opts ExtractOptions) (*KB, error) {
+	return ExtractXPZContext(context.Background(), path, opts)
+}
+
+// ExtractXPZContext is ExtractXPZ returning ctx.Err() once ctx is done
+func ExtractXPZContext(ctx context.Context, path string, opts ExtractOptions) (*KB, error) {
+	xpzOpts, err := opts.compile()
+	if err != nil {
+		return nil, err
+	}
+	result, err := xpz.ExtractContext(ctx, path, xpzOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &KB{Name: result.KBName, Objects: result.Objects, Info: result.Info, Skipped: result.Skipped}, nil
+}
+
+// ExtractXPZReader is ExtractXPZ for an archive of the given size read from
+// r, e.g. an HTTP upload held in memory
+func ExtractXPZReader(r io.ReaderAt, size int64, opts ExtractOptions) (*KB, error) {
+	return ExtractXPZReaderContext(context.Background(), r, size, opts)
+}
+
+// ExtractXPZReaderContext is ExtractXPZReader returning ctx.Err() once ctx is done
+func ExtractXPZReaderContext(ctx context.Context, r io.ReaderAt, size int64, opts ExtractOptions) (*KB, error) {
+	xpzOpts, err := opts.compile()
+	if err != nil {
+		return nil, err
+	}
+	result, err := xpz.ExtractReaderContext(ctx, r, size, xpzOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &KB{Name: result.KBName, Objects: result.Objects, Info: result.Info, Skipped: result.Skipped}, nil
+}
+
+// WriteXPZ writes kb as an XPZ archive that ExtractXPZ and GeneXus can read.
+// Only Procedure and Transaction objects are supported.
+func WriteXPZ(w io.Writer, kb *KB) error {
+	archive := xpz.NewWriter(w)
+	if err := archive.WriteExport("export.xml", kb.Name, kb.Objects); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// compile converts the options to extractor options
+func (o ExtractOptions) compile() (xpz.Options, error) {
+	include, err := xpz.CompilePatterns(o.Include)
+	if err != nil {
+		return xpz.Options{}, err
+	}
+	exclude, err := xpz.CompilePatterns(o.Exclude)
+	if err != nil {
+		return xpz.Options{}, err
+	}
+	return xpz.Options{
+		Types:         o.Types,
+		Include:       include,
+		Exclude:       exclude,
+		Packages:      o.Packages,
+		Jobs:          o.Jobs,
+		MaxSourceSize: o.MaxSourceSize,
+		MaxEntrySize:  o.MaxEntrySize,
+		MaxObjects:    o.MaxObjects,
+		Recover:       o.Recover,
+	}, nil
+}
+
+// Generate writes Markdown documentation for kb to outputDir, or to
+// opts.Storage when set
+func Generate(kb *KB, outputDir string, opts GenerateOptions) error {
+	return GenerateContext(context.Background(), kb, outputDir, opts)
+}
+
+// GenerateContext is Generate returning ctx.Err() once ctx is done. The
+// README describes kb.Info unless opts.KBInfo is set.
+func GenerateContext(ctx context.Context, kb *KB, outputDir string, opts GenerateOptions) error {
+	if opts.KBInfo.IsZero() {
+		opts.KBInfo = kb.Info
+	}
+	return generator.GenerateDocsContext(ctx, kb.Objects, kb.Name, outputDir, opts)
+}
+
+// ParseComment parses the first /** */ block of a GeneXus source. It
+// returns nil when the source has no documentation block. A @param without a
+// direction has an empty Direction, since only Parm() knows it.
+func ParseComment(source string) (*DocComment, error) {
+	return parser.Parse(source)
+}
@@ -0,0 +1,100 @@
+package gxdocgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rubensantoniorosa2704/gxdocgen/internal/fixture"
+)
+
+func TestExtractAndGenerate(t *testing.T) {
+	SetLogLevel(LogQuiet)
+	defer SetLogLevel(LogNormal)
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "kb.xpz")
+	file, err := os.Create(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.Write(file, fixture.Options{KB: "Demo", Procedures: 6, Packages: 2}); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	kb, err := ExtractXPZ(input, ExtractOptions{Exclude: []string{"^Proc0006$"}})
+	if err != nil {
+		t.Fatalf("ExtractXPZ failed: %v", err)
+	}
+	if kb.Name != "Demo" || len(kb.Objects) != 5 {
+		t.Fatalf("Unexpected KB: %s with %d objects", kb.Name, len(kb.Objects))
+	}
+
+	index := kb.Index()
+	if procs := index.ByPackage("pkg01"); len(procs) != 3 {
+		t.Errorf("Expected 3 procedures in pkg01, got %d", len(procs))
+	}
+	if callers := index.ReferencedBy("Proc0003"); len(callers) != 1 || callers[0] != "Proc0001" {
+		t.Errorf("Expected Proc0003 to be referenced by Proc0001, got %v", callers)
+	}
+
+	output := filepath.Join(dir, "docs")
+	if err := Generate(kb, output, GenerateOptions{}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	for _, name := range []string{"Demo.md", "pkg01/Proc0001.md", "summary.json"} {
+		if _, err := os.Stat(filepath.Join(output, filepath.FromSlash(name))); err != nil {
+			t.Errorf("Expected %s to be generated: %v", name, err)
+		}
+	}
+
+	memory := NewMemoryStorage()
+	if err := Generate(kb, "memory", GenerateOptions{Storage: memory}); err != nil {
+		t.Fatalf("Generate to memory failed: %v", err)
+	}
+	if files := memory.Files(); len(files) == 0 || files[0] != ".gxdocgen-manifest.json" {
+		t.Errorf("Unexpected in-memory files: %v", files)
+	}
+	if _, err := os.Stat("memory"); !os.IsNotExist(err) {
+		t.Error("Expected nothing written to disk with a Storage")
+	}
+}
+
+func TestWriteXPZ(t *testing.T) {
+	SetLogLevel(LogQuiet)
+	defer SetLogLevel(LogNormal)
+
+	kb := &KB{Name: "Demo", Objects: []Object{{
+		Type:       "Procedure",
+		Path:       "GetUser",
+		SourceCode: "/**\n * @summary Get a user\n */\n&User.Load(&UserID)",
+		Parameters: []Parameter{{Name: "UserID", Direction: "IN", Type: "Numeric"}},
+	}}}
+
+	var buf bytes.Buffer
+	if err := WriteXPZ(&buf, kb); err != nil {
+		t.Fatalf("WriteXPZ failed: %v", err)
+	}
+	extracted, err := ExtractXPZReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()), ExtractOptions{})
+	if err != nil {
+		t.Fatalf("ExtractXPZReader failed: %v", err)
+	}
+	if len(extracted.Objects) != 1 || extracted.Objects[0].Documentation.Summary != "Get a user" {
+		t.Errorf("Unexpected round trip: %+v", extracted.Objects)
+	}
+}
+
+func TestExtractXPZInvalidPattern(t *testing.T) {
+	if _, err := ExtractXPZ("missing.xpz", ExtractOptions{Include: []string{"("}}); err == nil {
+		t.Error("Expected an error for an invalid include pattern")
+	}
+}
+
+func TestParseComment(t *testing.T) {
+	doc, err := ParseComment("/**\n * @summary Get a user\n */\n&x = 1")
+	if err != nil || doc == nil || doc.Summary != "Get a user" {
+		t.Errorf("Unexpected result: %+v, %v", doc, err)
+	}
+}